@@ -1,40 +1,65 @@
 package api
 
 import (
-	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
 	"simple_bank/internal/db"
+	"simple_bank/internal/token"
 
 	"github.com/gin-gonic/gin"
 )
 
 type createAccountRequest struct {
-	Owner    string `json:"owner" binding:"required"`
 	Currency string `json:"currency" binding:"required,currency"`
 	CountryCode int32 `json:"countryCode" binding:"required"`
 }
 
+// createAccount opens an account owned by the authenticated user
 func (server *Server) createAccount(ctx *gin.Context) {
 	var req createAccountRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	_, tier, ok := server.userTier(ctx, authPayload.Username)
+	if !ok {
+		return
+	}
+
+	count, err := server.store.CountAccountsByOwner(ctx, authPayload.Username)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if count >= tier.MaxAccounts {
+		err := fmt.Errorf("kyc tier %d allows at most %d open accounts", tier.Level, tier.MaxAccounts)
+		respondError(ctx, http.StatusForbidden, err)
 		return
 	}
 
 	arg := db.CreateAccountParams{
-		Owner:       req.Owner,
+		Owner:       authPayload.Username,
 		Currency:    req.Currency,
 		CountryCode: req.CountryCode,
 		Balance:     0,
 	}
 
-	account, err := (server.store).CreateAccount(ctx, arg)
+	account, err := (server.store).CreateAccountTx(ctx, arg)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if db.ErrorCode(err) == db.UniqueViolation {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
 }
 
 type getAccountRequest struct {
@@ -44,22 +69,26 @@ type getAccountRequest struct {
 func (server *Server) getAccount(ctx *gin.Context) {
 	var req getAccountRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeAccount(ctx, req.ID, anyHolder...) {
 		return
 	}
 
 	account, err := (server.store).GetAccount(ctx, req.ID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
 			return
 		}
 
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
 }
 
 type listAccountRequest struct {
@@ -70,20 +99,133 @@ type listAccountRequest struct {
 func (server *Server) listAccounts(ctx *gin.Context) {
 	var req listAccountRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
-	args := db.ListAccountsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	args := db.ListAccountsByHolderParams{
+		Username: authPayload.Username,
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
 	}
 
-	account, err := (server.store).ListAccounts(ctx, args)
+	account, err := (server.store).ListAccountsByHolder(ctx, args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(account, newAccountResponse))
+}
+
+type accountURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateAccountStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active frozen debit_blocked"`
+}
+
+// updateAccountStatus freezes, debit blocks or reactivates an account.
+// Closing goes through closeAccount so the balance is swept first,
+// and an account frozen by bank staff stays frozen until staff unfreeze it.
+func (server *Server) updateAccountStatus(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req updateAccountStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeAccount(ctx, uri.ID, db.HolderOwner) {
+		return
+	}
+
+	account, err := server.store.UpdateAccountStatusTx(ctx, db.UpdateAccountStatusTxParams{
+		ID:     uri.ID,
+		Status: req.Status,
+		SetBy:  db.FrozenByCustomer,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrAccountClosed) {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrFrozenByStaff) {
+			respondError(ctx, http.StatusForbidden, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
+}
+
+type closeAccountRequest struct {
+	SweepToAccountID int64 `json:"sweep_to_account_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) closeAccount(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req closeAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeAccount(ctx, uri.ID, db.HolderOwner) {
+		return
+	}
+
+	if req.SweepToAccountID == uri.ID {
+		err := fmt.Errorf("account [%d] cannot sweep its balance to itself", uri.ID)
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// the balance may only be swept to an account the caller owns too
+	if req.SweepToAccountID != 0 && !server.authorizeAccount(ctx, req.SweepToAccountID, db.HolderOwner) {
+		return
+	}
+
+	result, err := server.store.CloseAccountTx(ctx, db.CloseAccountTxParams{
+		AccountID:        uri.ID,
+		SweepToAccountID: req.SweepToAccountID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrRecordNotFound):
+			respondError(ctx, http.StatusNotFound, err)
+		case errors.Is(err, db.ErrAccountClosed), errors.Is(err, db.ErrAccountCannotClose):
+			respondError(ctx, http.StatusConflict, err)
+		case errors.Is(err, db.ErrSweepAccountRequired), errors.Is(err, db.ErrNegativeBalance), errors.Is(err, db.ErrCurrencyMismatch):
+			respondError(ctx, http.StatusUnprocessableEntity, err)
+		case errors.Is(err, db.ErrAccountCannotReceive):
+			respondError(ctx, http.StatusForbidden, err)
+		default:
+			respondError(ctx, http.StatusInternalServerError, err)
+		}
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, newCloseAccountResponse(result))
 }
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/token"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// anyHolder lets every holder of an account through, including viewers
+var anyHolder = []string{db.HolderOwner, db.HolderSignatory, db.HolderViewer}
+
+// authorizeAccount checks the authenticated user holds the account with one of the given roles.
+// It writes a 403 response and returns false otherwise.
+func (server *Server) authorizeAccount(ctx *gin.Context, accountID int64, roles ...string) bool {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	holder, err := server.store.GetAccountHolder(ctx, db.GetAccountHolderParams{
+		AccountID: accountID,
+		Username:  authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err := fmt.Errorf("account [%d] doesn't belong to the authenticated user", accountID)
+			respondError(ctx, http.StatusForbidden, err)
+			return false
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return false
+	}
+
+	if !slices.Contains(roles, holder.Role) {
+		err := fmt.Errorf("a %s of account [%d] is not allowed to do this", holder.Role, accountID)
+		respondError(ctx, http.StatusForbidden, err)
+		return false
+	}
+
+	return true
+}
+
+func (server *Server) listAccountHolders(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeAccount(ctx, uri.ID, anyHolder...) {
+		return
+	}
+
+	holders, err := server.store.ListAccountHolders(ctx, uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(holders, newAccountHolderResponse))
+}
+
+type addAccountHolderRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Role     string `json:"role" binding:"required,oneof=owner signatory viewer"`
+}
+
+func (server *Server) addAccountHolder(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req addAccountHolderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeAccount(ctx, uri.ID, db.HolderOwner) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	holder, err := server.store.AddAccountHolderTx(ctx, db.AddAccountHolderTxParams{
+		AccountID: uri.ID,
+		Username:  req.Username,
+		Role:      req.Role,
+		Actor:     authPayload.Username,
+	})
+	if err != nil {
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			respondError(ctx, http.StatusConflict, err)
+		case db.ForeignKeyViolation:
+			err := fmt.Errorf("user %s is not registered", req.Username)
+			respondError(ctx, http.StatusUnprocessableEntity, err)
+		default:
+			respondError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountHolderResponse(holder))
+}
+
+type accountHolderURI struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+func (server *Server) removeAccountHolder(ctx *gin.Context) {
+	var uri accountHolderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeAccount(ctx, uri.ID, db.HolderOwner) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	holder, err := server.store.RemoveAccountHolderTx(ctx, db.RemoveAccountHolderTxParams{
+		AccountID: uri.ID,
+		Username:  uri.Username,
+		Actor:     authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrLastOwner) {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountHolderResponse(holder))
+}
+
+func (server *Server) listAccountHolderEvents(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeAccount(ctx, uri.ID, db.HolderOwner) {
+		return
+	}
+
+	events, err := server.store.ListAccountHolderEvents(ctx, uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(events, newAccountHolderEventResponse))
+}
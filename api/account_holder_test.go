@@ -0,0 +1,230 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/util"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// expectHolder stubs the holder lookup authorizeAccount makes for the authenticated user
+func expectHolder(store *mock_db.MockStore, accountID int64, username, role string) {
+	store.EXPECT().
+		GetAccountHolder(gomock.Any(), gomock.Eq(db.GetAccountHolderParams{
+			AccountID: accountID,
+			Username:  username,
+		})).
+		Times(1).
+		Return(db.AccountHolder{AccountID: accountID, Username: username, Role: role}, nil)
+}
+
+func TestAddAccountHolderAPI(t *testing.T) {
+	account := randomAccount()
+	other := util.RandomOwner()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"username": other, "role": db.HolderSignatory},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					AddAccountHolderTx(gomock.Any(), gomock.Eq(db.AddAccountHolderTxParams{
+						AccountID: account.ID,
+						Username:  other,
+						Role:      db.HolderSignatory,
+						Actor:     account.Owner,
+					})).
+					Times(1).
+					Return(db.AccountHolder{AccountID: account.ID, Username: other, Role: db.HolderSignatory}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SignatoryCannotAdd",
+			body: gin.H{"username": other, "role": db.HolderViewer},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderSignatory)
+				store.EXPECT().AddAccountHolderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyHolder",
+			body: gin.H{"username": other, "role": db.HolderViewer},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					AddAccountHolderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AccountHolder{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownUser",
+			body: gin.H{"username": other, "role": db.HolderViewer},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					AddAccountHolderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AccountHolder{}, &pgconn.PgError{Code: db.ForeignKeyViolation})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidRole",
+			body: gin.H{"username": other, "role": "admin"},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().AddAccountHolderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/accounts/%d/holders", account.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account.Owner, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRemoveAccountHolderAPI(t *testing.T) {
+	account := randomAccount()
+	other := util.RandomOwner()
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: other,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					RemoveAccountHolderTx(gomock.Any(), gomock.Eq(db.RemoveAccountHolderTxParams{
+						AccountID: account.ID,
+						Username:  other,
+						Actor:     account.Owner,
+					})).
+					Times(1).
+					Return(db.AccountHolder{AccountID: account.ID, Username: other, Role: db.HolderViewer}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "NotHolder",
+			username: other,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					RemoveAccountHolderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AccountHolder{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "LastOwner",
+			username: account.Owner,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					RemoveAccountHolderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AccountHolder{}, db.ErrLastOwner)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "ViewerCannotRemove",
+			username: other,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderViewer)
+				store.EXPECT().RemoveAccountHolderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/holders/%s", account.ID, tc.username)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account.Owner, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -10,9 +10,12 @@ import (
 	"net/http/httptest"
 	"simple_bank/internal/db"
 	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/internal/kyc"
 	"simple_bank/util"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -34,6 +37,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "OK",
 			accountID: account.ID,
 			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderViewer)
 				store.EXPECT().
 					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
 					Times(1).
@@ -48,10 +52,11 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "NotFound",
 			accountID: account.ID,
 			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderViewer)
 				store.EXPECT().
 					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
 					Times(1).
-					Return(db.Account{}, sql.ErrNoRows)
+					Return(db.Account{}, db.ErrRecordNotFound)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -61,6 +66,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "InternalServerError",
 			accountID: account.ID,
 			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderViewer)
 				store.EXPECT().
 					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
 					Times(1).
@@ -70,6 +76,22 @@ func TestGetAccountAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:      "UnauthorizedUser",
+			accountID: account.ID,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					GetAccountHolder(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AccountHolder{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name:      "InvalidID",
 			accountID: 0,
@@ -96,13 +118,15 @@ func TestGetAccountAPI(t *testing.T) {
 			// store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
 
 			//start test server and send request
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			url := fmt.Sprintf("/accounts/%d", tc.accountID)
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account.Owner, time.Minute)
+
 			server.router.ServeHTTP(recorder, request)
 			// check response
 			tc.checkResponse(t, recorder)
@@ -120,6 +144,7 @@ func randomAccount() db.Account {
 		Owner:    util.RandomOwner(),
 		Balance:  util.RandomMoney(),
 		Currency: util.RandomCurrency(),
+		Status:   db.AccountActive,
 	}
 }
 
@@ -128,8 +153,347 @@ func requireBodyMatchAccount(t *testing.T, body *bytes.Buffer, account db.Accoun
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotAccount db.Account
+	var gotAccount accountResponse
 	err = json.Unmarshal(data, &gotAccount)
 	require.NoError(t, err)
-	require.Equal(t, account, gotAccount)
+	require.Equal(t, newAccountResponse(account), gotAccount)
+}
+
+func TestUpdateAccountStatusAPI(t *testing.T) {
+	account := randomAccount()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"status": db.AccountFrozen},
+			buildStubs: func(store *mock_db.MockStore) {
+				frozen := account
+				frozen.Status = db.AccountFrozen
+
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Eq(db.UpdateAccountStatusTxParams{ID: account.ID, Status: db.AccountFrozen, SetBy: db.FrozenByCustomer})).
+					Times(1).
+					Return(frozen, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AccountClosed",
+			body: gin.H{"status": db.AccountActive},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Account{}, db.ErrAccountClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "FrozenByStaff",
+			body: gin.H{"status": db.AccountActive},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Eq(db.UpdateAccountStatusTxParams{ID: account.ID, Status: db.AccountActive, SetBy: db.FrozenByCustomer})).
+					Times(1).
+					Return(db.Account{}, db.ErrFrozenByStaff)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "account_frozen_by_staff")
+			},
+		},
+		{
+			name: "NotOwner",
+			body: gin.H{"status": db.AccountFrozen},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderSignatory)
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "CloseNotAllowed",
+			body: gin.H{"status": db.AccountClosed},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/accounts/%d/status", account.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account.Owner, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCloseAccountAPI(t *testing.T) {
+	account := randomAccount()
+	sweepAccountID := account.ID + 1
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"sweep_to_account_id": sweepAccountID},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				expectHolder(store, sweepAccountID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					CloseAccountTx(gomock.Any(), gomock.Eq(db.CloseAccountTxParams{AccountID: account.ID, SweepToAccountID: sweepAccountID})).
+					Times(1).
+					Return(db.CloseAccountTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SweepAccountRequired",
+			body: gin.H{},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					CloseAccountTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CloseAccountTxResult{}, db.ErrSweepAccountRequired)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyClosed",
+			body: gin.H{},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					CloseAccountTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CloseAccountTxResult{}, db.ErrAccountClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "SweepToOthersAccount",
+			body: gin.H{"sweep_to_account_id": sweepAccountID},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					GetAccountHolder(gomock.Any(), gomock.Eq(db.GetAccountHolderParams{AccountID: sweepAccountID, Username: account.Owner})).
+					Times(1).
+					Return(db.AccountHolder{}, db.ErrRecordNotFound)
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "SweepToViewedAccount",
+			body: gin.H{"sweep_to_account_id": sweepAccountID},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				expectHolder(store, sweepAccountID, account.Owner, db.HolderViewer)
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "FrozenAccount",
+			body: gin.H{},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					CloseAccountTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CloseAccountTxResult{}, fmt.Errorf("account [%d] is frozen: %w", account.ID, db.ErrAccountCannotClose))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "account_cannot_close")
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			body: gin.H{"sweep_to_account_id": sweepAccountID},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				expectHolder(store, sweepAccountID, account.Owner, db.HolderOwner)
+				store.EXPECT().
+					CloseAccountTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CloseAccountTxResult{}, fmt.Errorf("account [%d] is in EUR, not USD: %w", sweepAccountID, db.ErrCurrencyMismatch))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "currency_mismatch")
+			},
+		},
+		{
+			name: "SweepToSelf",
+			body: gin.H{"sweep_to_account_id": account.ID},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/accounts/%d/close", account.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account.Owner, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateAccountAPI(t *testing.T) {
+	account := randomAccount()
+	user := db.User{Username: account.Owner, KycStatus: kyc.StatusVerified, KycTier: 1}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(account.Owner)).Times(1).Return(user, nil)
+				store.EXPECT().CountAccountsByOwner(gomock.Any(), gomock.Eq(account.Owner)).Times(1).Return(int64(0), nil)
+				store.EXPECT().
+					CreateAccountTx(gomock.Any(), gomock.Eq(db.CreateAccountParams{
+						Owner:       account.Owner,
+						Currency:    account.Currency,
+						CountryCode: 1,
+					})).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccount(t, recorder.Body, account)
+			},
+		},
+		{
+			name: "OwnerNotRegistered",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(account.Owner)).Times(1).Return(db.User{}, db.ErrRecordNotFound)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "TierAccountLimit",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(account.Owner)).Times(1).Return(user, nil)
+				store.EXPECT().
+					CountAccountsByOwner(gomock.Any(), gomock.Eq(account.Owner)).
+					Times(1).
+					Return(kyc.TierFor(user.KycTier).MaxAccounts, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(gin.H{
+				"currency":    account.Currency,
+				"countryCode": 1,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account.Owner, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
 }
@@ -0,0 +1,315 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"simple_bank/internal/db"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var errAccountNotFrozen = errors.New("account is not frozen")
+
+// adminPageRequest pages through the back-office listings, which allow larger pages than customer ones
+type adminPageRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+func (req adminPageRequest) offset() int32 {
+	return (req.PageID - 1) * req.PageSize
+}
+
+type searchUsersRequest struct {
+	Query string `form:"q"`
+	adminPageRequest
+}
+
+// searchUsers finds users whose username, email or full name contains the query
+func (server *Server) searchUsers(ctx *gin.Context) {
+	var req searchUsersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	users, err := server.store.SearchUsers(ctx, db.SearchUsersParams{
+		Query:  pgtype.Text{String: req.Query, Valid: req.Query != ""},
+		Limit:  req.PageSize,
+		Offset: req.offset(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	rsp := make([]userResponse, len(users))
+	for i, user := range users {
+		rsp[i] = newUserResponse(user)
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type usernameURI struct {
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+type adminUserResponse struct {
+	userResponse
+	Roles []userRoleResponse `json:"roles"`
+}
+
+func (server *Server) getAdminUser(ctx *gin.Context) {
+	var uri usernameURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, uri.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	roles, err := server.store.ListUserRoles(ctx, user.Username)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, adminUserResponse{
+		userResponse: newUserResponse(user),
+		Roles:        convertAll(roles, newUserRoleResponse),
+	})
+}
+
+// listUserAccounts lists every account a user holds, whatever their role on it
+func (server *Server) listUserAccounts(ctx *gin.Context) {
+	var uri usernameURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req adminPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	accounts, err := server.store.ListAccountsByHolder(ctx, db.ListAccountsByHolderParams{
+		Username: uri.Username,
+		Limit:    req.PageSize,
+		Offset:   req.offset(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(accounts, newAccountResponse))
+}
+
+func (server *Server) getAdminAccount(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
+}
+
+type listLedgerRequest struct {
+	AccountID int64 `form:"account_id" binding:"omitempty,min=1"`
+	adminPageRequest
+}
+
+// listLedger returns the newest ledger entries first, across all accounts or for one of them
+func (server *Server) listLedger(ctx *gin.Context) {
+	var req listLedgerRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	entries, err := server.store.ListEntries(ctx, db.ListEntriesParams{
+		AccountID: pgtype.Int8{Int64: req.AccountID, Valid: req.AccountID != 0},
+		Limit:     req.PageSize,
+		Offset:    req.offset(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// entries span accounts, so their currency isn't at hand here
+	ctx.JSON(http.StatusOK, convertAll(entries, func(entry db.Entry) entryResponse {
+		return newEntryResponse(entry, "")
+	}))
+}
+
+// freezeAccount stops an account from sending or receiving money, whoever holds it.
+// Only staff can lift the freeze.
+func (server *Server) freezeAccount(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	server.setAccountStatus(ctx, uri.ID, db.AccountFrozen)
+}
+
+// unfreezeAccount reactivates a frozen account, whoever froze it. Accounts debit blocked by their owner are left alone.
+func (server *Server) unfreezeAccount(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if account.Status != db.AccountFrozen {
+		respondError(ctx, http.StatusConflict, errAccountNotFrozen)
+		return
+	}
+
+	server.setAccountStatus(ctx, uri.ID, db.AccountActive)
+}
+
+func (server *Server) setAccountStatus(ctx *gin.Context, accountID int64, status string) {
+	account, err := server.store.UpdateAccountStatusTx(ctx, db.UpdateAccountStatusTxParams{
+		ID:     accountID,
+		Status: status,
+		SetBy:  db.FrozenByStaff,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrAccountClosed) {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
+}
+
+type listOutboxEventsRequest struct {
+	// Published filters on whether the dispatcher has published an event yet
+	Published *bool `form:"published"`
+	adminPageRequest
+}
+
+func (server *Server) listOutboxEvents(ctx *gin.Context) {
+	var req listOutboxEventsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	arg := db.ListOutboxEventsParams{
+		Limit:  req.PageSize,
+		Offset: req.offset(),
+	}
+	if req.Published != nil {
+		arg.Published = pgtype.Bool{Bool: *req.Published, Valid: true}
+	}
+
+	events, err := server.store.ListOutboxEvents(ctx, arg)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(events, newOutboxEventResponse))
+}
+
+type listJobsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending running succeeded failed"`
+	Kind   string `form:"kind"`
+	adminPageRequest
+}
+
+func (server *Server) listJobs(ctx *gin.Context) {
+	var req listJobsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	jobs, err := server.store.ListJobs(ctx, db.ListJobsParams{
+		Status: pgtype.Text{String: req.Status, Valid: req.Status != ""},
+		Kind:   pgtype.Text{String: req.Kind, Valid: req.Kind != ""},
+		Limit:  req.PageSize,
+		Offset: req.offset(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(jobs, newJobResponse))
+}
+
+type jobURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getJob(ctx *gin.Context) {
+	var uri jobURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	job, err := server.store.GetJob(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, fmt.Errorf("job [%d] not found", uri.ID))
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newJobResponse(job))
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/token"
+
+	"github.com/gin-gonic/gin"
+)
+
+type roleResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// listRoles lists every role with the permissions it grants
+func (server *Server) listRoles(ctx *gin.Context) {
+	roles, err := server.store.ListRoles(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	rolePermissions, err := server.store.ListRolePermissions(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	permissions := make(map[string][]string, len(roles))
+	for _, rolePermission := range rolePermissions {
+		permissions[rolePermission.Role] = append(permissions[rolePermission.Role], rolePermission.Permission)
+	}
+
+	rsp := make([]roleResponse, len(roles))
+	for i, role := range roles {
+		rsp[i] = roleResponse{
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: permissions[role.Name],
+		}
+		if rsp[i].Permissions == nil {
+			rsp[i].Permissions = []string{}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type grantRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+func (server *Server) grantRole(ctx *gin.Context) {
+	var uri usernameURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req grantRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	userRole, err := server.store.GrantRoleTx(ctx, db.GrantUserRoleParams{
+		Username:  uri.Username,
+		Role:      req.Role,
+		GrantedBy: authPayload.Username,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			err := fmt.Errorf("user %s or role %s does not exist", uri.Username, req.Role)
+			respondError(ctx, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserRoleResponse(userRole))
+}
+
+type userRoleURI struct {
+	Username string `uri:"username" binding:"required,alphanum"`
+	Role     string `uri:"role" binding:"required"`
+}
+
+// revokeRole takes a role away from a user. Admins can't revoke their own roles,
+// so the last one able to manage roles can't lock everyone out by accident.
+func (server *Server) revokeRole(ctx *gin.Context) {
+	var uri userRoleURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if uri.Username == authPayload.Username {
+		err := errors.New("cannot revoke your own role")
+		respondError(ctx, http.StatusForbidden, err)
+		return
+	}
+
+	userRole, err := server.store.RevokeRoleTx(ctx, db.RevokeUserRoleParams{
+		Username: uri.Username,
+		Role:     uri.Role,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserRoleResponse(userRole))
+}
@@ -0,0 +1,329 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const staffUsername = "staff"
+
+// expectStaff gives the staff user the permissions, for as many checks as the route makes
+func expectStaff(store *mock_db.MockStore, permissions ...string) {
+	store.EXPECT().
+		ListUserPermissions(gomock.Any(), gomock.Eq(staffUsername)).
+		AnyTimes().
+		Return(permissions, nil)
+}
+
+func serveAdmin(t *testing.T, store *mock_db.MockStore, method, url string, body gin.H) *httptest.ResponseRecorder {
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	request, err := http.NewRequest(method, url, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, staffUsername, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestSearchUsersAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?q=" + user.Username + "&page_id=1&page_size=20",
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice)
+				store.EXPECT().
+					SearchUsers(gomock.Any(), gomock.Eq(db.SearchUsersParams{
+						Query: pgtype.Text{String: user.Username, Valid: true},
+						Limit: 20,
+					})).
+					Times(1).
+					Return([]db.User{user}, nil)
+				store.EXPECT().
+					RecordAudit(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, rec db.AuditRecord) error {
+						require.Equal(t, "http.read", rec.Action)
+						require.Equal(t, "GET /admin/users", rec.ResourceID)
+						return nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), "hashed_password")
+
+				var users []userResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &users))
+				require.Len(t, users, 1)
+				require.Equal(t, user.Username, users[0].Username)
+			},
+		},
+		{
+			name:  "NotStaff",
+			query: "?page_id=1&page_size=20",
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBanking)
+				store.EXPECT().SearchUsers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "PageTooLarge",
+			query: "?page_id=1&page_size=500",
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice)
+				store.EXPECT().SearchUsers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			recorder := serveAdmin(t, store, http.MethodGet, "/admin/users"+tc.query, nil)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestFreezeAccountAPI(t *testing.T) {
+	account := randomAccount()
+
+	testCases := []struct {
+		name          string
+		path          string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Freeze",
+			path: "freeze",
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice, db.PermAccountsFreeze)
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Eq(db.UpdateAccountStatusTxParams{ID: account.ID, Status: db.AccountFrozen, SetBy: db.FrozenByStaff})).
+					Times(1).
+					Return(db.Account{ID: account.ID, Status: db.AccountFrozen}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "FreezeWithoutPermission",
+			path: "freeze",
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice)
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Unfreeze",
+			path: "unfreeze",
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice, db.PermAccountsFreeze)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(db.Account{ID: account.ID, Status: db.AccountFrozen}, nil)
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Eq(db.UpdateAccountStatusTxParams{ID: account.ID, Status: db.AccountActive, SetBy: db.FrozenByStaff})).
+					Times(1).
+					Return(db.Account{ID: account.ID, Status: db.AccountActive}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnfreezeDebitBlocked",
+			path: "unfreeze",
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice, db.PermAccountsFreeze)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(db.Account{ID: account.ID, Status: db.AccountDebitBlocked}, nil)
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "FreezeClosed",
+			path: "freeze",
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice, db.PermAccountsFreeze)
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Account{}, db.ErrAccountClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			url := fmt.Sprintf("/admin/accounts/%d/%s", account.ID, tc.path)
+			recorder := serveAdmin(t, store, http.MethodPost, url, nil)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListJobsAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	expectStaff(store, db.PermBackOffice)
+	store.EXPECT().
+		ListJobs(gomock.Any(), gomock.Eq(db.ListJobsParams{
+			Status: pgtype.Text{String: "failed", Valid: true},
+			Limit:  10,
+			Offset: 10,
+		})).
+		Times(1).
+		Return([]db.Job{{ID: 3, Status: "failed"}}, nil)
+
+	recorder := serveAdmin(t, store, http.MethodGet, "/admin/jobs?status=failed&page_id=2&page_size=10", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = serveAdmin(t, store, http.MethodGet, "/admin/jobs?status=stuck&page_id=1&page_size=10", nil)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestGrantRoleAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"role": db.RoleBankStaff},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice, db.PermRolesManage)
+				store.EXPECT().
+					GrantRoleTx(gomock.Any(), gomock.Eq(db.GrantUserRoleParams{
+						Username:  "bob",
+						Role:      db.RoleBankStaff,
+						GrantedBy: staffUsername,
+					})).
+					Times(1).
+					Return(db.UserRole{Username: "bob", Role: db.RoleBankStaff, GrantedBy: staffUsername}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownRole",
+			body: gin.H{"role": "janitor"},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice, db.PermRolesManage)
+				store.EXPECT().
+					GrantRoleTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserRole{}, &pgconn.PgError{Code: db.ForeignKeyViolation})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "WithoutRolesManage",
+			body: gin.H{"role": db.RoleBankAdmin},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice)
+				store.EXPECT().GrantRoleTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			recorder := serveAdmin(t, store, http.MethodPost, "/admin/users/bob/roles", tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRevokeOwnRoleAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	expectStaff(store, db.PermBackOffice, db.PermRolesManage)
+	store.EXPECT().RevokeRoleTx(gomock.Any(), gomock.Any()).Times(0)
+
+	url := fmt.Sprintf("/admin/users/%s/roles/%s", staffUsername, db.RoleBankAdmin)
+	recorder := serveAdmin(t, store, http.MethodDelete, url, nil)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
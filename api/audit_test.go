@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/util"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// TestAuditMiddleware checks that a state-changing request is recorded with its actor and request ID
+func TestAuditMiddleware(t *testing.T) {
+	username := "alice"
+	requestID := "req-123"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	store.EXPECT().
+		CreateBeneficiary(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.Beneficiary{ID: 1, Username: username}, nil)
+	store.EXPECT().
+		RecordAudit(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, rec db.AuditRecord) error {
+			info := db.AuditInfoFrom(ctx)
+			require.NotNil(t, info)
+			require.Equal(t, username, info.Actor)
+			require.Equal(t, requestID, info.RequestID)
+			require.Equal(t, "POST /beneficiaries", rec.ResourceID)
+			return nil
+		})
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	body, err := json.Marshal(gin.H{"nickname": "rent", "account_id": 1})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/beneficiaries", bytes.NewReader(body))
+	require.NoError(t, err)
+	request.Header.Set(requestIDHeader, requestID)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, username, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, requestID, recorder.Header().Get(requestIDHeader))
+}
+
+func TestAuditMiddlewareSkipsReads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	store.EXPECT().RecordAudit(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().ListUserPermissions(gomock.Any(), gomock.Eq("alice")).Times(1).Return([]string{db.PermAuditEventsRead}, nil)
+	store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(1).Return([]db.AuditEvent{}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/audit_events?page_id=1&page_size=5", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "alice", time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NotEmpty(t, recorder.Header().Get(requestIDHeader))
+}
+
+func TestAuditMiddlewareClientIP(t *testing.T) {
+	testCases := []struct {
+		name           string
+		trustedProxies []string
+		ip             string
+	}{
+		{name: "UntrustedProxy", ip: "10.0.0.1"},
+		{name: "TrustedProxy", trustedProxies: []string{"10.0.0.0/8"}, ip: "203.0.113.7"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			store.EXPECT().
+				RecordAudit(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, rec db.AuditRecord) error {
+					require.Equal(t, tc.ip, db.AuditInfoFrom(ctx).IP)
+					return nil
+				})
+
+			server, err := NewServer(util.Config{
+				TokenSymmetricKey: util.RandomString(32),
+				TOTPEncryptionKey: testTOTPKey,
+				TrustedProxies:    tc.trustedProxies,
+			}, store)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			// a login without a body fails validation, but is still a state-changing request
+			request, err := http.NewRequest(http.MethodPost, "/users/login", nil)
+			require.NoError(t, err)
+			request.RemoteAddr = "10.0.0.1:4321"
+			request.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+		})
+	}
+}
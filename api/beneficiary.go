@@ -0,0 +1,251 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/token"
+
+	"github.com/gin-gonic/gin"
+)
+
+type createBeneficiaryRequest struct {
+	Nickname  string `json:"nickname" binding:"required,max=64"`
+	AccountID int64  `json:"account_id" binding:"required,min=1"`
+}
+
+// createBeneficiary saves a payee for the authenticated user.
+// New beneficiaries start unverified until the user confirms them.
+func (server *Server) createBeneficiary(ctx *gin.Context) {
+	var req createBeneficiaryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	beneficiary, err := server.store.CreateBeneficiary(ctx, db.CreateBeneficiaryParams{
+		Username:  authPayload.Username,
+		Nickname:  req.Nickname,
+		AccountID: req.AccountID,
+	})
+	if err != nil {
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			respondError(ctx, http.StatusConflict, err)
+		case db.ForeignKeyViolation:
+			respondError(ctx, http.StatusNotFound, err)
+		default:
+			respondError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newBeneficiaryResponse(beneficiary))
+}
+
+type listBeneficiariesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listBeneficiaries(ctx *gin.Context) {
+	var req listBeneficiariesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	beneficiaries, err := server.store.ListBeneficiaries(ctx, db.ListBeneficiariesParams{
+		Username: authPayload.Username,
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(beneficiaries, newBeneficiaryResponse))
+}
+
+// beneficiaryURI identifies one of the caller's beneficiaries.
+// Lookups are scoped to the caller, so another user's payee is reported as not found.
+type beneficiaryURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getBeneficiary(ctx *gin.Context) {
+	var uri beneficiaryURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	beneficiary, err := server.store.GetBeneficiary(ctx, db.GetBeneficiaryParams{
+		ID:       uri.ID,
+		Username: authPayload.Username,
+	})
+	if err != nil {
+		beneficiaryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newBeneficiaryResponse(beneficiary))
+}
+
+type updateBeneficiaryRequest struct {
+	Nickname string `json:"nickname" binding:"required,max=64"`
+}
+
+func (server *Server) updateBeneficiary(ctx *gin.Context) {
+	var uri beneficiaryURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req updateBeneficiaryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	beneficiary, err := server.store.UpdateBeneficiaryNickname(ctx, db.UpdateBeneficiaryNicknameParams{
+		ID:       uri.ID,
+		Username: authPayload.Username,
+		Nickname: req.Nickname,
+	})
+	if err != nil {
+		beneficiaryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newBeneficiaryResponse(beneficiary))
+}
+
+// verifyBeneficiary records that the user has confirmed the payee.
+// Transfers to a verified beneficiary no longer need the new payee confirmation, so users with two-factor
+// authentication must give a second factor in the X-OTP header, checked by authMiddleware.
+func (server *Server) verifyBeneficiary(ctx *gin.Context) {
+	var uri beneficiaryURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !server.requirePayeeStepUp(ctx, authPayload.Username) {
+		return
+	}
+
+	beneficiary, err := server.store.VerifyBeneficiary(ctx, db.VerifyBeneficiaryParams{
+		ID:       uri.ID,
+		Username: authPayload.Username,
+	})
+	if err != nil {
+		beneficiaryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newBeneficiaryResponse(beneficiary))
+}
+
+func (server *Server) deleteBeneficiary(ctx *gin.Context) {
+	var uri beneficiaryURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	beneficiary, err := server.store.DeleteBeneficiary(ctx, db.DeleteBeneficiaryParams{
+		ID:       uri.ID,
+		Username: authPayload.Username,
+	})
+	if err != nil {
+		beneficiaryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newBeneficiaryResponse(beneficiary))
+}
+
+func beneficiaryError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, db.ErrRecordNotFound):
+		respondError(ctx, http.StatusNotFound, err)
+	case db.ErrorCode(err) == db.UniqueViolation:
+		respondError(ctx, http.StatusConflict, err)
+	default:
+		respondError(ctx, http.StatusInternalServerError, err)
+	}
+}
+
+// errNewPayee is returned when a transfer goes to an account the caller hasn't verified as a payee
+var errNewPayee = errors.New("transfers to a new payee must be confirmed with confirm_new_payee")
+
+// payeeAccount resolves the account a transfer goes to, either directly or through a saved beneficiary.
+// Unless the request confirms the new payee, the target must be a verified beneficiary or an account
+// the caller holds. Confirming one takes a second factor from users with two-factor authentication.
+// It writes the error response and returns false otherwise.
+func (server *Server) payeeAccount(ctx *gin.Context, username string, req transferRequest) (int64, bool) {
+	if req.BeneficiaryID != 0 {
+		beneficiary, err := server.store.GetBeneficiary(ctx, db.GetBeneficiaryParams{
+			ID:       req.BeneficiaryID,
+			Username: username,
+		})
+		if err != nil {
+			beneficiaryError(ctx, err)
+			return 0, false
+		}
+
+		if beneficiary.Verified {
+			return beneficiary.AccountID, true
+		}
+
+		if !req.ConfirmNewPayee {
+			err := fmt.Errorf("beneficiary %q is not verified: %w", beneficiary.Nickname, errNewPayee)
+			respondError(ctx, http.StatusPreconditionRequired, err)
+			return 0, false
+		}
+
+		return beneficiary.AccountID, server.requirePayeeStepUp(ctx, username)
+	}
+
+	if req.ConfirmNewPayee {
+		return req.ToAccountID, server.requirePayeeStepUp(ctx, username)
+	}
+
+	beneficiary, err := server.store.GetBeneficiaryByAccount(ctx, db.GetBeneficiaryByAccountParams{
+		Username:  username,
+		AccountID: req.ToAccountID,
+	})
+	if err == nil && beneficiary.Verified {
+		return req.ToAccountID, true
+	}
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return 0, false
+	}
+
+	// moving money between the caller's own accounts needs no confirmation
+	_, err = server.store.GetAccountHolder(ctx, db.GetAccountHolderParams{
+		AccountID: req.ToAccountID,
+		Username:  username,
+	})
+	if err == nil {
+		return req.ToAccountID, true
+	}
+	if !errors.Is(err, db.ErrRecordNotFound) {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return 0, false
+	}
+
+	respondError(ctx, http.StatusPreconditionRequired, errNewPayee)
+	return 0, false
+}
@@ -0,0 +1,404 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/internal/kyc"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateBeneficiaryAPI(t *testing.T) {
+	account := randomAccount()
+	username := "alice"
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"nickname": "rent", "account_id": account.ID},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					CreateBeneficiary(gomock.Any(), gomock.Eq(db.CreateBeneficiaryParams{
+						Username:  username,
+						Nickname:  "rent",
+						AccountID: account.ID,
+					})).
+					Times(1).
+					Return(db.Beneficiary{ID: 1, Username: username, Nickname: "rent", AccountID: account.ID}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Duplicate",
+			body: gin.H{"nickname": "rent", "account_id": account.ID},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					CreateBeneficiary(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Beneficiary{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownAccount",
+			body: gin.H{"nickname": "rent", "account_id": account.ID},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					CreateBeneficiary(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Beneficiary{}, &pgconn.PgError{Code: db.ForeignKeyViolation})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "MissingNickname",
+			body: gin.H{"account_id": account.ID},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/beneficiaries", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// TestVerifyBeneficiaryAPI checks that verifying a payee takes a second factor from users who have one
+func TestVerifyBeneficiaryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	userTOTP, code := randomTOTP(t, user.Username)
+	beneficiary := db.Beneficiary{ID: 1, Username: user.Username, AccountID: 2, Nickname: "landlord", Verified: true}
+
+	testCases := []struct {
+		name          string
+		otp           string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			otp:  code,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(userTOTP, nil)
+				store.EXPECT().UseUserTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(userTOTP, nil)
+				store.EXPECT().
+					VerifyBeneficiary(gomock.Any(), gomock.Eq(db.VerifyBeneficiaryParams{ID: beneficiary.ID, Username: user.Username})).
+					Times(1).
+					Return(beneficiary, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NoTOTP",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					VerifyBeneficiary(gomock.Any(), gomock.Eq(db.VerifyBeneficiaryParams{ID: beneficiary.ID, Username: user.Username})).
+					Times(1).
+					Return(beneficiary, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "StepUpRequired",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(userTOTP, nil)
+				store.EXPECT().VerifyBeneficiary(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "otp_required")
+			},
+		},
+		{
+			name: "TOTPNotEnabled",
+			otp:  code,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().VerifyBeneficiary(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/beneficiaries/%d/verify", beneficiary.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			if tc.otp != "" {
+				request.Header.Set(otpHeader, tc.otp)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// TestCreateTransferPayeeAPI checks how transfers resolve and confirm their payee
+func TestCreateTransferPayeeAPI(t *testing.T) {
+	account1 := randomAccount()
+	account2 := randomAccount()
+	account2.ID = account1.ID + 1
+	account2.Currency = account1.Currency
+	account2.CountryCode = account1.CountryCode
+
+	beneficiary := db.Beneficiary{
+		ID:        3,
+		Username:  account1.Owner,
+		Nickname:  "landlord",
+		AccountID: account2.ID,
+	}
+	verified := beneficiary
+	verified.Verified = true
+	userTOTP, code := randomTOTP(t, account1.Owner)
+
+	// stubs for a transfer that gets past its payee checks
+	expectTransfer := func(store *mock_db.MockStore) {
+		store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+		store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+		store.EXPECT().
+			GetUser(gomock.Any(), gomock.Eq(account1.Owner)).
+			Times(1).
+			Return(db.User{Username: account1.Owner, KycStatus: kyc.StatusUnverified}, nil)
+		store.EXPECT().CountTransfersFromAccountSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+		store.EXPECT().
+			TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        10,
+			})).
+			Times(1)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		otp           string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "VerifiedBeneficiary",
+			body: gin.H{"beneficiary_id": beneficiary.ID},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().
+					GetBeneficiary(gomock.Any(), gomock.Eq(db.GetBeneficiaryParams{ID: beneficiary.ID, Username: account1.Owner})).
+					Times(1).
+					Return(verified, nil)
+				expectTransfer(store)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnverifiedBeneficiary",
+			body: gin.H{"beneficiary_id": beneficiary.ID},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Any()).Times(1).Return(beneficiary, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownBeneficiary",
+			body: gin.H{"beneficiary_id": beneficiary.ID},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().
+					GetBeneficiary(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Beneficiary{}, db.ErrRecordNotFound)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "UnconfirmedNewPayee",
+			body: gin.H{"to_account_id": account2.ID},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().
+					GetBeneficiaryByAccount(gomock.Any(), gomock.Eq(db.GetBeneficiaryByAccountParams{
+						Username:  account1.Owner,
+						AccountID: account2.ID,
+					})).
+					Times(1).
+					Return(db.Beneficiary{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					GetAccountHolder(gomock.Any(), gomock.Eq(db.GetAccountHolderParams{
+						AccountID: account2.ID,
+						Username:  account1.Owner,
+					})).
+					Times(1).
+					Return(db.AccountHolder{}, db.ErrRecordNotFound)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name: "ConfirmedNewPayeeNoTOTP",
+			body: gin.H{"to_account_id": account2.ID, "confirm_new_payee": true},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				expectTransfer(store)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ConfirmedNewPayeeSteppedUp",
+			body: gin.H{"to_account_id": account2.ID, "confirm_new_payee": true},
+			otp:  code,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(userTOTP, nil)
+				store.EXPECT().UseUserTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(userTOTP, nil)
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				expectTransfer(store)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ConfirmedNewPayeeStepUpRequired",
+			body: gin.H{"to_account_id": account2.ID, "confirm_new_payee": true},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(userTOTP, nil)
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "otp_required")
+			},
+		},
+		{
+			name: "ConfirmedUnverifiedBeneficiaryStepUpRequired",
+			body: gin.H{"beneficiary_id": beneficiary.ID, "confirm_new_payee": true},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(userTOTP, nil)
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Any()).Times(1).Return(beneficiary, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "BothPayees",
+			body: gin.H{"to_account_id": account2.ID, "beneficiary_id": beneficiary.ID},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			tc.body["from_account_id"] = account1.ID
+			tc.body["amount"] = 10
+			tc.body["currency"] = account1.Currency
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account1.Owner, time.Minute)
+			if tc.otp != "" {
+				request.Header.Set(otpHeader, tc.otp)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
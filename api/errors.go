@@ -0,0 +1,202 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"simple_bank/internal/db"
+	"simple_bank/internal/token"
+	"simple_bank/internal/webhook"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// apiError is the body of every error response. Code is stable and meant for clients to branch on,
+// Message is for humans and may change.
+type apiError struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Details   []fieldError `json:"details,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+}
+
+// fieldError describes one request field that failed validation
+type fieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type errorBody struct {
+	Error apiError `json:"error"`
+}
+
+// errorCodeKey holds the code of the error response written for a request
+const errorCodeKey = "error_code"
+
+// statusCodes are the codes of errors that have no more specific code in errorCodes
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          "invalid_argument",
+	http.StatusUnauthorized:        "unauthenticated",
+	http.StatusForbidden:           "permission_denied",
+	http.StatusNotFound:            "not_found",
+	http.StatusConflict:            "conflict",
+	http.StatusUnprocessableEntity: "unprocessable",
+	http.StatusTooManyRequests:     "rate_limited",
+	http.StatusInternalServerError: "internal",
+}
+
+// errorCodes gives the errors clients are likely to handle a code of their own
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{token.ErrExpiredToken, "token_expired"},
+	{token.ErrInvalidToken, "token_invalid"},
+	{errTokenRevoked, "token_revoked"},
+	{errOTPRequired, "otp_required"},
+	{db.ErrInvalidOTP, "otp_invalid"},
+	{db.ErrOTPLocked, "otp_locked"},
+	{errEmailNotVerified, "email_not_verified"},
+	{errNewPayee, "new_payee_unconfirmed"},
+	{db.ErrAccountClosed, "account_closed"},
+	{db.ErrFrozenByStaff, "account_frozen_by_staff"},
+	{db.ErrAccountCannotSend, "account_cannot_send"},
+	{db.ErrAccountCannotReceive, "account_cannot_receive"},
+	{db.ErrAccountCannotClose, "account_cannot_close"},
+	{db.ErrCurrencyMismatch, "currency_mismatch"},
+	{db.ErrLastOwner, "last_owner"},
+	{db.ErrLastMerchantAdmin, "last_merchant_admin"},
+	{db.ErrReviewNotPending, "review_not_pending"},
+	{db.ErrAdjustmentNotPending, "adjustment_not_pending"},
+	{db.ErrAdjustmentOverdraws, "insufficient_balance"},
+	{db.ErrSelfApproval, "self_approval"},
+	{webhook.ErrForbiddenTarget, "webhook_url_forbidden"},
+}
+
+// constraintMessages explains the violations of constraints clients can run into,
+// instead of the Postgres message naming the constraint
+var constraintMessages = map[string]string{
+	"owner_currency_key": "an account in this currency already exists",
+}
+
+// respondError writes the error response for err. Postgres unique and foreign key violations a handler didn't
+// expect become 409 and 422 instead of 500, their messages never name tables or constraints, and the message
+// of a 5xx is never shown to the client: err is attached to the context for the logger instead.
+func respondError(ctx *gin.Context, status int, err error) {
+	status, body := newErrorBody(ctx, status, err)
+	ctx.JSON(status, body)
+}
+
+// abortWithError is respondError for middlewares, stopping the handlers after it from running
+func abortWithError(ctx *gin.Context, status int, err error) {
+	status, body := newErrorBody(ctx, status, err)
+	ctx.AbortWithStatusJSON(status, body)
+}
+
+func newErrorBody(ctx *gin.Context, status int, err error) (int, errorBody) {
+	rsp := apiError{
+		Message:   err.Error(),
+		RequestID: ctx.Writer.Header().Get(requestIDHeader),
+	}
+
+	var pgErr *pgconn.PgError
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == db.UniqueViolation:
+		status = constraintStatus(status, http.StatusConflict)
+		rsp.Code = "already_exists"
+		rsp.Message = constraintMessage(pgErr, "the resource already exists")
+	case errors.As(err, &pgErr) && pgErr.Code == db.ForeignKeyViolation:
+		status = constraintStatus(status, http.StatusUnprocessableEntity)
+		rsp.Code = "invalid_reference"
+		rsp.Message = constraintMessage(pgErr, "a referenced resource does not exist")
+	case errors.As(err, &pgErr) && pgErr.Code == db.CheckViolation:
+		status = constraintStatus(status, http.StatusUnprocessableEntity)
+		rsp.Code = "constraint_violation"
+		rsp.Message = constraintMessage(pgErr, "the request breaks a data constraint")
+	case status >= http.StatusInternalServerError:
+		ctx.Error(err)
+		rsp.Message = http.StatusText(status)
+	case errors.Is(err, db.ErrRecordNotFound):
+		rsp.Message = "resource not found"
+	case errors.As(err, &validationErrs):
+		rsp.Code = "validation_failed"
+		rsp.Message = "request failed validation"
+		for _, fieldErr := range validationErrs {
+			rsp.Details = append(rsp.Details, newFieldError(fieldErr))
+		}
+	}
+
+	if rsp.Code == "" {
+		rsp.Code = errorCode(status, err)
+	}
+	// for the middlewares and handlers that log or count errors by code
+	ctx.Set(errorCodeKey, rsp.Code)
+
+	return status, errorBody{Error: rsp}
+}
+
+func errorCode(status int, err error) string {
+	if status < http.StatusInternalServerError {
+		for _, known := range errorCodes {
+			if errors.Is(err, known.err) {
+				return known.code
+			}
+		}
+	}
+
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+// constraintStatus keeps the status a handler chose for a constraint violation it expected,
+// and replaces the 500 of one it didn't
+func constraintStatus(status, mapped int) int {
+	if status >= http.StatusInternalServerError {
+		return mapped
+	}
+	return status
+}
+
+func constraintMessage(pgErr *pgconn.PgError, fallback string) string {
+	if msg, ok := constraintMessages[pgErr.ConstraintName]; ok {
+		return msg
+	}
+	return fallback
+}
+
+func newFieldError(fieldErr validator.FieldError) fieldError {
+	msg := fmt.Sprintf("%s failed the %s rule", fieldErr.Field(), fieldErr.Tag())
+	if fieldErr.Param() != "" {
+		msg = fmt.Sprintf("%s failed the %s=%s rule", fieldErr.Field(), fieldErr.Tag(), fieldErr.Param())
+	}
+
+	return fieldError{
+		Field:   fieldErr.Field(),
+		Rule:    fieldErr.Tag(),
+		Message: msg,
+	}
+}
+
+// requestFieldName names struct fields in validation errors the way the client sent them,
+// by their json, form or uri tag
+func requestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
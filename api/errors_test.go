@@ -0,0 +1,151 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/util"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRespondError(t *testing.T) {
+	duplicateAccount := &pgconn.PgError{
+		Code:           db.UniqueViolation,
+		Message:        `duplicate key value violates unique constraint "owner_currency_key"`,
+		ConstraintName: "owner_currency_key",
+	}
+
+	testCases := []struct {
+		name           string
+		status         int
+		err            error
+		expectedStatus int
+		expectedCode   string
+		checkMessage   func(t *testing.T, message string)
+	}{
+		{
+			name:           "UnexpectedUniqueViolation",
+			status:         http.StatusInternalServerError,
+			err:            fmt.Errorf("cannot create account: %w", duplicateAccount),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "already_exists",
+			checkMessage: func(t *testing.T, message string) {
+				require.Equal(t, "an account in this currency already exists", message)
+			},
+		},
+		{
+			name:           "ExpectedForeignKeyViolation",
+			status:         http.StatusNotFound,
+			err:            &pgconn.PgError{Code: db.ForeignKeyViolation, Message: `insert on table "beneficiaries" violates foreign key constraint`},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "invalid_reference",
+			checkMessage: func(t *testing.T, message string) {
+				require.NotContains(t, message, "beneficiaries")
+			},
+		},
+		{
+			name:           "UnexpectedForeignKeyViolation",
+			status:         http.StatusInternalServerError,
+			err:            &pgconn.PgError{Code: db.ForeignKeyViolation},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedCode:   "invalid_reference",
+		},
+		{
+			name:           "InternalError",
+			status:         http.StatusInternalServerError,
+			err:            errors.New(`failed to connect to "user=root_user database=bank_db"`),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal",
+			checkMessage: func(t *testing.T, message string) {
+				require.Equal(t, "Internal Server Error", message)
+			},
+		},
+		{
+			name:           "RecordNotFound",
+			status:         http.StatusNotFound,
+			err:            db.ErrRecordNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "not_found",
+			checkMessage: func(t *testing.T, message string) {
+				require.Equal(t, "resource not found", message)
+			},
+		},
+		{
+			name:           "KnownError",
+			status:         http.StatusForbidden,
+			err:            fmt.Errorf("account [7] is frozen: %w", db.ErrAccountCannotSend),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "account_cannot_send",
+			checkMessage: func(t *testing.T, message string) {
+				require.Equal(t, "account [7] is frozen: account cannot send money", message)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Header(requestIDHeader, "req-1")
+
+			respondError(ctx, tc.status, tc.err)
+			require.Equal(t, tc.expectedStatus, recorder.Code)
+
+			var body errorBody
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+			require.Equal(t, tc.expectedCode, body.Error.Code)
+			require.Equal(t, "req-1", body.Error.RequestID)
+			require.NotContains(t, body.Error.Message, "constraint")
+			if tc.checkMessage != nil {
+				tc.checkMessage(t, body.Error.Message)
+			}
+		})
+	}
+}
+
+func TestValidationErrorDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	store.EXPECT().CreateUserTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	body, err := json.Marshal(gin.H{
+		"username":  util.RandomOwner(),
+		"password":  "123",
+		"full_name": util.RandomOwner(),
+		"email":     "not-an-email",
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/users", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	var rsp errorBody
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Equal(t, "validation_failed", rsp.Error.Code)
+	require.NotEmpty(t, rsp.Error.RequestID)
+
+	fields := make(map[string]string)
+	for _, detail := range rsp.Error.Details {
+		fields[detail.Field] = detail.Rule
+	}
+	require.Equal(t, map[string]string{"password": "min", "email": "email"}, fields)
+}
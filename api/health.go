@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// livenessResponse is the body of GET /healthz
+type livenessResponse struct {
+	Status string `json:"status"`
+}
+
+// readinessCheckResponse is the outcome of one dependency check of GET /readyz.
+// The endpoint is public, so why a check failed is only logged.
+type readinessCheckResponse struct {
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// readinessResponse is the body of GET /readyz. Status is ok, degraded when only a
+// background worker is failing, or unavailable when a critical dependency is.
+type readinessResponse struct {
+	Status string                            `json:"status"`
+	Checks map[string]readinessCheckResponse `json:"checks"`
+}
+
+func newReadinessResponse(report health.Report) readinessResponse {
+	rsp := readinessResponse{
+		Status: report.Status,
+		Checks: make(map[string]readinessCheckResponse, len(report.Results)),
+	}
+	for _, result := range report.Results {
+		rsp.Checks[result.Name] = readinessCheckResponse{
+			Status:     result.Status,
+			Critical:   result.Critical,
+			DurationMs: result.Duration.Milliseconds(),
+		}
+	}
+	return rsp
+}
+
+// WatchWorker adds the heartbeat of a background worker to the readiness checks.
+// A failing worker degrades the service without taking it out of rotation.
+func (server *Server) WatchWorker(name string, heartbeat *health.Heartbeat) {
+	server.workers = append(server.workers, health.Check{Name: name, Run: heartbeat.Check})
+}
+
+// readinessChecks are the database checks followed by the watched workers
+func (server *Server) readinessChecks() []health.Check {
+	checks := []health.Check{
+		{Name: "database", Critical: true, Run: server.store.Ping},
+		{Name: "migrations", Critical: true, Run: server.checkMigrations},
+	}
+	return append(checks, server.workers...)
+}
+
+// checkMigrations fails unless the database is at the newest migration this build ships with
+func (server *Server) checkMigrations(ctx context.Context) error {
+	latest, err := db.LatestMigration()
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := server.store.MigrationVersion(ctx)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return fmt.Errorf("no migrations applied, want version %d", latest)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case dirty:
+		return fmt.Errorf("migration %d failed halfway and left the schema dirty", version)
+	case version != latest:
+		return fmt.Errorf("schema is at version %d, want %d", version, latest)
+	}
+	return nil
+}
+
+// healthz reports that the process is up and serving, without touching any dependency
+func (server *Server) healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, livenessResponse{Status: health.StatusOK})
+}
+
+// readyz checks the database, its migrations and the background workers.
+// It answers 503 only when a critical dependency fails, so a stuck worker
+// is reported as degraded without taking the service out of rotation.
+func (server *Server) readyz(ctx *gin.Context) {
+	report := health.Run(ctx, server.readinessChecks())
+	for _, result := range report.Results {
+		if result.Err != nil {
+			slog.WarnContext(ctx, "readiness check failed", "check", result.Name, "critical", result.Critical, "error", result.Err)
+		}
+	}
+
+	status := http.StatusOK
+	if report.Status == health.StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.JSON(status, newReadinessResponse(report))
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/internal/health"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHealthz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	store.EXPECT().Ping(gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.JSONEq(t, `{"status":"ok"}`, recorder.Body.String())
+}
+
+func TestReadyz(t *testing.T) {
+	latest, err := db.LatestMigration()
+	require.NoError(t, err)
+	require.Positive(t, latest)
+
+	testCases := []struct {
+		name          string
+		workerErr     error
+		logged        string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Ready",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().Ping(gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().MigrationVersion(gomock.Any()).Times(1).Return(latest, false, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				rsp := readinessBody(t, recorder)
+				require.Equal(t, health.StatusOK, rsp.Status)
+				require.Len(t, rsp.Checks, 3)
+				for name, check := range rsp.Checks {
+					require.Equal(t, health.StatusOK, check.Status, name)
+				}
+				require.True(t, rsp.Checks["database"].Critical)
+				require.False(t, rsp.Checks["outbox"].Critical)
+			},
+		},
+		{
+			name:   "DatabaseDown",
+			logged: "connection refused",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().Ping(gomock.Any()).Times(1).Return(errors.New("connection refused"))
+				store.EXPECT().MigrationVersion(gomock.Any()).Times(1).Return(int64(0), false, errors.New("connection refused"))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+				rsp := readinessBody(t, recorder)
+				require.Equal(t, health.StatusUnavailable, rsp.Status)
+				require.Equal(t, health.StatusUnavailable, rsp.Checks["database"].Status)
+			},
+		},
+		{
+			name:   "MigrationsBehind",
+			logged: "want",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().Ping(gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().MigrationVersion(gomock.Any()).Times(1).Return(latest-1, false, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+				rsp := readinessBody(t, recorder)
+				require.Equal(t, health.StatusOK, rsp.Checks["database"].Status)
+				require.Equal(t, health.StatusUnavailable, rsp.Checks["migrations"].Status)
+			},
+		},
+		{
+			name:   "MigrationsDirty",
+			logged: "dirty",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().Ping(gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().MigrationVersion(gomock.Any()).Times(1).Return(latest, true, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+			},
+		},
+		{
+			name:   "NoMigrations",
+			logged: "no migrations applied",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().Ping(gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().MigrationVersion(gomock.Any()).Times(1).Return(int64(0), false, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+			},
+		},
+		{
+			name:      "WorkerFailing",
+			workerErr: errors.New("broker unreachable"),
+			logged:    "broker unreachable",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().Ping(gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().MigrationVersion(gomock.Any()).Times(1).Return(latest, false, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				rsp := readinessBody(t, recorder)
+				require.Equal(t, health.StatusDegraded, rsp.Status)
+				require.Equal(t, health.StatusDegraded, rsp.Checks["outbox"].Status)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			logs := captureLogs(t)
+			server := newTestServer(t, store)
+			heartbeat := health.NewHeartbeat(time.Minute)
+			heartbeat.Beat(tc.workerErr)
+			server.WatchWorker("outbox", heartbeat)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+
+			// why a check failed is logged, never shown to the unauthenticated caller
+			if tc.logged != "" {
+				require.Contains(t, logs.String(), tc.logged)
+				require.NotContains(t, recorder.Body.String(), tc.logged)
+			}
+		})
+	}
+}
+
+func readinessBody(t *testing.T, recorder *httptest.ResponseRecorder) readinessResponse {
+	var rsp readinessResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	return rsp
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/kyc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// userTier looks up a user and the KYC limits that apply to them.
+// Only registered users can hold accounts, so an unknown user is rejected.
+func (server *Server) userTier(ctx *gin.Context, username string) (db.User, kyc.Tier, bool) {
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err := fmt.Errorf("user %s is not registered", username)
+			respondError(ctx, http.StatusForbidden, err)
+			return db.User{}, kyc.Tier{}, false
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return db.User{}, kyc.Tier{}, false
+	}
+
+	return user, kyc.TierFor(user.KycTier), true
+}
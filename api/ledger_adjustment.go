@@ -0,0 +1,143 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/token"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (server *Server) listGLAccounts(ctx *gin.Context) {
+	glAccounts, err := server.store.ListGLAccounts(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(glAccounts, newGLAccountResponse))
+}
+
+type requestAdjustmentRequest struct {
+	AccountID  int64  `json:"account_id" binding:"required,min=1"`
+	GLCode     string `json:"gl_code" binding:"required,oneof=suspense adjustments"`
+	Amount     int64  `json:"amount" binding:"required,ne=0"`
+	ReasonCode string `json:"reason_code" binding:"required,oneof=goodwill fee_refund error_correction chargeback write_off"`
+	Note       string `json:"note" binding:"max=500"`
+}
+
+// requestAdjustment asks for a manual credit (positive amount) or debit (negative amount) to a customer account.
+// It takes effect once a different staff member approves it.
+func (server *Server) requestAdjustment(ctx *gin.Context) {
+	var req requestAdjustmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	adjustment, err := server.store.RequestAdjustmentTx(ctx, db.CreateLedgerAdjustmentParams{
+		AccountID:   req.AccountID,
+		GlCode:      req.GLCode,
+		Amount:      req.Amount,
+		ReasonCode:  req.ReasonCode,
+		Note:        req.Note,
+		RequestedBy: authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrAccountClosed) {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, newAdjustmentResponse(adjustment, ""))
+}
+
+type listAdjustmentsRequest struct {
+	Status    string `form:"status" binding:"omitempty,oneof=pending_approval approved rejected"`
+	AccountID int64  `form:"account_id" binding:"omitempty,min=1"`
+	adminPageRequest
+}
+
+func (server *Server) listAdjustments(ctx *gin.Context) {
+	var req listAdjustmentsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	adjustments, err := server.store.ListLedgerAdjustments(ctx, db.ListLedgerAdjustmentsParams{
+		Status:    pgtype.Text{String: req.Status, Valid: req.Status != ""},
+		AccountID: pgtype.Int8{Int64: req.AccountID, Valid: req.AccountID != 0},
+		Limit:     req.PageSize,
+		Offset:    req.offset(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(adjustments, func(adjustment db.LedgerAdjustment) adjustmentResponse {
+		return newAdjustmentResponse(adjustment, "")
+	}))
+}
+
+type adjustmentURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) approveAdjustment(ctx *gin.Context) {
+	server.decideAdjustment(ctx, true)
+}
+
+func (server *Server) rejectAdjustment(ctx *gin.Context) {
+	server.decideAdjustment(ctx, false)
+}
+
+func (server *Server) decideAdjustment(ctx *gin.Context, approve bool) {
+	var uri adjustmentURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.DecideAdjustmentTx(ctx, db.DecideAdjustmentTxParams{
+		AdjustmentID: uri.ID,
+		Approve:      approve,
+		DecidedBy:    authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrSelfApproval) {
+			respondError(ctx, http.StatusForbidden, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrAdjustmentNotPending) || errors.Is(err, db.ErrAccountClosed) ||
+			errors.Is(err, db.ErrAdjustmentOverdraws) {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newDecideAdjustmentResponse(result))
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRequestAdjustmentAPI(t *testing.T) {
+	account := randomAccount()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"account_id": account.ID, "gl_code": db.GLAdjustments, "amount": 25, "reason_code": db.AdjustmentGoodwill, "note": "outage"},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice, db.PermLedgerAdjust)
+				store.EXPECT().
+					RequestAdjustmentTx(gomock.Any(), gomock.Eq(db.CreateLedgerAdjustmentParams{
+						AccountID:   account.ID,
+						GlCode:      db.GLAdjustments,
+						Amount:      25,
+						ReasonCode:  db.AdjustmentGoodwill,
+						Note:        "outage",
+						RequestedBy: staffUsername,
+					})).
+					Times(1).
+					Return(db.LedgerAdjustment{ID: 1, Status: db.AdjustmentPending}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownReasonCode",
+			body: gin.H{"account_id": account.ID, "gl_code": db.GLSuspense, "amount": 25, "reason_code": "because"},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice, db.PermLedgerAdjust)
+				store.EXPECT().RequestAdjustmentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ZeroAmount",
+			body: gin.H{"account_id": account.ID, "gl_code": db.GLSuspense, "amount": 0, "reason_code": db.AdjustmentFeeRefund},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice, db.PermLedgerAdjust)
+				store.EXPECT().RequestAdjustmentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ClosedAccount",
+			body: gin.H{"account_id": account.ID, "gl_code": db.GLSuspense, "amount": -5, "reason_code": db.AdjustmentErrorCorrection},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice, db.PermLedgerAdjust)
+				store.EXPECT().
+					RequestAdjustmentTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LedgerAdjustment{}, db.ErrAccountClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "WithoutLedgerAdjust",
+			body: gin.H{"account_id": account.ID, "gl_code": db.GLSuspense, "amount": 25, "reason_code": db.AdjustmentGoodwill},
+			buildStubs: func(store *mock_db.MockStore) {
+				expectStaff(store, db.PermBackOffice)
+				store.EXPECT().RequestAdjustmentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			recorder := serveAdmin(t, store, http.MethodPost, "/admin/adjustments", tc.body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDecideAdjustmentAPI(t *testing.T) {
+	adjustmentID := int64(3)
+
+	testCases := []struct {
+		name          string
+		action        string
+		permissions   []string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "Approve",
+			action:      "approve",
+			permissions: []string{db.PermBackOffice, db.PermLedgerApprove},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					DecideAdjustmentTx(gomock.Any(), gomock.Eq(db.DecideAdjustmentTxParams{
+						AdjustmentID: adjustmentID,
+						Approve:      true,
+						DecidedBy:    staffUsername,
+					})).
+					Times(1).
+					Return(db.DecideAdjustmentTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "Reject",
+			action:      "reject",
+			permissions: []string{db.PermBackOffice, db.PermLedgerApprove},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					DecideAdjustmentTx(gomock.Any(), gomock.Eq(db.DecideAdjustmentTxParams{
+						AdjustmentID: adjustmentID,
+						DecidedBy:    staffUsername,
+					})).
+					Times(1).
+					Return(db.DecideAdjustmentTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "OwnRequest",
+			action:      "approve",
+			permissions: []string{db.PermBackOffice, db.PermLedgerApprove},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					DecideAdjustmentTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DecideAdjustmentTxResult{}, db.ErrSelfApproval)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:        "AlreadyDecided",
+			action:      "approve",
+			permissions: []string{db.PermBackOffice, db.PermLedgerApprove},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					DecideAdjustmentTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DecideAdjustmentTxResult{}, db.ErrAdjustmentNotPending)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "MakerCannotApprove",
+			action:      "approve",
+			permissions: []string{db.PermBackOffice, db.PermLedgerAdjust},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().DecideAdjustmentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			expectStaff(store, tc.permissions...)
+			tc.buildStubs(store)
+
+			url := fmt.Sprintf("/admin/adjustments/%d/%s", adjustmentID, tc.action)
+			recorder := serveAdmin(t, store, http.MethodPost, url, nil)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
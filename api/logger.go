@@ -0,0 +1,85 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"simple_bank/internal/logging"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// requestIDMiddleware gives every request an ID, the client's X-Request-ID when it sent one,
+// returns it in the X-Request-ID response header and puts it in the request context,
+// where the logger, the audit log and error responses pick it up
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		ctx.Header(requestIDHeader, requestID)
+
+		fields := &logging.Fields{RequestID: requestID}
+		ctx.Request = ctx.Request.WithContext(logging.WithFields(ctx.Request.Context(), fields))
+		ctx.Next()
+	}
+}
+
+// requestID returns the ID requestIDMiddleware gave the request
+func requestID(ctx *gin.Context) string {
+	if fields := logging.FieldsFrom(ctx.Request.Context()); fields != nil {
+		return fields.RequestID
+	}
+	return ""
+}
+
+// loggerMiddleware logs every request once it has been handled, with its route, status and latency.
+// The query string is left out as it can carry secrets such as email verification codes.
+// Server errors are logged at error level with the error respondError attached to the context.
+func loggerMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("method", ctx.Request.Method),
+			slog.String("route", ctx.FullPath()),
+			slog.String("path", ctx.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", ctx.ClientIP()),
+			slog.Int("size", ctx.Writer.Size()),
+		}
+		if code := ctx.GetString(errorCodeKey); code != "" {
+			attrs = append(attrs, slog.String("error_code", code))
+		}
+		if err := ctx.Errors.Last(); err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		logger.LogAttrs(ctx.Request.Context(), level, "http request", attrs...)
+	}
+}
+
+// recoveryMiddleware turns a panicking handler into a 500 with the usual error body,
+// leaving the panic for loggerMiddleware to log
+func recoveryMiddleware() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(ctx *gin.Context, recovered any) {
+		abortWithError(ctx, http.StatusInternalServerError, fmt.Errorf("panic: %v\n%s", recovered, debug.Stack()))
+	})
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/internal/logging"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// captureLogs makes the default logger, which the server logs with, write to the returned buffer
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "debug")
+	require.NoError(t, err)
+
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	return &buf
+}
+
+func TestLoggerMiddleware(t *testing.T) {
+	account := randomAccount()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	expectHolder(store, account.ID, account.Owner, db.HolderOwner)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, errors.New("connection reset"))
+
+	logs := captureLogs(t)
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/accounts/%d?code=s3cret", account.ID), nil)
+	require.NoError(t, err)
+	request.Header.Set(requestIDHeader, "req-42")
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account.Owner, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &line))
+	require.Equal(t, "ERROR", line["level"])
+	require.Equal(t, "/accounts/:id", line["route"])
+	require.Equal(t, fmt.Sprintf("/accounts/%d", account.ID), line["path"])
+	require.EqualValues(t, http.StatusInternalServerError, line["status"])
+	require.Equal(t, "req-42", line["request_id"])
+	require.Equal(t, account.Owner, line["user"])
+	require.Equal(t, "connection reset", line["error"])
+	require.NotContains(t, logs.String(), "s3cret")
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	logs := captureLogs(t)
+
+	router := gin.New()
+	router.Use(requestIDMiddleware(), loggerMiddleware(slog.Default()), recoveryMiddleware())
+	router.GET("/panic", func(ctx *gin.Context) { panic("boom") })
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/panic", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.NotContains(t, recorder.Body.String(), "boom")
+
+	var body errorBody
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	require.Equal(t, recorder.Header().Get(requestIDHeader), body.Error.RequestID)
+	require.Contains(t, logs.String(), "panic: boom")
+}
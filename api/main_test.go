@@ -2,11 +2,45 @@ package api
 
 import (
 	"os"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/util"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
+// testTOTPKey encrypts the TOTP secrets of test servers, so stubs can return secrets sealed with it
+const testTOTPKey = "0123456789abcdef0123456789abcdef"
+
+// newTestServer creates a server with a random token key around the given store
+func newTestServer(t *testing.T, store db.Store) *Server {
+	config := util.Config{
+		TokenSymmetricKey:   util.RandomString(32),
+		TOTPEncryptionKey:   testTOTPKey,
+		AccessTokenDuration: time.Minute,
+	}
+
+	// audit rows are written for every state-changing request, authMiddleware checks every token
+	// against the password change time, logins look for a second factor and callers are customers
+	// administering merchants unless a test says otherwise. Tests that care set their own expectations first.
+	if mockStore, ok := store.(*mock_db.MockStore); ok {
+		mockStore.EXPECT().RecordAudit(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+		mockStore.EXPECT().GetUserPasswordChangedAt(gomock.Any(), gomock.Any()).AnyTimes().Return(pgtype.Timestamptz{}, nil)
+		mockStore.EXPECT().GetUserTOTP(gomock.Any(), gomock.Any()).AnyTimes().Return(db.UserTotp{}, db.ErrRecordNotFound)
+		mockStore.EXPECT().ListUserPermissions(gomock.Any(), gomock.Any()).AnyTimes().Return([]string{db.PermBanking, db.PermMerchantsManage}, nil)
+	}
+
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+
+	return server
+}
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 	os.Exit(m.Run())
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"simple_bank/internal/db"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (server *Server) listMerchantAdmins(ctx *gin.Context) {
+	var uri merchantURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeMerchant(ctx, uri.ID) {
+		return
+	}
+
+	admins, err := server.store.ListMerchantAdmins(ctx, uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(admins, newMerchantAdminResponse))
+}
+
+type addMerchantAdminRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+}
+
+// addMerchantAdmin lets an administrator of a merchant appoint another registered user
+func (server *Server) addMerchantAdmin(ctx *gin.Context) {
+	var uri merchantURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req addMerchantAdminRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeMerchant(ctx, uri.ID) {
+		return
+	}
+
+	admin, err := server.store.AddMerchantAdminTx(ctx, db.CreateMerchantAdminParams{
+		MerchantID: uri.ID,
+		Username:   req.Username,
+	})
+	if err != nil {
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			respondError(ctx, http.StatusConflict, err)
+		case db.ForeignKeyViolation:
+			err := fmt.Errorf("user %s is not registered", req.Username)
+			respondError(ctx, http.StatusUnprocessableEntity, err)
+		default:
+			respondError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newMerchantAdminResponse(admin))
+}
+
+type merchantAdminURI struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+func (server *Server) removeMerchantAdmin(ctx *gin.Context) {
+	var uri merchantAdminURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeMerchant(ctx, uri.ID) {
+		return
+	}
+
+	admin, err := server.store.RemoveMerchantAdminTx(ctx, db.DeleteMerchantAdminParams{
+		MerchantID: uri.ID,
+		Username:   uri.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrLastMerchantAdmin) {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newMerchantAdminResponse(admin))
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddMerchantAdminAPI(t *testing.T) {
+	merchant := db.Merchant{ID: 4, MerchantName: "shop", AdminID: 1}
+	caller := "alice"
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"username": "bob"},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), gomock.Eq(merchant.ID)).Times(1).Return(merchant, nil)
+				expectMerchantAdmin(store, merchant.ID, caller, true)
+				store.EXPECT().
+					AddMerchantAdminTx(gomock.Any(), gomock.Eq(db.CreateMerchantAdminParams{MerchantID: merchant.ID, Username: "bob"})).
+					Times(1).
+					Return(db.MerchantAdmin{MerchantID: merchant.ID, Username: "bob"}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var admin merchantAdminResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &admin))
+				require.Equal(t, "bob", admin.Username)
+			},
+		},
+		{
+			name: "NotMerchantAdmin",
+			body: gin.H{"username": "bob"},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), gomock.Eq(merchant.ID)).Times(1).Return(merchant, nil)
+				expectMerchantAdmin(store, merchant.ID, caller, false)
+				store.EXPECT().AddMerchantAdminTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyAdmin",
+			body: gin.H{"username": "bob"},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), gomock.Eq(merchant.ID)).Times(1).Return(merchant, nil)
+				expectMerchantAdmin(store, merchant.ID, caller, true)
+				store.EXPECT().
+					AddMerchantAdminTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MerchantAdmin{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownUser",
+			body: gin.H{"username": "nobody"},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), gomock.Eq(merchant.ID)).Times(1).Return(merchant, nil)
+				expectMerchantAdmin(store, merchant.ID, caller, true)
+				store.EXPECT().
+					AddMerchantAdminTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MerchantAdmin{}, &pgconn.PgError{Code: db.ForeignKeyViolation})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidUsername",
+			body: gin.H{"username": "bob!"},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/merchants/%d/admins", merchant.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, caller, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRemoveMerchantAdminAPI(t *testing.T) {
+	merchant := db.Merchant{ID: 4, MerchantName: "shop", AdminID: 1}
+	caller := "alice"
+
+	testCases := []struct {
+		name          string
+		removeErr     error
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "NotAnAdmin",
+			removeErr: db.ErrRecordNotFound,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "LastAdmin",
+			removeErr: db.ErrLastMerchantAdmin,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			store.EXPECT().GetMerchant(gomock.Any(), gomock.Eq(merchant.ID)).Times(1).Return(merchant, nil)
+			expectMerchantAdmin(store, merchant.ID, caller, true)
+			store.EXPECT().
+				RemoveMerchantAdminTx(gomock.Any(), gomock.Eq(db.DeleteMerchantAdminParams{MerchantID: merchant.ID, Username: "bob"})).
+				Times(1).
+				Return(db.MerchantAdmin{MerchantID: merchant.ID, Username: "bob"}, tc.removeErr)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/merchants/%d/admins/bob", merchant.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, caller, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"simple_bank/internal/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsMiddleware counts every request and its latency by route template, method and status
+func metricsMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		metrics.ObserveHTTPRequest(ctx.FullPath(), ctx.Request.Method, ctx.Writer.Status(), time.Since(start))
+	}
+}
+
+// serveMetrics serves the metrics of the default Prometheus registry for scraping
+func serveMetrics() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
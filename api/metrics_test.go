@@ -0,0 +1,71 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/internal/metrics"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// TestTransferMetrics checks that a refused transfer is counted by its error code and shows up on /metrics
+func TestTransferMetrics(t *testing.T) {
+	account1 := randomAccount()
+	account1.Status = db.AccountFrozen
+	account2 := randomAccount()
+	account2.ID = account1.ID + 1
+	account2.Currency = account1.Currency
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+
+	failed := metrics.TransfersTotal.WithLabelValues(metrics.TransferFailed, "account_cannot_send")
+	failedBefore := testutil.ToFloat64(failed)
+	requests := metrics.HTTPRequestsTotal.WithLabelValues("/transfers", http.MethodPost, "403")
+	requestsBefore := testutil.ToFloat64(requests)
+
+	body, err := json.Marshal(gin.H{
+		"from_account_id":   account1.ID,
+		"to_account_id":     account2.ID,
+		"amount":            10,
+		"currency":          account1.Currency,
+		"confirm_new_payee": true,
+	})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(body))
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account1.Owner, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.Equal(t, failedBefore+1, testutil.ToFloat64(failed))
+	require.Equal(t, requestsBefore+1, testutil.ToFloat64(requests))
+
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/metrics", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `simple_bank_transfers_total{outcome="failed",reason="account_cannot_send"}`)
+	require.Contains(t, recorder.Body.String(), `simple_bank_http_request_duration_seconds_bucket{method="POST",route="/transfers",status="403"`)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/logging"
+	"simple_bank/internal/token"
+	"simple_bank/internal/totp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHeaderKey  = "authorization"
+	authorizationTypeBearer = "bearer"
+	authorizationPayloadKey = "authorization_payload"
+)
+
+// authMiddleware creates a gin middleware for authorization.
+// It rejects requests without a valid bearer token, or with one issued before the user last changed
+// their password, and stores the token payload in the context.
+// A second factor in the X-OTP header is checked as well, and marks the request as stepped up.
+func authMiddleware(tokenMaker token.Maker, store db.Store, totpCipher *totp.Cipher) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
+		if len(authorizationHeader) == 0 {
+			err := errors.New("authorization header is not provided")
+			abortWithError(ctx, http.StatusUnauthorized, err)
+			return
+		}
+
+		fields := strings.Fields(authorizationHeader)
+		if len(fields) < 2 {
+			err := errors.New("invalid authorization header format")
+			abortWithError(ctx, http.StatusUnauthorized, err)
+			return
+		}
+
+		authorizationType := strings.ToLower(fields[0])
+		if authorizationType != authorizationTypeBearer {
+			err := fmt.Errorf("unsupported authorization type %s", authorizationType)
+			abortWithError(ctx, http.StatusUnauthorized, err)
+			return
+		}
+
+		accessToken := fields[1]
+		payload, err := tokenMaker.VerifyToken(accessToken)
+		if err != nil {
+			abortWithError(ctx, http.StatusUnauthorized, err)
+			return
+		}
+
+		passwordChangedAt, err := store.GetUserPasswordChangedAt(ctx, payload.Username)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				abortWithError(ctx, http.StatusUnauthorized, err)
+				return
+			}
+
+			abortWithError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		if passwordChangedAt.Valid && payload.IssuedAt.Before(passwordChangedAt.Time) {
+			abortWithError(ctx, http.StatusUnauthorized, errTokenRevoked)
+			return
+		}
+
+		if code := ctx.GetHeader(otpHeader); code != "" {
+			userTOTP, enrolled, err := db.ConfirmedTOTP(ctx, store, totpCipher, payload.Username)
+			if err != nil {
+				abortWithError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+
+			if !enrolled {
+				abortWithError(ctx, http.StatusUnauthorized, errTOTPNotEnabled)
+				return
+			}
+
+			err = db.CheckSecondFactor(ctx, store, userTOTP, code)
+			if err != nil {
+				if errors.Is(err, db.ErrInvalidOTP) {
+					abortWithError(ctx, http.StatusUnauthorized, err)
+					return
+				}
+
+				if errors.Is(err, db.ErrOTPLocked) {
+					abortWithError(ctx, http.StatusTooManyRequests, err)
+					return
+				}
+
+				abortWithError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+
+			ctx.Set(stepUpKey, true)
+		}
+
+		ctx.Set(authorizationPayloadKey, payload)
+		if info := db.AuditInfoFrom(ctx.Request.Context()); info != nil {
+			info.Actor = payload.Username
+		}
+		if fields := logging.FieldsFrom(ctx.Request.Context()); fields != nil {
+			fields.Username = payload.Username
+		}
+		ctx.Next()
+	}
+}
+
+// requirePermission creates a gin middleware, placed after authMiddleware, that only lets callers through
+// whose roles grant permission
+func requirePermission(store db.Store, permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		permissions, err := store.ListUserPermissions(ctx, authPayload.Username)
+		if err != nil {
+			abortWithError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		if !slices.Contains(permissions, permission) {
+			err := fmt.Errorf("user %s lacks the %s permission", authPayload.Username, permission)
+			abortWithError(ctx, http.StatusForbidden, err)
+			return
+		}
+
+		ctx.Next()
+	}
+}
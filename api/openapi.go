@@ -0,0 +1,439 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"simple_bank/util"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// openAPIOperation documents one route of NewServer. The request and response schemas are reflected from
+// the types the handler binds and writes, so the spec follows the code instead of being kept next to it.
+type openAPIOperation struct {
+	Method string
+	// Path is the route as registered with gin, e.g. /accounts/:id
+	Path    string
+	Tag     string
+	Summary string
+	// Public routes need no bearer token
+	Public bool
+	// Permission is the permission requirePermission checks on the route, if any
+	Permission string
+	// StepUp routes may need a second factor in the X-OTP header
+	StepUp bool
+	// URI, Query and Body are zero values of the request types the handler binds, nil if it binds none
+	URI, Query, Body any
+	// Responses maps every success status to a zero value of its body type, nil for an empty body
+	Responses map[int]any
+	// Errors are the statuses the handler responds with besides the ones every route of its kind can return
+	Errors []int
+}
+
+// openAPIUndocumentedRoutes are left out of the spec: the spec itself and the Prometheus scrape
+var openAPIUndocumentedRoutes = map[string]bool{
+	"GET /openapi.json": true,
+	"GET /docs":         true,
+	"GET /metrics":      true,
+}
+
+// ginParam matches the path parameters of gin routes
+var ginParam = regexp.MustCompile(`:(\w+)`)
+
+// openAPIPath turns a gin route into an OpenAPI path, e.g. /accounts/:id into /accounts/{id}
+func openAPIPath(path string) string {
+	return ginParam.ReplaceAllString(path, "{$1}")
+}
+
+// newOpenAPISpec builds the OpenAPI 3 document of operations
+func newOpenAPISpec(operations []openAPIOperation) map[string]any {
+	schemas := schemaBuilder{components: map[string]any{}}
+	paths := map[string]map[string]any{}
+
+	for _, op := range operations {
+		path := openAPIPath(op.Path)
+		if paths[path] == nil {
+			paths[path] = map[string]any{}
+		}
+		paths[path][strings.ToLower(op.Method)] = schemas.operation(op)
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "Simple Bank API",
+			"version":     "1.0.0",
+			"description": "Errors are returned as {\"error\": {...}} with a stable code clients can branch on.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}
+}
+
+// serveOpenAPI writes the spec of every route, built once in NewServer
+func (server *Server) serveOpenAPI(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", server.openAPISpec)
+}
+
+// swaggerUI loads Swagger UI from a CDN and points it at /openapi.json
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Simple Bank API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>`
+
+func (server *Server) serveDocs(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUI))
+}
+
+func mustMarshalSpec(spec map[string]any) []byte {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		panic(fmt.Sprintf("cannot marshal OpenAPI spec: %v", err))
+	}
+	return data
+}
+
+// schemaBuilder reflects Go types into OpenAPI schemas, collecting named structs as components
+type schemaBuilder struct {
+	components map[string]any
+}
+
+func (b *schemaBuilder) operation(op openAPIOperation) map[string]any {
+	doc := map[string]any{
+		"tags":        []string{op.Tag},
+		"summary":     op.Summary,
+		"operationId": strings.ToLower(op.Method) + strings.ReplaceAll(openAPIPath(op.Path), "/", "_"),
+	}
+
+	var params []any
+	if op.URI != nil {
+		params = append(params, b.parameters(reflect.TypeOf(op.URI), "path", "uri")...)
+	}
+	if op.Query != nil {
+		params = append(params, b.parameters(reflect.TypeOf(op.Query), "query", "form")...)
+	}
+	if op.StepUp {
+		params = append(params, map[string]any{
+			"name":        otpHeader,
+			"in":          "header",
+			"description": "A TOTP or recovery code, needed from users with two-factor authentication to trust a new payee, and for transfers at or above the step-up threshold",
+			"schema":      map[string]any{"type": "string"},
+		})
+	}
+	if params != nil {
+		doc["parameters"] = params
+	}
+
+	if op.Body != nil {
+		doc["requestBody"] = map[string]any{
+			"required": true,
+			"content":  jsonContent(b.schema(reflect.TypeOf(op.Body))),
+		}
+	}
+
+	if !op.Public {
+		doc["security"] = []any{map[string]any{"bearerAuth": []string{}}}
+	}
+	if op.Permission != "" {
+		doc["description"] = fmt.Sprintf("Requires the %s permission.", op.Permission)
+		doc["x-permission"] = op.Permission
+	}
+
+	responses := map[string]any{}
+	for status, body := range op.Responses {
+		rsp := map[string]any{"description": http.StatusText(status)}
+		if body != nil {
+			rsp["content"] = jsonContent(b.schema(reflect.TypeOf(body)))
+		}
+		responses[strconv.Itoa(status)] = rsp
+	}
+
+	errorSchema := b.schema(reflect.TypeOf(errorBody{}))
+	for _, status := range op.errorStatuses() {
+		responses[strconv.Itoa(status)] = map[string]any{
+			"description": http.StatusText(status),
+			"content":     jsonContent(errorSchema),
+		}
+	}
+	doc["responses"] = responses
+
+	return doc
+}
+
+// errorStatuses adds the statuses any route of its kind can return to the ones listed for op
+func (op openAPIOperation) errorStatuses() []int {
+	statuses := map[int]bool{http.StatusInternalServerError: true}
+	if op.URI != nil || op.Query != nil || op.Body != nil {
+		statuses[http.StatusBadRequest] = true
+	}
+	if !op.Public {
+		statuses[http.StatusUnauthorized] = true
+	}
+	if op.Permission != "" {
+		statuses[http.StatusForbidden] = true
+	}
+	for _, status := range op.Errors {
+		statuses[status] = true
+	}
+
+	sorted := make([]int, 0, len(statuses))
+	for status := range statuses {
+		sorted = append(sorted, status)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// parameters documents the fields of a uri or query request struct
+func (b *schemaBuilder) parameters(t reflect.Type, in, tagKey string) []any {
+	var params []any
+	for _, f := range structFields(t, tagKey) {
+		schema := b.schema(f.field.Type)
+		required := applyBindingRules(schema, f.field.Tag.Get("binding"))
+
+		params = append(params, map[string]any{
+			"name":     f.name,
+			"in":       in,
+			"required": required || in == "path",
+			"schema":   schema,
+		})
+	}
+
+	return params
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	nullableSchemas = map[reflect.Type]map[string]any{
+		reflect.TypeOf(pgtype.Timestamptz{}): {"type": "string", "format": "date-time"},
+		reflect.TypeOf(pgtype.Date{}):        {"type": "string", "format": "date"},
+		reflect.TypeOf(pgtype.Text{}):        {"type": "string"},
+		reflect.TypeOf(pgtype.Int4{}):        {"type": "integer", "format": "int32"},
+		reflect.TypeOf(pgtype.Int8{}):        {"type": "integer", "format": "int64"},
+	}
+)
+
+// schema returns the schema of values of t as encoding/json writes them
+func (b *schemaBuilder) schema(t reflect.Type) map[string]any {
+	if known, ok := nullableSchemas[t]; ok {
+		schema := map[string]any{"nullable": true}
+		for k, v := range known {
+			schema[k] = v
+		}
+		return schema
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == rawMessageType:
+		return map[string]any{"description": "any JSON value"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := b.schema(t.Elem())
+		if _, ok := schema["$ref"]; ok {
+			return map[string]any{"allOf": []any{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		return b.structRef(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+// structRef adds a struct to the components and returns a reference to it
+func (b *schemaBuilder) structRef(t reflect.Type) map[string]any {
+	name := componentName(t)
+	ref := map[string]any{"$ref": "#/components/schemas/" + name}
+	if _, ok := b.components[name]; ok {
+		return ref
+	}
+
+	// a placeholder stops self-referencing types from recursing forever
+	b.components[name] = map[string]any{}
+
+	properties := map[string]any{}
+	var required []string
+	for _, f := range structFields(t, "json") {
+		schema := b.schema(f.field.Type)
+		if applyBindingRules(schema, f.field.Tag.Get("binding")) {
+			required = append(required, f.name)
+		}
+		properties[f.name] = schema
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if required != nil {
+		schema["required"] = required
+	}
+	b.components[name] = schema
+
+	return ref
+}
+
+// componentName exports the name of t and prefixes it with its package outside of api,
+// e.g. db.Account becomes DbAccount and transferRequest becomes TransferRequest
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	if t.PkgPath() != reflect.TypeOf(Server{}).PkgPath() {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + strings.ToUpper(name[:1]) + name[1:]
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+type namedField struct {
+	name  string
+	field reflect.StructField
+}
+
+// structFields lists the fields of t under their tagKey names, flattening embedded structs like encoding/json
+func structFields(t reflect.Type, tagKey string) []namedField {
+	var fields []namedField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(tagKey), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, structFields(field.Type, tagKey)...)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			// uri and query fields are only bound by their tag
+			if tagKey != "json" {
+				continue
+			}
+			name = field.Name
+		}
+		fields = append(fields, namedField{name: name, field: field})
+	}
+
+	return fields
+}
+
+// applyBindingRules adds the constraints of binding tag rules to schema and reports whether the field is required
+func applyBindingRules(schema map[string]any, rules string) bool {
+	if rules == "" {
+		return false
+	}
+
+	target := schema
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		tag, param, _ := strings.Cut(rule, "=")
+		switch tag {
+		case "required":
+			required = true
+		case "dive":
+			// the rules after dive apply to the elements of a slice
+			if items, ok := schema["items"].(map[string]any); ok {
+				target = items
+			}
+		case "min", "max":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			key := map[string]string{"string": "Length", "array": "Items"}[fmt.Sprint(target["type"])]
+			if key == "" {
+				key = map[string]string{"min": "minimum", "max": "maximum"}[tag]
+			} else {
+				key = tag + key
+			}
+			target[key] = n
+		case "ne":
+			target["not"] = map[string]any{"enum": []any{enumValue(target, param)}}
+		case "oneof":
+			var values []any
+			for _, value := range strings.Fields(param) {
+				values = append(values, enumValue(target, value))
+			}
+			target["enum"] = values
+		case "currency":
+			target["enum"] = []any{util.USD, util.EUR, util.CAD}
+		case "email":
+			target["format"] = "email"
+		case "url":
+			target["format"] = "uri"
+		case "alphanum":
+			target["pattern"] = "^[a-zA-Z0-9]+$"
+		case "datetime":
+			if param == time.DateOnly {
+				target["format"] = "date"
+			}
+		case "required_without":
+			target["description"] = fmt.Sprintf("required unless %s is set", param)
+		case "excluded_with":
+			target["description"] = fmt.Sprintf("must be left out when %s is set", param)
+		}
+	}
+
+	return required
+}
+
+func enumValue(schema map[string]any, value string) any {
+	if schema["type"] == "integer" {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	}
+	return value
+}
@@ -0,0 +1,429 @@
+package api
+
+import (
+	"net/http"
+	"simple_bank/internal/db"
+)
+
+// openAPIOperations documents every route of NewServer, in the same order.
+// TestOpenAPICoversRoutes fails when a route is added without an entry here.
+var openAPIOperations = []openAPIOperation{
+	// orchestrator probes
+	{
+		Method: http.MethodGet, Path: "/healthz", Tag: "health", Public: true,
+		Summary:   "Report that the process is up, without checking any dependency",
+		Responses: map[int]any{http.StatusOK: livenessResponse{}},
+	},
+	{
+		Method: http.MethodGet, Path: "/readyz", Tag: "health", Public: true,
+		Summary: "Check the database, its migrations and the background workers; 503 when a critical check fails",
+		Responses: map[int]any{
+			http.StatusOK:                 readinessResponse{},
+			http.StatusServiceUnavailable: readinessResponse{},
+		},
+	},
+
+	// users
+	{
+		Method: http.MethodPost, Path: "/users", Tag: "users", Public: true,
+		Summary:   "Register a user and mail them an email verification link",
+		Body:      createUserRequest{},
+		Responses: map[int]any{http.StatusOK: userResponse{}},
+		Errors:    []int{http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/users/login", Tag: "users", Public: true,
+		Summary:   "Issue an access token, asking for a second factor once two-factor authentication is on",
+		Body:      loginUserRequest{},
+		Responses: map[int]any{http.StatusOK: loginUserResponse{}},
+		Errors:    []int{http.StatusUnauthorized, http.StatusTooManyRequests},
+	},
+	{
+		Method: http.MethodGet, Path: "/verify_email", Tag: "users", Public: true,
+		Summary:   "Verify a user's email address with the code from the emailed link",
+		Query:     verifyEmailRequest{},
+		Responses: map[int]any{http.StatusOK: verifyEmailResponse{}},
+	},
+	{
+		Method: http.MethodPost, Path: "/users/password/forgot", Tag: "users", Public: true,
+		Summary:   "Mail a password reset token, whether or not the user exists",
+		Body:      forgotPasswordRequest{},
+		Responses: map[int]any{http.StatusAccepted: nil},
+	},
+	{
+		Method: http.MethodPost, Path: "/users/password/reset", Tag: "users", Public: true,
+		Summary:   "Set a new password with a reset token",
+		Body:      resetPasswordRequest{},
+		Responses: map[int]any{http.StatusOK: userResponse{}},
+	},
+	{
+		Method: http.MethodPost, Path: "/users/kyc", Tag: "users",
+		Summary:   "Submit the caller's KYC profile for verification",
+		Body:      submitKYCRequest{},
+		Responses: map[int]any{http.StatusOK: userResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusBadGateway},
+	},
+	{
+		Method: http.MethodPost, Path: "/users/verify_email", Tag: "users",
+		Summary:   "Send the caller a new email verification link",
+		Responses: map[int]any{http.StatusAccepted: nil},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/users/password", Tag: "users",
+		Summary:   "Change the caller's password, revoking their earlier access tokens",
+		Body:      changePasswordRequest{},
+		Responses: map[int]any{http.StatusOK: loginUserResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/users/totp", Tag: "users",
+		Summary:   "Start enrolling the caller in TOTP two-factor authentication",
+		Responses: map[int]any{http.StatusOK: enrollTOTPResponse{}},
+		Errors:    []int{http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/users/totp/confirm", Tag: "users",
+		Summary:   "Turn on two-factor authentication with a code from the app and get recovery codes",
+		Body:      totpCodeRequest{},
+		Responses: map[int]any{http.StatusOK: confirmTOTPResponse{}},
+		Errors:    []int{http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/users/totp/disable", Tag: "users",
+		Summary:   "Turn off two-factor authentication with a current code",
+		Body:      totpCodeRequest{},
+		Responses: map[int]any{http.StatusNoContent: nil},
+		Errors:    []int{http.StatusNotFound, http.StatusTooManyRequests},
+	},
+
+	// accounts
+	{
+		Method: http.MethodPost, Path: "/accounts", Tag: "accounts", Permission: db.PermBanking,
+		Summary:   "Open an account owned by the caller, within their KYC tier's limit",
+		Body:      createAccountRequest{},
+		Responses: map[int]any{http.StatusOK: accountResponse{}},
+		Errors:    []int{http.StatusConflict},
+	},
+	{
+		Method: http.MethodGet, Path: "/accounts/:id", Tag: "accounts", Permission: db.PermBanking,
+		Summary:   "Get an account the caller holds",
+		URI:       getAccountRequest{},
+		Responses: map[int]any{http.StatusOK: accountResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/accounts", Tag: "accounts", Permission: db.PermBanking,
+		Summary:   "List the accounts the caller holds",
+		Query:     listAccountRequest{},
+		Responses: map[int]any{http.StatusOK: []accountResponse{}},
+	},
+	{
+		Method: http.MethodPatch, Path: "/accounts/:id/status", Tag: "accounts", Permission: db.PermBanking,
+		Summary:   "Freeze, debit block or reactivate an account the caller owns",
+		URI:       accountURI{},
+		Body:      updateAccountStatusRequest{},
+		Responses: map[int]any{http.StatusOK: accountResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/accounts/:id/close", Tag: "accounts", Permission: db.PermBanking,
+		Summary:   "Sweep an account's balance to another account and close it",
+		URI:       accountURI{},
+		Body:      closeAccountRequest{},
+		Responses: map[int]any{http.StatusOK: closeAccountResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+	},
+	{
+		Method: http.MethodGet, Path: "/accounts/:id/holders", Tag: "accounts", Permission: db.PermBanking,
+		Summary:   "List the holders of an account",
+		URI:       accountURI{},
+		Responses: map[int]any{http.StatusOK: []accountHolderResponse{}},
+	},
+	{
+		Method: http.MethodPost, Path: "/accounts/:id/holders", Tag: "accounts", Permission: db.PermBanking,
+		Summary:   "Add a joint holder to an account the caller owns",
+		URI:       accountURI{},
+		Body:      addAccountHolderRequest{},
+		Responses: map[int]any{http.StatusOK: accountHolderResponse{}},
+		Errors:    []int{http.StatusConflict, http.StatusUnprocessableEntity},
+	},
+	{
+		Method: http.MethodDelete, Path: "/accounts/:id/holders/:username", Tag: "accounts", Permission: db.PermBanking,
+		Summary:   "Remove a holder from an account the caller owns, keeping at least one owner",
+		URI:       accountHolderURI{},
+		Responses: map[int]any{http.StatusOK: accountHolderResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodGet, Path: "/accounts/:id/holder_events", Tag: "accounts", Permission: db.PermBanking,
+		Summary:   "Audit trail of holder changes on an account the caller owns",
+		URI:       accountURI{},
+		Responses: map[int]any{http.StatusOK: []accountHolderEventResponse{}},
+	},
+
+	// saved payees
+	{
+		Method: http.MethodPost, Path: "/beneficiaries", Tag: "beneficiaries", Permission: db.PermBanking,
+		Summary:   "Save a payee, unverified until the caller confirms it",
+		Body:      createBeneficiaryRequest{},
+		Responses: map[int]any{http.StatusOK: beneficiaryResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodGet, Path: "/beneficiaries", Tag: "beneficiaries", Permission: db.PermBanking,
+		Summary:   "List the caller's saved payees",
+		Query:     listBeneficiariesRequest{},
+		Responses: map[int]any{http.StatusOK: []beneficiaryResponse{}},
+	},
+	{
+		Method: http.MethodGet, Path: "/beneficiaries/:id", Tag: "beneficiaries", Permission: db.PermBanking,
+		Summary:   "Get one of the caller's saved payees",
+		URI:       beneficiaryURI{},
+		Responses: map[int]any{http.StatusOK: beneficiaryResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodPatch, Path: "/beneficiaries/:id", Tag: "beneficiaries", Permission: db.PermBanking,
+		Summary:   "Rename a saved payee",
+		URI:       beneficiaryURI{},
+		Body:      updateBeneficiaryRequest{},
+		Responses: map[int]any{http.StatusOK: beneficiaryResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodDelete, Path: "/beneficiaries/:id", Tag: "beneficiaries", Permission: db.PermBanking,
+		Summary:   "Delete a saved payee",
+		URI:       beneficiaryURI{},
+		Responses: map[int]any{http.StatusOK: beneficiaryResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/beneficiaries/:id/verify", Tag: "beneficiaries", Permission: db.PermBanking, StepUp: true,
+		Summary:   "Confirm a saved payee, so transfers to it need no new payee confirmation; takes X-OTP from users with two-factor authentication",
+		URI:       beneficiaryURI{},
+		Responses: map[int]any{http.StatusOK: beneficiaryResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusTooManyRequests},
+	},
+
+	// account transfers
+	{
+		Method: http.MethodPost, Path: "/transfers", Tag: "transfers", Permission: db.PermBanking, StepUp: true,
+		Summary: "Screen and post a transfer, or hold it for review when screening flags it",
+		Body:    transferRequest{},
+		Responses: map[int]any{
+			http.StatusOK:       transferResultResponse{},
+			http.StatusAccepted: transferReviewResponse{},
+		},
+		Errors: []int{http.StatusNotFound, http.StatusPreconditionRequired, http.StatusTooManyRequests},
+	},
+
+	// merchant administrators
+	{
+		Method: http.MethodGet, Path: "/merchants/:id/admins", Tag: "merchants", Permission: db.PermMerchantsManage,
+		Summary:   "List the administrators of a merchant the caller administers",
+		URI:       merchantURI{},
+		Responses: map[int]any{http.StatusOK: []merchantAdminResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/merchants/:id/admins", Tag: "merchants", Permission: db.PermMerchantsManage,
+		Summary:   "Make a user an administrator of the merchant",
+		URI:       merchantURI{},
+		Body:      addMerchantAdminRequest{},
+		Responses: map[int]any{http.StatusOK: merchantAdminResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+	},
+	{
+		Method: http.MethodDelete, Path: "/merchants/:id/admins/:username", Tag: "merchants", Permission: db.PermMerchantsManage,
+		Summary:   "Remove an administrator of the merchant, keeping at least one",
+		URI:       merchantAdminURI{},
+		Responses: map[int]any{http.StatusOK: merchantAdminResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/merchants/:id/webhooks", Tag: "merchants", Permission: db.PermMerchantsManage,
+		Summary:   "Register a webhook endpoint; its signing secret is only shown once",
+		URI:       merchantURI{},
+		Body:      createWebhookEndpointRequest{},
+		Responses: map[int]any{http.StatusOK: createWebhookEndpointResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/:id/webhooks", Tag: "merchants", Permission: db.PermMerchantsManage,
+		Summary:   "List the merchant's webhook endpoints",
+		URI:       merchantURI{},
+		Responses: map[int]any{http.StatusOK: []webhookEndpointResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodDelete, Path: "/merchants/:id/webhooks/:webhook_id", Tag: "merchants", Permission: db.PermMerchantsManage,
+		Summary:   "Delete a webhook endpoint",
+		URI:       webhookEndpointURI{},
+		Responses: map[int]any{http.StatusOK: webhookEndpointResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/:id/webhook_deliveries", Tag: "merchants", Permission: db.PermMerchantsManage,
+		Summary:   "Delivery log of the merchant's webhooks",
+		URI:       merchantURI{},
+		Query:     listWebhookDeliveriesRequest{},
+		Responses: map[int]any{http.StatusOK: []webhookDeliveryResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/merchants/:id/webhook_deliveries/:delivery_id/retry", Tag: "merchants", Permission: db.PermMerchantsManage,
+		Summary:   "Deliver a webhook again",
+		URI:       webhookDeliveryURI{},
+		Responses: map[int]any{http.StatusOK: webhookDeliveryResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+
+	// bank staff
+	{
+		Method: http.MethodGet, Path: "/transfer_reviews", Tag: "staff", Permission: db.PermTransferReviewsDecide,
+		Summary:   "List transfers held by fraud screening",
+		Query:     listTransferReviewsRequest{},
+		Responses: map[int]any{http.StatusOK: []transferReviewResponse{}},
+	},
+	{
+		Method: http.MethodPost, Path: "/transfer_reviews/:id/approve", Tag: "staff", Permission: db.PermTransferReviewsDecide,
+		Summary:   "Approve a held transfer, posting it",
+		URI:       transferReviewURI{},
+		Responses: map[int]any{http.StatusOK: reviewTransferResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/transfer_reviews/:id/reject", Tag: "staff", Permission: db.PermTransferReviewsDecide,
+		Summary:   "Reject a held transfer",
+		URI:       transferReviewURI{},
+		Responses: map[int]any{http.StatusOK: reviewTransferResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodGet, Path: "/audit_events", Tag: "staff", Permission: db.PermAuditEventsRead,
+		Summary:   "Search the audit log",
+		Query:     listAuditEventsRequest{},
+		Responses: map[int]any{http.StatusOK: []auditEventResponse{}},
+	},
+
+	// back office
+	{
+		Method: http.MethodGet, Path: "/admin/users", Tag: "admin", Permission: db.PermBackOffice,
+		Summary:   "Search users by username, email or full name",
+		Query:     searchUsersRequest{},
+		Responses: map[int]any{http.StatusOK: []userResponse{}},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/users/:username", Tag: "admin", Permission: db.PermBackOffice,
+		Summary:   "Get a user and their roles",
+		URI:       usernameURI{},
+		Responses: map[int]any{http.StatusOK: adminUserResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/users/:username/accounts", Tag: "admin", Permission: db.PermBackOffice,
+		Summary:   "List the accounts a user holds",
+		URI:       usernameURI{},
+		Query:     adminPageRequest{},
+		Responses: map[int]any{http.StatusOK: []accountResponse{}},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/accounts/:id", Tag: "admin", Permission: db.PermBackOffice,
+		Summary:   "Get any account",
+		URI:       accountURI{},
+		Responses: map[int]any{http.StatusOK: accountResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/entries", Tag: "admin", Permission: db.PermBackOffice,
+		Summary:   "The ledger across accounts, or of one account",
+		Query:     listLedgerRequest{},
+		Responses: map[int]any{http.StatusOK: []entryResponse{}},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/outbox_events", Tag: "admin", Permission: db.PermBackOffice,
+		Summary:   "Inspect outbox events",
+		Query:     listOutboxEventsRequest{},
+		Responses: map[int]any{http.StatusOK: []outboxEventResponse{}},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/jobs", Tag: "admin", Permission: db.PermBackOffice,
+		Summary:   "Inspect background jobs",
+		Query:     listJobsRequest{},
+		Responses: map[int]any{http.StatusOK: []jobResponse{}},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/jobs/:id", Tag: "admin", Permission: db.PermBackOffice,
+		Summary:   "Get a background job",
+		URI:       jobURI{},
+		Responses: map[int]any{http.StatusOK: jobResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/accounts/:id/freeze", Tag: "admin", Permission: db.PermAccountsFreeze,
+		Summary:   "Freeze any account",
+		URI:       accountURI{},
+		Responses: map[int]any{http.StatusOK: accountResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/accounts/:id/unfreeze", Tag: "admin", Permission: db.PermAccountsFreeze,
+		Summary:   "Reactivate a frozen account",
+		URI:       accountURI{},
+		Responses: map[int]any{http.StatusOK: accountResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/gl_accounts", Tag: "admin", Permission: db.PermBackOffice,
+		Summary:   "The bank's GL accounts",
+		Responses: map[int]any{http.StatusOK: []glAccountResponse{}},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/adjustments", Tag: "admin", Permission: db.PermBackOffice,
+		Summary:   "List manual ledger adjustments",
+		Query:     listAdjustmentsRequest{},
+		Responses: map[int]any{http.StatusOK: []adjustmentResponse{}},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/adjustments", Tag: "admin", Permission: db.PermLedgerAdjust,
+		Summary:   "Request a manual credit or debit, applied once someone else approves it",
+		Body:      requestAdjustmentRequest{},
+		Responses: map[int]any{http.StatusAccepted: adjustmentResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/adjustments/:id/approve", Tag: "admin", Permission: db.PermLedgerApprove,
+		Summary:   "Approve someone else's adjustment request, posting it against its GL account",
+		URI:       adjustmentURI{},
+		Responses: map[int]any{http.StatusOK: decideAdjustmentResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/adjustments/:id/reject", Tag: "admin", Permission: db.PermLedgerApprove,
+		Summary:   "Reject someone else's adjustment request",
+		URI:       adjustmentURI{},
+		Responses: map[int]any{http.StatusOK: decideAdjustmentResponse{}},
+		Errors:    []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/roles", Tag: "admin", Permission: db.PermRolesManage,
+		Summary:   "List roles and their permissions",
+		Responses: map[int]any{http.StatusOK: []roleResponse{}},
+	},
+	{
+		Method: http.MethodPost, Path: "/admin/users/:username/roles", Tag: "admin", Permission: db.PermRolesManage,
+		Summary:   "Grant a user a role",
+		URI:       usernameURI{},
+		Body:      grantRoleRequest{},
+		Responses: map[int]any{http.StatusOK: userRoleResponse{}},
+		Errors:    []int{http.StatusUnprocessableEntity},
+	},
+	{
+		Method: http.MethodDelete, Path: "/admin/users/:username/roles/:role", Tag: "admin", Permission: db.PermRolesManage,
+		Summary:   "Revoke a role from a user other than the caller",
+		URI:       userRoleURI{},
+		Responses: map[int]any{http.StatusOK: userRoleResponse{}},
+		Errors:    []int{http.StatusNotFound},
+	},
+}
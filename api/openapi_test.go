@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	mock_db "simple_bank/internal/db/mock"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type openAPIDoc struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Required   []string                   `json:"required"`
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func getOpenAPIDoc(t *testing.T, server *Server) (openAPIDoc, []byte) {
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var doc openAPIDoc
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+	return doc, recorder.Body.Bytes()
+}
+
+// TestOpenAPICoversRoutes fails when a route of NewServer has no entry in openAPIOperations, or the other way round
+func TestOpenAPICoversRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mock_db.NewMockStore(ctrl))
+	doc, _ := getOpenAPIDoc(t, server)
+
+	documented := 0
+	for _, route := range server.router.Routes() {
+		if openAPIUndocumentedRoutes[route.Method+" "+route.Path] {
+			continue
+		}
+		documented++
+
+		operations, ok := doc.Paths[openAPIPath(route.Path)]
+		require.True(t, ok, "route %s %s is missing from the OpenAPI spec", route.Method, route.Path)
+		_, ok = operations[strings.ToLower(route.Method)]
+		require.True(t, ok, "route %s %s is missing from the OpenAPI spec", route.Method, route.Path)
+	}
+
+	require.Len(t, openAPIOperations, documented, "the OpenAPI spec documents routes NewServer doesn't register")
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mock_db.NewMockStore(ctrl))
+	doc, body := getOpenAPIDoc(t, server)
+	require.Equal(t, "3.0.3", doc.OpenAPI)
+
+	// every reference points at a component
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				name := strings.TrimPrefix(ref, "#/components/schemas/")
+				require.Contains(t, doc.Components.Schemas, name)
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	var raw any
+	require.NoError(t, json.Unmarshal(body, &raw))
+	walk(raw)
+
+	transfer := doc.Components.Schemas["TransferRequest"]
+	require.ElementsMatch(t, []string{"from_account_id", "amount", "currency"}, transfer.Required)
+	require.JSONEq(t, `{"type":"string","enum":["USD","EUR","CAD"]}`, string(transfer.Properties["currency"]))
+
+	require.Contains(t, doc.Components.Schemas["AccountResponse"].Properties, "balance")
+	require.Contains(t, doc.Components.Schemas["Money"].Properties, "minor_units")
+	require.Contains(t, doc.Components.Schemas["ErrorBody"].Properties, "error")
+}
+
+func TestServeDocs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mock_db.NewMockStore(ctrl))
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/docs", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `url: "/openapi.json"`)
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/jobs"
+	"simple_bank/internal/tasks"
+	"simple_bank/internal/token"
+	"simple_bank/util"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// errTokenRevoked is returned for access tokens issued before the user's last password change
+var errTokenRevoked = errors.New("token was issued before the password was changed")
+
+// passwordChangedNow is the password_changed_at of a password set now.
+// It is truncated to the microseconds Postgres stores, so tokens issued afterwards never compare as older.
+func passwordChangedNow() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now().Truncate(time.Microsecond), Valid: true}
+}
+
+type changePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required,min=6"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+// changePassword sets a new password for the caller. Every earlier access token stops working,
+// so the response carries a fresh one.
+func (server *Server) changePassword(ctx *gin.Context) {
+	var req changePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = util.CheckPassword(req.OldPassword, user.HashedPassword)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	user, err = server.store.UpdatePasswordTx(ctx, db.UpdateUserPasswordParams{
+		Username:          user.Username,
+		HashedPassword:    hashedPassword,
+		PasswordChangedAt: passwordChangedNow(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.AccessTokenDuration)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, loginUserResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessPayload.ExpiredAt,
+		User:                 newUserResponse(user),
+	})
+}
+
+type forgotPasswordRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+}
+
+// forgotPassword mails a reset token to the user's address.
+// It answers the same whether or not the user exists, so it can't be used to probe for usernames.
+func (server *Server) forgotPassword(ctx *gin.Context) {
+	var req forgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.Status(http.StatusAccepted)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// a send already queued for the user is enough
+	_, err = tasks.SendPasswordReset.Enqueue(ctx, server.store, tasks.PasswordResetPayload{Username: user.Username},
+		jobs.UniqueKey("password_reset:"+user.Username))
+	if err != nil && !errors.Is(err, jobs.ErrDuplicate) {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
+
+type resetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+// resetPassword sets a new password with a token from forgotPassword
+func (server *Server) resetPassword(ctx *gin.Context) {
+	var req resetPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	user, err := server.store.ResetPasswordTx(ctx, db.ResetPasswordTxParams{
+		TokenHash:         tasks.HashResetToken(req.Token),
+		HashedPassword:    hashedPassword,
+		PasswordChangedAt: passwordChangedNow(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidPasswordReset) {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
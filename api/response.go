@@ -0,0 +1,524 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"simple_bank/internal/db"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The types in this file are the wire format of the resources handlers return.
+// They are built from the sqlc models instead of serializing them, so a schema change
+// only reaches clients when one of these types changes with it.
+
+// money is an amount in minor units, e.g. cents, with its currency when the resource knows it.
+// Display is the same amount in major units for showing to people, e.g. "-12.50 USD".
+type money struct {
+	MinorUnits int64  `json:"minor_units"`
+	Currency   string `json:"currency,omitempty"`
+	Display    string `json:"display"`
+}
+
+func newMoney(minorUnits int64, currency string) money {
+	sign := ""
+	abs := minorUnits
+	if minorUnits < 0 {
+		sign = "-"
+		abs = -minorUnits
+	}
+
+	display := fmt.Sprintf("%s%d.%02d", sign, abs/100, abs%100)
+	if currency != "" {
+		display += " " + currency
+	}
+
+	return money{MinorUnits: minorUnits, Currency: currency, Display: display}
+}
+
+// timestamp writes t in UTC, which encoding/json formats as RFC 3339
+func timestamp(t pgtype.Timestamptz) time.Time {
+	return t.Time.UTC()
+}
+
+// optionalTimestamp is timestamp for columns that may be NULL, written as null
+func optionalTimestamp(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	ts := t.Time.UTC()
+	return &ts
+}
+
+func optionalText(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
+
+func optionalInt(i pgtype.Int8) *int64 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int64
+}
+
+// convertAll builds the response of every item in a list
+func convertAll[T, R any](items []T, convert func(T) R) []R {
+	rsp := make([]R, len(items))
+	for i, item := range items {
+		rsp[i] = convert(item)
+	}
+	return rsp
+}
+
+type accountResponse struct {
+	ID          int64      `json:"id"`
+	Owner       string     `json:"owner"`
+	Balance     money      `json:"balance"`
+	Currency    string     `json:"currency"`
+	CountryCode int32      `json:"country_code"`
+	Status      string     `json:"status"`
+	FrozenBy    *string    `json:"frozen_by"`
+	ClosedAt    *time.Time `json:"closed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func newAccountResponse(account db.Account) accountResponse {
+	return accountResponse{
+		ID:          account.ID,
+		Owner:       account.Owner,
+		Balance:     newMoney(account.Balance, account.Currency),
+		Currency:    account.Currency,
+		CountryCode: account.CountryCode,
+		Status:      account.Status,
+		FrozenBy:    optionalText(account.FrozenBy),
+		ClosedAt:    optionalTimestamp(account.ClosedAt),
+		CreatedAt:   timestamp(account.CreatedAt),
+		UpdatedAt:   timestamp(account.UpdatedAt),
+	}
+}
+
+type entryResponse struct {
+	ID        int64     `json:"id"`
+	AccountID int64     `json:"account_id"`
+	Amount    money     `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// newEntryResponse takes the currency of the entry's account, or "" when it isn't at hand
+func newEntryResponse(entry db.Entry, currency string) entryResponse {
+	return entryResponse{
+		ID:        entry.ID,
+		AccountID: entry.AccountID,
+		Amount:    newMoney(entry.Amount, currency),
+		CreatedAt: timestamp(entry.CreatedAt),
+	}
+}
+
+type transferResponse struct {
+	ID            int64     `json:"id"`
+	FromAccountID int64     `json:"from_account_id"`
+	ToAccountID   int64     `json:"to_account_id"`
+	Amount        money     `json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// transferResultResponse is a posted transfer with both sides of it
+type transferResultResponse struct {
+	Transfer    transferResponse `json:"transfer"`
+	FromAccount accountResponse  `json:"from_account"`
+	ToAccount   accountResponse  `json:"to_account"`
+	FromEntry   entryResponse    `json:"from_entry"`
+	ToEntry     entryResponse    `json:"to_entry"`
+}
+
+func newTransferResultResponse(result db.TransferTxResult) transferResultResponse {
+	currency := result.FromAccount.Currency
+
+	return transferResultResponse{
+		Transfer: transferResponse{
+			ID:            result.Transfer.ID,
+			FromAccountID: result.Transfer.FromAccountID,
+			ToAccountID:   result.Transfer.ToAccountID,
+			Amount:        newMoney(result.Transfer.Amount, currency),
+			CreatedAt:     timestamp(result.Transfer.CreatedAt),
+		},
+		FromAccount: newAccountResponse(result.FromAccount),
+		ToAccount:   newAccountResponse(result.ToAccount),
+		FromEntry:   newEntryResponse(result.FromEntry, currency),
+		ToEntry:     newEntryResponse(result.ToEntry, result.ToAccount.Currency),
+	}
+}
+
+type closeAccountResponse struct {
+	Account accountResponse `json:"account"`
+	// Sweep is the transfer that moved the remaining balance, null if there was none
+	Sweep *transferResultResponse `json:"sweep"`
+}
+
+func newCloseAccountResponse(result db.CloseAccountTxResult) closeAccountResponse {
+	rsp := closeAccountResponse{Account: newAccountResponse(result.Account)}
+	if result.Sweep != nil {
+		sweep := newTransferResultResponse(*result.Sweep)
+		rsp.Sweep = &sweep
+	}
+	return rsp
+}
+
+type transferReviewResponse struct {
+	ID            int64      `json:"id"`
+	FromAccountID int64      `json:"from_account_id"`
+	ToAccountID   int64      `json:"to_account_id"`
+	Amount        money      `json:"amount"`
+	Reasons       []string   `json:"reasons"`
+	Status        string     `json:"status"`
+	TransferID    *int64     `json:"transfer_id"`
+	ReviewedBy    *string    `json:"reviewed_by"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func newTransferReviewResponse(review db.TransferReview) transferReviewResponse {
+	reasons := review.Reasons
+	if reasons == nil {
+		reasons = []string{}
+	}
+
+	return transferReviewResponse{
+		ID:            review.ID,
+		FromAccountID: review.FromAccountID,
+		ToAccountID:   review.ToAccountID,
+		Amount:        newMoney(review.Amount, ""),
+		Reasons:       reasons,
+		Status:        review.Status,
+		TransferID:    optionalInt(review.TransferID),
+		ReviewedBy:    optionalText(review.ReviewedBy),
+		ReviewedAt:    optionalTimestamp(review.ReviewedAt),
+		CreatedAt:     timestamp(review.CreatedAt),
+	}
+}
+
+type reviewTransferResponse struct {
+	Review transferReviewResponse `json:"review"`
+	// Transfer is the posted transfer of an approved review, null for a rejected one
+	Transfer *transferResultResponse `json:"transfer"`
+}
+
+func newReviewTransferResponse(result db.ReviewTransferTxResult) reviewTransferResponse {
+	rsp := reviewTransferResponse{Review: newTransferReviewResponse(result.Review)}
+	if result.Transfer != nil {
+		transfer := newTransferResultResponse(*result.Transfer)
+		rsp.Transfer = &transfer
+	}
+	return rsp
+}
+
+type accountHolderResponse struct {
+	AccountID int64     `json:"account_id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newAccountHolderResponse(holder db.AccountHolder) accountHolderResponse {
+	return accountHolderResponse{
+		AccountID: holder.AccountID,
+		Username:  holder.Username,
+		Role:      holder.Role,
+		CreatedAt: timestamp(holder.CreatedAt),
+	}
+}
+
+type accountHolderEventResponse struct {
+	ID        int64     `json:"id"`
+	AccountID int64     `json:"account_id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newAccountHolderEventResponse(event db.AccountHolderEvent) accountHolderEventResponse {
+	return accountHolderEventResponse{
+		ID:        event.ID,
+		AccountID: event.AccountID,
+		Username:  event.Username,
+		Role:      event.Role,
+		Action:    event.Action,
+		Actor:     event.Actor,
+		CreatedAt: timestamp(event.CreatedAt),
+	}
+}
+
+type beneficiaryResponse struct {
+	ID        int64     `json:"id"`
+	Nickname  string    `json:"nickname"`
+	AccountID int64     `json:"account_id"`
+	Verified  bool      `json:"verified"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newBeneficiaryResponse(beneficiary db.Beneficiary) beneficiaryResponse {
+	return beneficiaryResponse{
+		ID:        beneficiary.ID,
+		Nickname:  beneficiary.Nickname,
+		AccountID: beneficiary.AccountID,
+		Verified:  beneficiary.Verified,
+		CreatedAt: timestamp(beneficiary.CreatedAt),
+	}
+}
+
+type merchantAdminResponse struct {
+	MerchantID int64     `json:"merchant_id"`
+	Username   string    `json:"username"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func newMerchantAdminResponse(admin db.MerchantAdmin) merchantAdminResponse {
+	return merchantAdminResponse{
+		MerchantID: admin.MerchantID,
+		Username:   admin.Username,
+		CreatedAt:  timestamp(admin.CreatedAt),
+	}
+}
+
+type webhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	EndpointID     int64           `json:"endpoint_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastError      *string         `json:"last_error"`
+	ResponseStatus *int32          `json:"response_status"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func newWebhookDeliveryResponse(delivery db.WebhookDelivery) webhookDeliveryResponse {
+	rsp := webhookDeliveryResponse{
+		ID:            delivery.ID,
+		EndpointID:    delivery.EndpointID,
+		EventID:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: timestamp(delivery.NextAttemptAt),
+		LastError:     optionalText(delivery.LastError),
+		DeliveredAt:   optionalTimestamp(delivery.DeliveredAt),
+		CreatedAt:     timestamp(delivery.CreatedAt),
+	}
+	if delivery.ResponseStatus.Valid {
+		rsp.ResponseStatus = &delivery.ResponseStatus.Int32
+	}
+	return rsp
+}
+
+type auditEventResponse struct {
+	ID           int64           `json:"id"`
+	Actor        string          `json:"actor"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   string          `json:"resource_id"`
+	Before       json.RawMessage `json:"before"`
+	After        json.RawMessage `json:"after"`
+	IP           string          `json:"ip"`
+	RequestID    string          `json:"request_id"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+func newAuditEventResponse(event db.AuditEvent) auditEventResponse {
+	return auditEventResponse{
+		ID:           event.ID,
+		Actor:        event.Actor,
+		Action:       event.Action,
+		ResourceType: event.ResourceType,
+		ResourceID:   event.ResourceID,
+		Before:       event.Before,
+		After:        event.After,
+		IP:           event.Ip,
+		RequestID:    event.RequestID,
+		CreatedAt:    timestamp(event.CreatedAt),
+	}
+}
+
+type outboxEventResponse struct {
+	ID            int64           `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int32           `json:"attempts"`
+	LastError     *string         `json:"last_error"`
+	PublishedAt   *time.Time      `json:"published_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func newOutboxEventResponse(event db.Outbox) outboxEventResponse {
+	return outboxEventResponse{
+		ID:            event.ID,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		EventType:     event.EventType,
+		Payload:       event.Payload,
+		Attempts:      event.Attempts,
+		LastError:     optionalText(event.LastError),
+		PublishedAt:   optionalTimestamp(event.PublishedAt),
+		CreatedAt:     timestamp(event.CreatedAt),
+	}
+}
+
+type jobResponse struct {
+	ID          int64           `json:"id"`
+	Kind        string          `json:"kind"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	UniqueKey   *string         `json:"unique_key"`
+	Attempts    int32           `json:"attempts"`
+	MaxAttempts int32           `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedAt    *time.Time      `json:"locked_at"`
+	LastError   *string         `json:"last_error"`
+	FinishedAt  *time.Time      `json:"finished_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func newJobResponse(job db.Job) jobResponse {
+	return jobResponse{
+		ID:          job.ID,
+		Kind:        job.Kind,
+		Payload:     job.Payload,
+		Status:      job.Status,
+		UniqueKey:   optionalText(job.UniqueKey),
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       timestamp(job.RunAt),
+		LockedAt:    optionalTimestamp(job.LockedAt),
+		LastError:   optionalText(job.LastError),
+		FinishedAt:  optionalTimestamp(job.FinishedAt),
+		CreatedAt:   timestamp(job.CreatedAt),
+	}
+}
+
+type glAccountResponse struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Currency  string    `json:"currency"`
+	Balance   money     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newGLAccountResponse(glAccount db.GlAccount) glAccountResponse {
+	return glAccountResponse{
+		ID:        glAccount.ID,
+		Code:      glAccount.Code,
+		Currency:  glAccount.Currency,
+		Balance:   newMoney(glAccount.Balance, glAccount.Currency),
+		CreatedAt: timestamp(glAccount.CreatedAt),
+	}
+}
+
+type glEntryResponse struct {
+	ID          int64     `json:"id"`
+	GLAccountID int64     `json:"gl_account_id"`
+	Amount      money     `json:"amount"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type adjustmentResponse struct {
+	ID          int64      `json:"id"`
+	AccountID   int64      `json:"account_id"`
+	GLCode      string     `json:"gl_code"`
+	Amount      money      `json:"amount"`
+	ReasonCode  string     `json:"reason_code"`
+	Note        string     `json:"note"`
+	Status      string     `json:"status"`
+	RequestedBy string     `json:"requested_by"`
+	DecidedBy   *string    `json:"decided_by"`
+	DecidedAt   *time.Time `json:"decided_at"`
+	EntryID     *int64     `json:"entry_id"`
+	GLEntryID   *int64     `json:"gl_entry_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// newAdjustmentResponse takes the currency of the adjusted account, or "" when it isn't at hand
+func newAdjustmentResponse(adjustment db.LedgerAdjustment, currency string) adjustmentResponse {
+	return adjustmentResponse{
+		ID:          adjustment.ID,
+		AccountID:   adjustment.AccountID,
+		GLCode:      adjustment.GlCode,
+		Amount:      newMoney(adjustment.Amount, currency),
+		ReasonCode:  adjustment.ReasonCode,
+		Note:        adjustment.Note,
+		Status:      adjustment.Status,
+		RequestedBy: adjustment.RequestedBy,
+		DecidedBy:   optionalText(adjustment.DecidedBy),
+		DecidedAt:   optionalTimestamp(adjustment.DecidedAt),
+		EntryID:     optionalInt(adjustment.EntryID),
+		GLEntryID:   optionalInt(adjustment.GlEntryID),
+		CreatedAt:   timestamp(adjustment.CreatedAt),
+	}
+}
+
+// decideAdjustmentResponse is a decided adjustment, with the balanced entries it posted when approved
+type decideAdjustmentResponse struct {
+	Adjustment adjustmentResponse `json:"adjustment"`
+	Account    *accountResponse   `json:"account"`
+	Entry      *entryResponse     `json:"entry"`
+	GLAccount  *glAccountResponse `json:"gl_account"`
+	GLEntry    *glEntryResponse   `json:"gl_entry"`
+}
+
+func newDecideAdjustmentResponse(result db.DecideAdjustmentTxResult) decideAdjustmentResponse {
+	var rsp decideAdjustmentResponse
+	currency := ""
+	if result.Account != nil {
+		currency = result.Account.Currency
+		account := newAccountResponse(*result.Account)
+		rsp.Account = &account
+	}
+	if result.Entry != nil {
+		entry := newEntryResponse(*result.Entry, currency)
+		rsp.Entry = &entry
+	}
+	if result.GLAccount != nil {
+		glAccount := newGLAccountResponse(*result.GLAccount)
+		rsp.GLAccount = &glAccount
+	}
+	if result.GLEntry != nil {
+		rsp.GLEntry = &glEntryResponse{
+			ID:          result.GLEntry.ID,
+			GLAccountID: result.GLEntry.GlAccountID,
+			Amount:      newMoney(result.GLEntry.Amount, currency),
+			CreatedAt:   timestamp(result.GLEntry.CreatedAt),
+		}
+	}
+	rsp.Adjustment = newAdjustmentResponse(result.Adjustment, currency)
+
+	return rsp
+}
+
+type userRoleResponse struct {
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	GrantedBy string    `json:"granted_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newUserRoleResponse(userRole db.UserRole) userRoleResponse {
+	return userRoleResponse{
+		Username:  userRole.Username,
+		Role:      userRole.Role,
+		GrantedBy: userRole.GrantedBy,
+		CreatedAt: timestamp(userRole.CreatedAt),
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"simple_bank/internal/db"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMoney(t *testing.T) {
+	testCases := []struct {
+		name       string
+		minorUnits int64
+		currency   string
+		display    string
+	}{
+		{name: "Whole", minorUnits: 1000, currency: "USD", display: "10.00 USD"},
+		{name: "Cents", minorUnits: 1005, currency: "EUR", display: "10.05 EUR"},
+		{name: "Negative", minorUnits: -1250, currency: "CAD", display: "-12.50 CAD"},
+		{name: "NegativeUnderOne", minorUnits: -5, currency: "USD", display: "-0.05 USD"},
+		{name: "Zero", minorUnits: 0, currency: "USD", display: "0.00 USD"},
+		{name: "UnknownCurrency", minorUnits: 250, display: "2.50"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newMoney(tc.minorUnits, tc.currency)
+			require.Equal(t, tc.minorUnits, m.MinorUnits)
+			require.Equal(t, tc.currency, m.Currency)
+			require.Equal(t, tc.display, m.Display)
+		})
+	}
+}
+
+func TestAccountResponseWireFormat(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+	account := db.Account{
+		ID:          7,
+		Owner:       "alice",
+		Balance:     -1250,
+		Currency:    "EUR",
+		CountryCode: 49,
+		Status:      db.AccountActive,
+		CreatedAt:   pgtype.Timestamptz{Time: createdAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: createdAt, Valid: true},
+	}
+
+	data, err := json.Marshal(newAccountResponse(account))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"id": 7,
+		"owner": "alice",
+		"balance": {"minor_units": -1250, "currency": "EUR", "display": "-12.50 EUR"},
+		"currency": "EUR",
+		"country_code": 49,
+		"status": "active",
+		"frozen_by": null,
+		"closed_at": null,
+		"created_at": "2024-03-01T08:30:00Z",
+		"updated_at": "2024-03-01T08:30:00Z"
+	}`, string(data))
+}
+
+func TestReviewTransferResponseRejected(t *testing.T) {
+	rsp := newReviewTransferResponse(db.ReviewTransferTxResult{
+		Review: db.TransferReview{ID: 3, Amount: 500, Status: db.TransferReviewRejected},
+	})
+
+	data, err := json.Marshal(rsp)
+	require.NoError(t, err)
+
+	var body map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &body))
+	require.JSONEq(t, `null`, string(body["transfer"]))
+	require.Contains(t, string(body["review"]), `"reasons":[]`)
+}
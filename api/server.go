@@ -1,7 +1,20 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
 	"simple_bank/internal/db"
+	"simple_bank/internal/fraud"
+	"simple_bank/internal/health"
+	"simple_bank/internal/kyc"
+	"simple_bank/internal/token"
+	"simple_bank/internal/totp"
+	"simple_bank/util"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -11,40 +24,221 @@ import (
 // Server is an HTTP server that handles banking API requests.
 // It uses a Gin router for HTTP routing and a database store for data persistence.
 type Server struct {
-	store  db.Store
-	router *gin.Engine
+	config      util.Config
+	store       db.Store
+	tokenMaker  token.Maker
+	totpCipher  *totp.Cipher
+	kycProvider kyc.Provider
+	screener    *fraud.Engine
+	router      *gin.Engine
+	httpServer  *http.Server
+	openAPISpec []byte
+	workers     []health.Check
 }
 
 // NewServer creates a new HTTP server and setup routing.
 // NewServer creates and returns a new Server instance with the provided database store.
 // It initializes a Gin router and sets up the following routes:
-// - POST /accounts: creates a new account
-// - GET /accounts/:id: retrieves an account by ID
-// - GET /accounts: lists all accounts
-func NewServer(store db.Store) *Server {
-	server := &Server{store: store}
-	router := gin.Default()
+// - POST /users and /users/login: registers a user and issues access tokens, asking for a second factor once enabled
+// - GET /verify_email: verifies a user's email address with the code from the emailed link
+// - POST /users/password/forgot and /users/password/reset: mails a reset token and sets a new password with it
+// The following routes require a bearer token:
+// - POST /users/kyc: submits the caller's KYC profile for verification
+// - POST /users/verify_email: sends the caller a new verification link
+// - POST /users/password: changes the caller's password, revoking their earlier access tokens
+// - POST /users/totp, /users/totp/confirm and /users/totp/disable: manages TOTP two-factor authentication
+// The following routes also require the banking.use permission of customers:
+// - POST /accounts: creates a new account owned by the caller
+// - GET /accounts/:id: retrieves an account the caller holds
+// - GET /accounts: lists the accounts the caller holds
+// - PATCH /accounts/:id/status: freezes, debit blocks or reactivates an account
+// - POST /accounts/:id/close: sweeps the balance to another account and closes it
+// - GET, POST /accounts/:id/holders and DELETE /accounts/:id/holders/:username: manages joint holders
+// - GET /accounts/:id/holder_events: audit trail of holder changes
+// - POST, GET /beneficiaries and GET, PATCH, DELETE /beneficiaries/:id: manages the caller's saved payees
+// - POST /beneficiaries/:id/verify: confirms a saved payee, with an X-OTP step-up for users with two-factor authentication
+// - POST /transfers: screens and posts a transfer, or holds it for review; large ones need an X-OTP step-up,
+//   as do new payees of users with two-factor authentication
+// The following routes require merchants.manage and that the caller administers the merchant:
+// - GET, POST /merchants/:id/admins and DELETE /merchants/:id/admins/:username: manages merchant administrators
+// - POST, GET /merchants/:id/webhooks and DELETE /merchants/:id/webhooks/:webhook_id: manages webhook endpoints
+// - GET /merchants/:id/webhook_deliveries and POST /merchants/:id/webhook_deliveries/:delivery_id/retry: delivery log
+// The following routes are for bank staff:
+// - GET /transfer_reviews and POST /transfer_reviews/:id/approve, /reject: decides held transfers (transfer_reviews.decide)
+// - GET /audit_events: searches the audit log (audit_events.read)
+// The following back office routes require back_office.access, and every request to them is audited:
+// - GET /admin/users, /admin/users/:username and /admin/users/:username/accounts: looks up users and their accounts
+// - GET /admin/accounts/:id and /admin/entries: any account and the ledger across accounts
+// - GET /admin/outbox_events, /admin/jobs and /admin/jobs/:id: inspects outbox events and background jobs
+// - POST /admin/accounts/:id/freeze and /unfreeze: freezes and reactivates any account (accounts.freeze)
+// - GET /admin/gl_accounts and /admin/adjustments: the bank's GL accounts and manual adjustments
+// - POST /admin/adjustments: requests a manual credit or debit with a reason code (ledger.adjust)
+// - POST /admin/adjustments/:id/approve, /reject: decides someone else's adjustment request (ledger.approve)
+// - GET /admin/roles, POST /admin/users/:username/roles, DELETE /admin/users/:username/roles/:role: roles (roles.manage)
+// Every state-changing request is recorded in the audit log.
+// GET /openapi.json serves the OpenAPI spec of these routes and GET /docs browses it with Swagger UI.
+// GET /metrics serves Prometheus metrics of the requests, transfers and database.
+// GET /healthz reports the process is up and GET /readyz checks the database, its migrations and the watched workers.
+func NewServer(config util.Config, store db.Store) (*Server, error) {
+	tokenMaker, err := token.NewJWTMaker(config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create token maker: %w", err)
+	}
+
+	totpCipher, err := totp.NewCipher(config.TOTPEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create TOTP cipher: %w", err)
+	}
+
+	kycProvider, err := kyc.NewProvider(config.KYCProvider)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create KYC provider: %w", err)
+	}
+
+	server := &Server{
+		config:      config,
+		store:       store,
+		tokenMaker:  tokenMaker,
+		totpCipher:  totpCipher,
+		kycProvider: kycProvider,
+		screener:    fraud.NewEngine(store, fraud.DefaultRules()...),
+	}
+	router := gin.New()
+	// store calls take the gin context, which has to reach the audit info in the request context
+	router.ContextWithFallback = true
+	// the client IP of logs and audit rows comes from X-Forwarded-For only behind these proxies,
+	// anyone else could write any address in it
+	if err := router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	router.Use(tracingMiddleware(), requestIDMiddleware(), loggerMiddleware(slog.Default()), metricsMiddleware(), recoveryMiddleware(), auditMiddleware(store))
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterTagNameFunc(requestFieldName)
 	}
 
-	// router
-	router.POST("/accounts", server.createAccount)
-	router.GET("/accounts/:id", server.getAccount)
-	router.GET("/accounts", server.listAccounts)
+	// the spec of every route below
+	router.GET("/openapi.json", server.serveOpenAPI)
+	router.GET("/docs", server.serveDocs)
+
+	// Prometheus scrapes
+	router.GET("/metrics", serveMetrics())
+
+	// orchestrator probes
+	router.GET("/healthz", server.healthz)
+	router.GET("/readyz", server.readyz)
+
+	// users
+	router.POST("/users", server.createUser)
+	router.POST("/users/login", server.loginUser)
+	router.GET("/verify_email", server.verifyEmail)
+	router.POST("/users/password/forgot", server.forgotPassword)
+	router.POST("/users/password/reset", server.resetPassword)
+
+	// the caller's own profile, open to every signed in user
+	authRoutes := router.Group("/", authMiddleware(server.tokenMaker, store, server.totpCipher))
+	authRoutes.POST("/users/kyc", server.submitKYC)
+	authRoutes.POST("/users/verify_email", server.resendVerifyEmail)
+	authRoutes.POST("/users/password", server.changePassword)
+	authRoutes.POST("/users/totp", server.enrollTOTP)
+	authRoutes.POST("/users/totp/confirm", server.confirmTOTP)
+	authRoutes.POST("/users/totp/disable", server.disableTOTP)
+
+	// customers
+	bankingRoutes := authRoutes.Group("/", requirePermission(store, db.PermBanking))
+
+	// accounts, authorized through account_holders
+	bankingRoutes.POST("/accounts", server.createAccount)
+	bankingRoutes.GET("/accounts/:id", server.getAccount)
+	bankingRoutes.GET("/accounts", server.listAccounts)
+	bankingRoutes.PATCH("/accounts/:id/status", server.updateAccountStatus)
+	bankingRoutes.POST("/accounts/:id/close", server.closeAccount)
+	bankingRoutes.GET("/accounts/:id/holders", server.listAccountHolders)
+	bankingRoutes.POST("/accounts/:id/holders", server.addAccountHolder)
+	bankingRoutes.DELETE("/accounts/:id/holders/:username", server.removeAccountHolder)
+	bankingRoutes.GET("/accounts/:id/holder_events", server.listAccountHolderEvents)
+
+	// saved payees
+	bankingRoutes.POST("/beneficiaries", server.createBeneficiary)
+	bankingRoutes.GET("/beneficiaries", server.listBeneficiaries)
+	bankingRoutes.GET("/beneficiaries/:id", server.getBeneficiary)
+	bankingRoutes.PATCH("/beneficiaries/:id", server.updateBeneficiary)
+	bankingRoutes.DELETE("/beneficiaries/:id", server.deleteBeneficiary)
+	bankingRoutes.POST("/beneficiaries/:id/verify", server.verifyBeneficiary)
 
 	// account transfers
-	router.POST("/transfers", server.createTransfer)
+	bankingRoutes.POST("/transfers", server.createTransfer)
+
+	// merchant administrators, authorized through merchant_admins
+	merchantRoutes := authRoutes.Group("/merchants/:id", requirePermission(store, db.PermMerchantsManage))
+	merchantRoutes.GET("/admins", server.listMerchantAdmins)
+	merchantRoutes.POST("/admins", server.addMerchantAdmin)
+	merchantRoutes.DELETE("/admins/:username", server.removeMerchantAdmin)
+	merchantRoutes.POST("/webhooks", server.createWebhookEndpoint)
+	merchantRoutes.GET("/webhooks", server.listWebhookEndpoints)
+	merchantRoutes.DELETE("/webhooks/:webhook_id", server.deleteWebhookEndpoint)
+	merchantRoutes.GET("/webhook_deliveries", server.listWebhookDeliveries)
+	merchantRoutes.POST("/webhook_deliveries/:delivery_id/retry", server.retryWebhookDelivery)
+
+	// bank staff: transfers held by fraud screening and the audit log
+	reviewRoutes := authRoutes.Group("/transfer_reviews", requirePermission(store, db.PermTransferReviewsDecide))
+	reviewRoutes.GET("", server.listTransferReviews)
+	reviewRoutes.POST("/:id/approve", server.approveTransferReview)
+	reviewRoutes.POST("/:id/reject", server.rejectTransferReview)
+	authRoutes.GET("/audit_events", requirePermission(store, db.PermAuditEventsRead), server.listAuditEvents)
+
+	// back office, where even reads are audited
+	adminRoutes := authRoutes.Group("/admin", requirePermission(store, db.PermBackOffice), auditReadsMiddleware(store))
+	adminRoutes.GET("/users", server.searchUsers)
+	adminRoutes.GET("/users/:username", server.getAdminUser)
+	adminRoutes.GET("/users/:username/accounts", server.listUserAccounts)
+	adminRoutes.GET("/accounts/:id", server.getAdminAccount)
+	adminRoutes.GET("/entries", server.listLedger)
+	adminRoutes.GET("/outbox_events", server.listOutboxEvents)
+	adminRoutes.GET("/jobs", server.listJobs)
+	adminRoutes.GET("/jobs/:id", server.getJob)
+	adminRoutes.POST("/accounts/:id/freeze", requirePermission(store, db.PermAccountsFreeze), server.freezeAccount)
+	adminRoutes.POST("/accounts/:id/unfreeze", requirePermission(store, db.PermAccountsFreeze), server.unfreezeAccount)
+	adminRoutes.GET("/gl_accounts", server.listGLAccounts)
+	adminRoutes.GET("/adjustments", server.listAdjustments)
+	adminRoutes.POST("/adjustments", requirePermission(store, db.PermLedgerAdjust), server.requestAdjustment)
+	adminRoutes.POST("/adjustments/:id/approve", requirePermission(store, db.PermLedgerApprove), server.approveAdjustment)
+	adminRoutes.POST("/adjustments/:id/reject", requirePermission(store, db.PermLedgerApprove), server.rejectAdjustment)
+	adminRoutes.GET("/roles", requirePermission(store, db.PermRolesManage), server.listRoles)
+	adminRoutes.POST("/users/:username/roles", requirePermission(store, db.PermRolesManage), server.grantRole)
+	adminRoutes.DELETE("/users/:username/roles/:role", requirePermission(store, db.PermRolesManage), server.revokeRole)
 
 	server.router = router
-	return server
+	server.httpServer = &http.Server{
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	server.openAPISpec = mustMarshalSpec(newOpenAPISpec(openAPIOperations))
+	return server, nil
 }
 
-// Start runs the sHttp server on a specific address
+// StartServer runs the HTTP server on a specific address until Shutdown is called,
+// when it returns nil
 func (server *Server) StartServer(address string) error {
-	return server.router.Run(address)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("cannot listen on %s: %w", address, err)
+	}
+
+	slog.Info("HTTP server listening", "address", listener.Addr().String())
+	err = server.httpServer.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
 }
 
-func errorResponse(err error) gin.H {
-	return gin.H{"error": err.Error()}
+// Shutdown stops accepting connections and waits for in-flight requests, such as transfers,
+// to finish. Once ctx is done the remaining connections are closed and its error is returned.
+func (server *Server) Shutdown(ctx context.Context) error {
+	err := server.httpServer.Shutdown(ctx)
+	if err != nil {
+		server.httpServer.Close()
+	}
+	return err
 }
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+	mock_db "simple_bank/internal/db/mock"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStartServerAfterShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mock_db.NewMockStore(ctrl))
+	require.NoError(t, server.Shutdown(context.Background()))
+
+	// a server shut down during startup stops cleanly instead of reporting an error
+	require.NoError(t, server.StartServer("127.0.0.1:0"))
+}
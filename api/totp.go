@@ -0,0 +1,220 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/token"
+	"simple_bank/internal/totp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	totpIssuer = "Simple Bank"
+	// otpHeader carries a second factor on an authenticated request, for operations that need a step-up
+	otpHeader = "X-OTP"
+	stepUpKey = "step_up_verified"
+)
+
+var (
+	errOTPRequired        = errors.New("a two-factor code is required")
+	errTOTPNotEnabled     = errors.New("two-factor authentication is not enabled")
+	errTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+)
+
+// requireStepUp lets transfers below the configured threshold through and asks for a second factor,
+// checked by authMiddleware from the X-OTP header, above it
+func (server *Server) requireStepUp(ctx *gin.Context, amount int64) bool {
+	threshold := server.config.StepUpTransferThreshold
+	if threshold <= 0 || amount < threshold || ctx.GetBool(stepUpKey) {
+		return true
+	}
+
+	err := fmt.Errorf("transfers of %d or more need a two-factor code in the %s header", threshold, otpHeader)
+	respondError(ctx, http.StatusUnauthorized, err)
+	return false
+}
+
+// requirePayeeStepUp asks users with two-factor authentication for a second factor before they trust a new payee,
+// so a stolen access token alone can't send money to an account of the thief's choosing.
+// Users without it have no code to give and go through on the token alone.
+func (server *Server) requirePayeeStepUp(ctx *gin.Context, username string) bool {
+	if ctx.GetBool(stepUpKey) {
+		return true
+	}
+
+	enrolled, err := db.HasTOTP(ctx, server.store, username)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return false
+	}
+
+	if !enrolled {
+		return true
+	}
+
+	err = fmt.Errorf("a new payee needs a two-factor code in the %s header: %w", otpHeader, errOTPRequired)
+	respondError(ctx, http.StatusUnauthorized, err)
+	return false
+}
+
+type enrollTOTPResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// enrollTOTP gives the caller a new TOTP secret. It only takes effect once confirmed with a code from the app,
+// so enrolling again before that simply replaces the secret.
+func (server *Server) enrollTOTP(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	sealed, err := server.totpCipher.Seal(secret, authPayload.Username)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, err = server.store.UpsertUserTOTP(ctx, db.UpsertUserTOTPParams{
+		Username: authPayload.Username,
+		Secret:   sealed,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusConflict, errTOTPAlreadyEnabled)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, enrollTOTPResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, totpIssuer, authPayload.Username),
+	})
+}
+
+type totpCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type confirmTOTPResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// confirmTOTP turns on two-factor authentication once the caller proves their app computes the right codes.
+// The recovery codes are only ever shown in this response.
+func (server *Server) confirmTOTP(ctx *gin.Context) {
+	var req totpCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	userTOTP, err := server.store.GetUserTOTP(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusConflict, db.ErrTOTPNotPending)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	secret, err := server.totpCipher.Open(userTOTP.Secret, userTOTP.Username)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	step, ok := totp.Validate(secret, req.Code, time.Now())
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, db.ErrInvalidOTP)
+		return
+	}
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes(totp.RecoveryCodeCount)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	hashes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		hashes[i] = totp.HashRecoveryCode(code)
+	}
+
+	_, err = server.store.ConfirmTOTPTx(ctx, db.ConfirmTOTPTxParams{
+		Username:           authPayload.Username,
+		Step:               step,
+		RecoveryCodeHashes: hashes,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrTOTPNotPending) {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, confirmTOTPResponse{RecoveryCodes: recoveryCodes})
+}
+
+// disableTOTP turns off two-factor authentication. It takes a current code or a recovery code,
+// so a stolen access token alone can't remove the second factor.
+func (server *Server) disableTOTP(ctx *gin.Context) {
+	var req totpCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	userTOTP, enrolled, err := db.ConfirmedTOTP(ctx, server.store, server.totpCipher, authPayload.Username)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !enrolled {
+		respondError(ctx, http.StatusNotFound, errTOTPNotEnabled)
+		return
+	}
+
+	err = db.CheckSecondFactor(ctx, server.store, userTOTP, req.Code)
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidOTP) {
+			respondError(ctx, http.StatusUnauthorized, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrOTPLocked) {
+			respondError(ctx, http.StatusTooManyRequests, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = server.store.DisableTOTPTx(ctx, authPayload.Username)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,402 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/internal/kyc"
+	"simple_bank/internal/totp"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// randomTOTP returns a confirmed enrollment for username as stored, its secret sealed with testTOTPKey,
+// along with a currently valid code
+func randomTOTP(t *testing.T, username string) (db.UserTotp, string) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	code, err := totp.Code(secret, time.Now())
+	require.NoError(t, err)
+
+	cipher, err := totp.NewCipher(testTOTPKey)
+	require.NoError(t, err)
+
+	sealed, err := cipher.Seal(secret, username)
+	require.NoError(t, err)
+
+	return db.UserTotp{
+		Username:    username,
+		Secret:      sealed,
+		ConfirmedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}, code
+}
+
+func TestEnrollTOTPAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	cipher, err := totp.NewCipher(testTOTPKey)
+	require.NoError(t, err)
+
+	var stored string
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					UpsertUserTOTP(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.UpsertUserTOTPParams) (db.UserTotp, error) {
+						require.Equal(t, user.Username, arg.Username)
+						stored = arg.Secret
+						return db.UserTotp{Username: arg.Username, Secret: arg.Secret}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response enrollTOTPResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.NotEmpty(t, response.Secret)
+
+				// only the sealed secret is stored
+				require.NotContains(t, stored, response.Secret)
+				secret, err := cipher.Open(stored, user.Username)
+				require.NoError(t, err)
+				require.Equal(t, response.Secret, secret)
+
+				require.Contains(t, response.ProvisioningURI, "otpauth://totp/")
+				require.Contains(t, response.ProvisioningURI, "secret="+response.Secret)
+			},
+		},
+		{
+			name: "AlreadyEnabled",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					UpsertUserTOTP(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserTotp{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/users/totp", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestConfirmTOTPAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	confirmed, code := randomTOTP(t, user.Username)
+	pending := confirmed
+	pending.ConfirmedAt = pgtype.Timestamptz{}
+
+	testCases := []struct {
+		name          string
+		code          string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			code: code,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(pending, nil)
+				store.EXPECT().
+					ConfirmTOTPTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.ConfirmTOTPTxParams) (db.UserTotp, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.InDelta(t, totp.Step(time.Now()), arg.Step, 1)
+						require.Len(t, arg.RecoveryCodeHashes, totp.RecoveryCodeCount)
+						return confirmed, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response confirmTOTPResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.RecoveryCodes, totp.RecoveryCodeCount)
+			},
+		},
+		{
+			name: "InvalidCode",
+			code: "000000",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(pending, nil)
+				store.EXPECT().ConfirmTOTPTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotEnrolled",
+			code: code,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.UserTotp{}, db.ErrRecordNotFound)
+				store.EXPECT().ConfirmTOTPTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(gin.H{"code": tc.code})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users/totp/confirm", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestLoginUserWithTOTPAPI(t *testing.T) {
+	user, password := randomUser(t)
+	userTOTP, code := randomTOTP(t, user.Username)
+
+	testCases := []struct {
+		name          string
+		otp           string
+		lockedUntil   time.Time
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			otp:  code,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					UseUserTOTPStep(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.UseUserTOTPStepParams) (db.UserTotp, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.InDelta(t, totp.Step(time.Now()), arg.Step, 1)
+						return userTOTP, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "MissingOTP",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().UseUserTOTPStep(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errOTPRequired.Error())
+			},
+		},
+		{
+			name: "ReplayedCode",
+			otp:  code,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().UseUserTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(db.UserTotp{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					RecordFailedOTP(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.RecordFailedOTPParams) (db.UserTotp, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, int32(db.MaxOTPAttempts), arg.MaxAttempts)
+						require.WithinDuration(t, time.Now().Add(db.OTPLockout), arg.LockedUntil.Time, time.Minute)
+						return userTOTP, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "LockedOut",
+			otp:         code,
+			lockedUntil: time.Now().Add(db.OTPLockout),
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().UseUserTOTPStep(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "otp_locked")
+			},
+		},
+		{
+			name: "RecoveryCode",
+			otp:  "abcde-12345",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					UseRecoveryCode(gomock.Any(), gomock.Eq(db.UseRecoveryCodeParams{Username: user.Username, CodeHash: totp.HashRecoveryCode("abcde-12345")})).
+					Times(1).
+					Return(db.RecoveryCode{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+			stored := userTOTP
+			if !tc.lockedUntil.IsZero() {
+				stored.LockedUntil = pgtype.Timestamptz{Time: tc.lockedUntil, Valid: true}
+			}
+			store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(stored, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(gin.H{"username": user.Username, "password": password, "otp": tc.otp})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users/login", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateTransferStepUp(t *testing.T) {
+	account1 := randomAccount()
+	account2 := randomAccount()
+	account2.ID = account1.ID + 1
+	account2.Currency = account1.Currency
+	account2.CountryCode = account1.CountryCode
+
+	user := db.User{Username: account1.Owner, KycStatus: kyc.StatusUnverified}
+	userTOTP, code := randomTOTP(t, account1.Owner)
+	amount := kyc.TierFor(0).MaxTransferAmount
+
+	testCases := []struct {
+		name          string
+		otp           string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "SteppedUp",
+			otp:  code,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(userTOTP, nil)
+				store.EXPECT().UseUserTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(userTOTP, nil)
+				store.EXPECT().CountTransfersFromAccountSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "StepUpRequired",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Contains(t, recorder.Body.String(), otpHeader)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.config.StepUpTransferThreshold = amount
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(gin.H{
+				"from_account_id":   account1.ID,
+				"to_account_id":     account2.ID,
+				"amount":            amount,
+				"currency":          account1.Currency,
+				"confirm_new_payee": true,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account1.Owner, time.Minute)
+			if tc.otp != "" {
+				request.Header.Set(otpHeader, tc.otp)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"simple_bank/internal/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingMiddleware starts a span per request, continuing the trace of the traceparent header when the client
+// sent one, and puts it in the request context, where the spans of store transactions and queries nest under it
+func tracingMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+		name := ctx.Request.Method
+		attrs := []trace.SpanStartOption{
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.URLPath(ctx.Request.URL.Path),
+			),
+		}
+		if route := ctx.FullPath(); route != "" {
+			name += " " + route
+			attrs = append(attrs, trace.WithAttributes(semconv.HTTPRoute(route)))
+		}
+
+		spanCtx, span := tracing.Tracer().Start(parent, name, attrs...)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if err := ctx.Errors.Last(); err != nil {
+			span.RecordError(err)
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
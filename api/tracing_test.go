@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	mock_db "simple_bank/internal/db/mock"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+)
+
+// TestTracingMiddleware checks that a request's span continues the trace of its traceparent header
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mock_db.NewMockStore(ctrl))
+
+	request, err := http.NewRequest(http.MethodGet, "/accounts/1", nil)
+	require.NoError(t, err)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	server.router.ServeHTTP(httptest.NewRecorder(), request)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "GET /accounts/:id", spans[0].Name())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+
+	var status int64
+	for _, attr := range spans[0].Attributes() {
+		if attr.Key == "http.response.status_code" {
+			status = attr.Value.AsInt64()
+		}
+	}
+	require.Equal(t, int64(http.StatusUnauthorized), status)
+}
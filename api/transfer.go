@@ -1,70 +1,164 @@
 package api
 
 import (
-	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"simple_bank/internal/db"
+	"simple_bank/internal/fraud"
+	"simple_bank/internal/kyc"
+	"simple_bank/internal/metrics"
+	"simple_bank/internal/token"
 
 	"github.com/gin-gonic/gin"
 )
 
+// transferRequest sends money either to a raw account ID or to one of the caller's beneficiaries
 type transferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required"`
-	Amount        int64  `json:"amount" binding:"required"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID   int64  `json:"from_account_id" binding:"required"`
+	ToAccountID     int64  `json:"to_account_id" binding:"required_without=BeneficiaryID,excluded_with=BeneficiaryID"`
+	BeneficiaryID   int64  `json:"beneficiary_id" binding:"omitempty,min=1"`
+	Amount          int64  `json:"amount" binding:"required,min=1"`
+	Currency        string `json:"currency" binding:"required,currency"`
+	ConfirmNewPayee bool   `json:"confirm_new_payee"`
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
+	// refused and failed requests are counted by their error code, posted and held ones below
+	defer func() {
+		if code := ctx.GetString(errorCodeKey); code != "" {
+			metrics.ObserveTransferFailed(code)
+		}
+	}()
+
 	var req transferRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// viewers can see an account but only owners and signatories can move its money
+	if !server.authorizeAccount(ctx, req.FromAccountID, db.HolderOwner, db.HolderSignatory) {
 		return
 	}
 
-	if !server.validAccount(ctx, req.FromAccountID, req.Currency) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	toAccountID, ok := server.payeeAccount(ctx, authPayload.Username, req)
+	if !ok {
 		return
 	}
 
-	if !server.validAccount(ctx, req.ToAccountID, req.Currency) {
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	toAccount, valid := server.validAccount(ctx, toAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	if !db.CanSend(fromAccount.Status) {
+		err := fmt.Errorf("account [%d] is %s: %w", fromAccount.ID, fromAccount.Status, db.ErrAccountCannotSend)
+		respondError(ctx, http.StatusForbidden, err)
+		return
+	}
+
+	if !db.CanReceive(toAccount.Status) {
+		err := fmt.Errorf("account [%d] is %s: %w", toAccount.ID, toAccount.Status, db.ErrAccountCannotReceive)
+		respondError(ctx, http.StatusForbidden, err)
+		return
+	}
+
+	user, tier, ok := server.userTier(ctx, authPayload.Username)
+	if !ok {
+		return
+	}
+
+	if req.Amount > tier.MaxTransferAmount {
+		err := fmt.Errorf("kyc tier %d allows transfers of at most %d", tier.Level, tier.MaxTransferAmount)
+		respondError(ctx, http.StatusForbidden, err)
+		return
+	}
+
+	if !user.IsEmailVerified && req.Amount > kyc.MaxUnverifiedEmailTransfer {
+		respondError(ctx, http.StatusForbidden, errEmailNotVerified)
+		return
+	}
+
+	if !server.requireStepUp(ctx, req.Amount) {
+		return
+	}
+
+	decision, err := server.screener.Screen(ctx, fraud.Transfer{
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Amount:      req.Amount,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// suspicious transfers are queued for an admin instead of being posted
+	if decision.Flagged {
+		review, err := server.store.CreateTransferReview(ctx, db.CreateTransferReviewParams{
+			FromAccountID: req.FromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        req.Amount,
+			Reasons:       decision.Reasons,
+		})
+		if err != nil {
+			respondError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		metrics.ObserveTransferHeld()
+		ctx.JSON(http.StatusAccepted, newTransferReviewResponse(review))
 		return
 	}
 
 	arg := db.TransferTxParams{
 		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
+		ToAccountID:   toAccountID,
 		Amount:        req.Amount,
 	}
 
 	result, err := (server.store).TransferTx(ctx, arg)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		// the status may have changed between the checks above and the transaction
+		if errors.Is(err, db.ErrAccountCannotSend) || errors.Is(err, db.ErrAccountCannotReceive) {
+			respondError(ctx, http.StatusForbidden, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, result)
+	metrics.ObserveTransferCreated(fromAccount.Currency, req.Amount)
+	ctx.JSON(http.StatusOK, newTransferResultResponse(result))
 }
 
-func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) bool {
+func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return false
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return account, false
 		}
 
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return false
+		respondError(ctx, http.StatusInternalServerError, err)
+		return account, false
 	}
 
 	if account.Currency != currency {
 		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s ", account.ID, account.Currency, currency)
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 
-		return false
+		return account, false
 	}
 
-	return true
+	return account, true
 }
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/token"
+
+	"github.com/gin-gonic/gin"
+)
+
+type listTransferReviewsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending_review approved rejected"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listTransferReviews(ctx *gin.Context) {
+	var req listTransferReviewsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Status == "" {
+		req.Status = db.TransferReviewPending
+	}
+
+	arg := db.ListTransferReviewsParams{
+		Status: req.Status,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	reviews, err := server.store.ListTransferReviews(ctx, arg)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(reviews, newTransferReviewResponse))
+}
+
+type transferReviewURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) approveTransferReview(ctx *gin.Context) {
+	server.reviewTransfer(ctx, true)
+}
+
+func (server *Server) rejectTransferReview(ctx *gin.Context) {
+	server.reviewTransfer(ctx, false)
+}
+
+func (server *Server) reviewTransfer(ctx *gin.Context, approve bool) {
+	var uri transferReviewURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// the decision is attributed to the signed in staff member
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.ReviewTransferTx(ctx, db.ReviewTransferTxParams{
+		ReviewID:   uri.ID,
+		Approve:    approve,
+		ReviewedBy: authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrReviewNotPending) {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+
+		if errors.Is(err, db.ErrAccountCannotSend) || errors.Is(err, db.ErrAccountCannotReceive) {
+			respondError(ctx, http.StatusForbidden, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newReviewTransferResponse(result))
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/internal/fraud"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// TestCreateTransferHeldForReview checks that a transfer flagged by the fraud
+// rules is queued as a pending review instead of being posted.
+func TestCreateTransferHeldForReview(t *testing.T) {
+	account1 := randomAccount()
+	account2 := randomAccount()
+	account2.ID = account1.ID + 1
+	account2.Currency = account1.Currency
+	account2.CountryCode = account1.CountryCode
+	amount := int64(fraud.DefaultLargeAmount)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(account1.Owner)).
+		Times(1).
+		Return(db.User{Username: account1.Owner, KycTier: 1, IsEmailVerified: true}, nil)
+	store.EXPECT().CountTransfersBetweenAccounts(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+	store.EXPECT().CountTransfersFromAccountSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+
+	arg := db.CreateTransferReviewParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		Reasons:       []string{"new_payee_large_amount"},
+	}
+	store.EXPECT().
+		CreateTransferReview(gomock.Any(), gomock.Eq(arg)).
+		Times(1).
+		Return(db.TransferReview{ID: 1, Status: db.TransferReviewPending}, nil)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	body, err := json.Marshal(gin.H{
+		"from_account_id":   account1.ID,
+		"to_account_id":     account2.ID,
+		"amount":            amount,
+		"currency":          account1.Currency,
+		"confirm_new_payee": true,
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account1.Owner, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+}
+
+func TestApproveTransferReviewAPI(t *testing.T) {
+	reviewID := int64(7)
+
+	testCases := []struct {
+		name          string
+		permissions   []string
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			permissions: []string{db.PermTransferReviewsDecide},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					ReviewTransferTx(gomock.Any(), gomock.Eq(db.ReviewTransferTxParams{
+						ReviewID:   reviewID,
+						Approve:    true,
+						ReviewedBy: "admin",
+					})).
+					Times(1).
+					Return(db.ReviewTransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			permissions: []string{db.PermTransferReviewsDecide},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					ReviewTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewTransferTxResult{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:        "AlreadyDecided",
+			permissions: []string{db.PermTransferReviewsDecide},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					ReviewTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewTransferTxResult{}, db.ErrReviewNotPending)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "NotStaff",
+			permissions: []string{db.PermBanking},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					ReviewTransferTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			store.EXPECT().ListUserPermissions(gomock.Any(), gomock.Eq("admin")).Times(1).Return(tc.permissions, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/transfer_reviews/%d/approve", reviewID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "admin", time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/internal/kyc"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateTransferAPI(t *testing.T) {
+	account1 := randomAccount()
+	account2 := randomAccount()
+	account2.ID = account1.ID + 1
+	account2.Currency = account1.Currency
+	account2.CountryCode = account1.CountryCode
+
+	unverified := db.User{Username: account1.Owner, KycStatus: kyc.StatusUnverified}
+
+	testCases := []struct {
+		name          string
+		amount        int64
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			amount: 10,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(unverified, nil)
+				store.EXPECT().CountTransfersFromAccountSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        10,
+					})).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "TierTransferLimit",
+			amount: kyc.TierFor(0).MaxTransferAmount + 1,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(unverified, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "EmailNotVerified",
+			amount: kyc.MaxUnverifiedEmailTransfer + 1,
+			buildStubs: func(store *mock_db.MockStore) {
+				user := db.User{Username: account1.Owner, KycStatus: kyc.StatusVerified, KycTier: 1}
+
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(user, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "verify your email")
+			},
+		},
+		{
+			name:   "ViewerCannotSend",
+			amount: 10,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderViewer)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "FrozenFromAccount",
+			amount: 10,
+			buildStubs: func(store *mock_db.MockStore) {
+				frozen := account1
+				frozen.Status = db.AccountFrozen
+
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(frozen, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "NegativeAmount",
+			amount: -10,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetAccountHolder(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(gin.H{
+				"from_account_id":   account1.ID,
+				"to_account_id":     account2.ID,
+				"amount":            tc.amount,
+				"currency":          account1.Currency,
+				"confirm_new_payee": true,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account1.Owner, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/kyc"
+	"simple_bank/internal/tasks"
+	"simple_bank/internal/token"
+	"simple_bank/util"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type createUserRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Password string `json:"password" binding:"required,min=6"`
+	FullName string `json:"full_name" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
+}
+
+// userResponse is a user without its hashed password or raw KYC data
+type userResponse struct {
+	Username          string    `json:"username"`
+	FullName          string    `json:"full_name"`
+	Email             string    `json:"email"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
+	KycStatus         string    `json:"kyc_status"`
+	KycTier           int32     `json:"kyc_tier"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func newUserResponse(user db.User) userResponse {
+	return userResponse{
+		Username:          user.Username,
+		FullName:          user.FullName,
+		Email:             user.Email,
+		IsEmailVerified:   user.IsEmailVerified,
+		KycStatus:         user.KycStatus,
+		KycTier:           user.KycTier,
+		PasswordChangedAt: timestamp(user.PasswordChangedAt),
+		CreatedAt:         timestamp(user.CreatedAt),
+	}
+}
+
+func (server *Server) createUser(ctx *gin.Context) {
+	var req createUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.Password)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	arg := db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:          req.Username,
+			HashedPassword:    hashedPassword,
+			FullName:          req.FullName,
+			Email:             req.Email,
+			PasswordChangedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		},
+		// the verification email is only queued if the user is committed
+		AfterCreate: func(q *db.Queries, user db.User) error {
+			return tasks.EnqueueVerifyEmail(ctx, q, user.Username)
+		},
+	}
+
+	user, err := server.store.CreateUserTx(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
+
+type loginUserRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Password string `json:"password" binding:"required,min=6"`
+	// OTP is a TOTP or recovery code, required once the user has enabled two-factor authentication
+	OTP string `json:"otp"`
+}
+
+// errInvalidCredentials answers both an unknown username and a wrong password
+var errInvalidCredentials = errors.New("incorrect username or password")
+
+type loginUserResponse struct {
+	AccessToken          string       `json:"access_token"`
+	AccessTokenExpiresAt time.Time    `json:"access_token_expires_at"`
+	User                 userResponse `json:"user"`
+}
+
+func (server *Server) loginUser(ctx *gin.Context) {
+	var req loginUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// an unknown user and a wrong password get the same answer, so logins can't probe for usernames
+	user, err := server.store.GetUser(ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusUnauthorized, errInvalidCredentials)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = util.CheckPassword(req.Password, user.HashedPassword)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errInvalidCredentials)
+		return
+	}
+
+	userTOTP, enrolled, err := db.ConfirmedTOTP(ctx, server.store, server.totpCipher, user.Username)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if enrolled {
+		if req.OTP == "" {
+			respondError(ctx, http.StatusUnauthorized, errOTPRequired)
+			return
+		}
+
+		err = db.CheckSecondFactor(ctx, server.store, userTOTP, req.OTP)
+		if err != nil {
+			if errors.Is(err, db.ErrInvalidOTP) {
+				respondError(ctx, http.StatusUnauthorized, err)
+				return
+			}
+
+			if errors.Is(err, db.ErrOTPLocked) {
+				respondError(ctx, http.StatusTooManyRequests, err)
+				return
+			}
+
+			respondError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.AccessTokenDuration)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, loginUserResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessPayload.ExpiredAt,
+		User:                 newUserResponse(user),
+	})
+}
+
+type submitKYCRequest struct {
+	DateOfBirth string `json:"date_of_birth" binding:"required,datetime=2006-01-02"`
+	Address     string `json:"address" binding:"required"`
+	NationalID  string `json:"national_id" binding:"required"`
+}
+
+// submitKYC runs the caller's identity data through the KYC provider and stores the verdict.
+// A verified user moves up to the provider's tier; a rejected one drops back to tier 0.
+func (server *Server) submitKYC(ctx *gin.Context) {
+	var req submitKYCRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	dateOfBirth, err := time.Parse(time.DateOnly, req.DateOfBirth)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	result, err := server.kycProvider.Verify(ctx, kyc.Profile{
+		Username:    user.Username,
+		FullName:    user.FullName,
+		DateOfBirth: dateOfBirth,
+		Address:     req.Address,
+		NationalID:  req.NationalID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadGateway, err)
+		return
+	}
+
+	user, err = server.store.UpdateUserKYC(ctx, db.UpdateUserKYCParams{
+		Username:    user.Username,
+		DateOfBirth: pgtype.Date{Time: dateOfBirth, Valid: true},
+		Address:     pgtype.Text{String: req.Address, Valid: true},
+		NationalID:  pgtype.Text{String: req.NationalID, Valid: true},
+		KycStatus:   result.Status,
+		KycTier:     result.Tier,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/kyc"
+	"simple_bank/internal/tasks"
+	"simple_bank/internal/token"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errEmailNotVerified     = fmt.Errorf("verify your email address to send more than %d", kyc.MaxUnverifiedEmailTransfer)
+	errEmailAlreadyVerified = errors.New("email address is already verified")
+)
+
+type verifyEmailRequest struct {
+	ID         int64  `form:"id" binding:"required,min=1"`
+	SecretCode string `form:"code" binding:"required"`
+}
+
+type verifyEmailResponse struct {
+	IsVerified bool `json:"is_verified"`
+}
+
+// verifyEmail uses up the code from a verification link and marks the user's email as verified
+func (server *Server) verifyEmail(ctx *gin.Context) {
+	var req verifyEmailRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := server.store.VerifyEmailTx(ctx, db.VerifyEmailTxParams{
+		ID:         req.ID,
+		SecretCode: req.SecretCode,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidVerifyEmail) {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, verifyEmailResponse{IsVerified: result.User.IsEmailVerified})
+}
+
+// resendVerifyEmail queues a new verification link for the caller, e.g. after the last one expired
+func (server *Server) resendVerifyEmail(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if user.IsEmailVerified {
+		respondError(ctx, http.StatusConflict, errEmailAlreadyVerified)
+		return
+	}
+
+	if err := tasks.EnqueueVerifyEmail(ctx, server.store, user.Username); err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
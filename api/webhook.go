@@ -0,0 +1,264 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"simple_bank/internal/db"
+	"simple_bank/internal/token"
+	"simple_bank/internal/webhook"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type merchantURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// authorizeMerchant checks the authenticated user is one of the merchant's administrators.
+// It writes the error response and returns false otherwise.
+func (server *Server) authorizeMerchant(ctx *gin.Context, merchantID int64) bool {
+	_, err := server.store.GetMerchant(ctx, merchantID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return false
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	isAdmin, err := server.store.IsMerchantAdmin(ctx, db.IsMerchantAdminParams{
+		MerchantID: merchantID,
+		Username:   authPayload.Username,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return false
+	}
+
+	if !isAdmin {
+		err := fmt.Errorf("user %s does not administer merchant [%d]", authPayload.Username, merchantID)
+		respondError(ctx, http.StatusForbidden, err)
+		return false
+	}
+
+	return true
+}
+
+// webhookEndpointResponse is an endpoint without its signing secret
+type webhookEndpointResponse struct {
+	ID         int64     `json:"id"`
+	MerchantID int64     `json:"merchant_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func newWebhookEndpointResponse(endpoint db.WebhookEndpoint) webhookEndpointResponse {
+	return webhookEndpointResponse{
+		ID:         endpoint.ID,
+		MerchantID: endpoint.MerchantID,
+		URL:        endpoint.Url,
+		EventTypes: endpoint.EventTypes,
+		Active:     endpoint.Active,
+		CreatedAt:  timestamp(endpoint.CreatedAt),
+	}
+}
+
+type createWebhookEndpointRequest struct {
+	URL        string   `json:"url" binding:"required,url"`
+	EventTypes []string `json:"event_types" binding:"dive,oneof=transfer.created order.created order.status_changed"`
+}
+
+// createWebhookEndpointResponse is the only time the signing secret is returned
+type createWebhookEndpointResponse struct {
+	webhookEndpointResponse
+	Secret string `json:"secret"`
+}
+
+func (server *Server) createWebhookEndpoint(ctx *gin.Context) {
+	var uri merchantURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req createWebhookEndpointRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := webhook.ValidateURL(req.URL); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeMerchant(ctx, uri.ID) {
+		return
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	eventTypes := req.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	endpoint, err := server.store.CreateWebhookEndpoint(ctx, db.CreateWebhookEndpointParams{
+		MerchantID: uri.ID,
+		Url:        req.URL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createWebhookEndpointResponse{
+		webhookEndpointResponse: newWebhookEndpointResponse(endpoint),
+		Secret:                  endpoint.Secret,
+	})
+}
+
+func (server *Server) listWebhookEndpoints(ctx *gin.Context) {
+	var uri merchantURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeMerchant(ctx, uri.ID) {
+		return
+	}
+
+	endpoints, err := server.store.ListWebhookEndpoints(ctx, uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	rsp := make([]webhookEndpointResponse, len(endpoints))
+	for i, endpoint := range endpoints {
+		rsp[i] = newWebhookEndpointResponse(endpoint)
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type webhookEndpointURI struct {
+	ID        int64 `uri:"id" binding:"required,min=1"`
+	WebhookID int64 `uri:"webhook_id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteWebhookEndpoint(ctx *gin.Context) {
+	var uri webhookEndpointURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeMerchant(ctx, uri.ID) {
+		return
+	}
+
+	endpoint, err := server.store.DeleteWebhookEndpoint(ctx, db.DeleteWebhookEndpointParams{
+		ID:         uri.WebhookID,
+		MerchantID: uri.ID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newWebhookEndpointResponse(endpoint))
+}
+
+type listWebhookDeliveriesRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending succeeded dead"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+// listWebhookDeliveries is the merchant's delivery log, newest first
+func (server *Server) listWebhookDeliveries(ctx *gin.Context) {
+	var uri merchantURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req listWebhookDeliveriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeMerchant(ctx, uri.ID) {
+		return
+	}
+
+	deliveries, err := server.store.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		MerchantID: uri.ID,
+		Status:     pgtype.Text{String: req.Status, Valid: req.Status != ""},
+		Limit:      req.PageSize,
+		Offset:     (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertAll(deliveries, newWebhookDeliveryResponse))
+}
+
+type webhookDeliveryURI struct {
+	ID         int64 `uri:"id" binding:"required,min=1"`
+	DeliveryID int64 `uri:"delivery_id" binding:"required,min=1"`
+}
+
+// retryWebhookDelivery puts a dead lettered delivery back in the queue
+func (server *Server) retryWebhookDelivery(ctx *gin.Context) {
+	var uri webhookDeliveryURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.authorizeMerchant(ctx, uri.ID) {
+		return
+	}
+
+	delivery, err := server.store.RetryWebhookDelivery(ctx, db.RetryWebhookDeliveryParams{
+		ID:         uri.DeliveryID,
+		MerchantID: uri.ID,
+	})
+	if err != nil {
+		// unknown, another merchant's and not yet dead deliveries all look the same here
+		if errors.Is(err, db.ErrRecordNotFound) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newWebhookDeliveryResponse(delivery))
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhookEndpointAPI(t *testing.T) {
+	account := randomAccount()
+	merchant := db.Merchant{ID: 4, MerchantName: "shop", AdminID: int32(account.ID)}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mock_db.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"url": "https://shop.example/hooks", "event_types": []string{db.EventTransferCreated}},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), gomock.Eq(merchant.ID)).Times(1).Return(merchant, nil)
+				expectMerchantAdmin(store, merchant.ID, account.Owner, true)
+				store.EXPECT().
+					CreateWebhookEndpoint(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateWebhookEndpointParams) (db.WebhookEndpoint, error) {
+						require.Equal(t, merchant.ID, arg.MerchantID)
+						require.NotEmpty(t, arg.Secret)
+						return db.WebhookEndpoint{ID: 1, MerchantID: arg.MerchantID, Url: arg.Url, Secret: arg.Secret, EventTypes: arg.EventTypes}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp createWebhookEndpointResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.NotEmpty(t, rsp.Secret)
+				require.Equal(t, "https://shop.example/hooks", rsp.URL)
+			},
+		},
+		{
+			name: "MerchantNotFound",
+			body: gin.H{"url": "https://shop.example/hooks"},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), gomock.Any()).Times(1).Return(db.Merchant{}, db.ErrRecordNotFound)
+				store.EXPECT().CreateWebhookEndpoint(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotMerchantAdmin",
+			body: gin.H{"url": "https://shop.example/hooks"},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), gomock.Any()).Times(1).Return(merchant, nil)
+				expectMerchantAdmin(store, merchant.ID, account.Owner, false)
+				store.EXPECT().CreateWebhookEndpoint(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "PlainHTTP",
+			body: gin.H{"url": "http://shop.example/hooks"},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().CreateWebhookEndpoint(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "webhook_url_forbidden")
+			},
+		},
+		{
+			name: "PrivateAddress",
+			body: gin.H{"url": "https://169.254.169.254/latest/meta-data"},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().CreateWebhookEndpoint(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownEventType",
+			body: gin.H{"url": "https://shop.example/hooks", "event_types": []string{"account.created"}},
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/merchants/%d/webhooks", merchant.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account.Owner, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRetryWebhookDeliveryAPI(t *testing.T) {
+	account := randomAccount()
+	merchant := db.Merchant{ID: 4, AdminID: int32(account.ID)}
+
+	testCases := []struct {
+		name          string
+		retryErr      error
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "NotDead",
+			retryErr: db.ErrRecordNotFound,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			store.EXPECT().GetMerchant(gomock.Any(), gomock.Eq(merchant.ID)).Times(1).Return(merchant, nil)
+			expectMerchantAdmin(store, merchant.ID, account.Owner, true)
+			store.EXPECT().
+				RetryWebhookDelivery(gomock.Any(), gomock.Eq(db.RetryWebhookDeliveryParams{ID: 9, MerchantID: merchant.ID})).
+				Times(1).
+				Return(db.WebhookDelivery{ID: 9, Status: "pending"}, tc.retryErr)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/merchants/%d/webhook_deliveries/9/retry", merchant.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, account.Owner, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func expectMerchantAdmin(store *mock_db.MockStore, merchantID int64, username string, isAdmin bool) {
+	store.EXPECT().
+		IsMerchantAdmin(gomock.Any(), gomock.Eq(db.IsMerchantAdminParams{
+			MerchantID: merchantID,
+			Username:   username,
+		})).
+		Times(1).
+		Return(isAdmin, nil)
+}
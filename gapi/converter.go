@@ -0,0 +1,84 @@
+package gapi
+
+import (
+	"simple_bank/internal/db"
+	"simple_bank/pb"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func timestamp(t pgtype.Timestamptz) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+// convertUser leaves out the password hash and raw KYC data, like the HTTP userResponse
+func convertUser(user db.User) *pb.User {
+	return &pb.User{
+		Username:          user.Username,
+		FullName:          user.FullName,
+		Email:             user.Email,
+		IsEmailVerified:   user.IsEmailVerified,
+		KycStatus:         user.KycStatus,
+		KycTier:           user.KycTier,
+		PasswordChangedAt: timestamp(user.PasswordChangedAt),
+		CreatedAt:         timestamp(user.CreatedAt),
+	}
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:          account.ID,
+		Owner:       account.Owner,
+		Balance:     account.Balance,
+		Currency:    account.Currency,
+		CountryCode: account.CountryCode,
+		Status:      account.Status,
+		ClosedAt:    timestamp(account.ClosedAt),
+		CreatedAt:   timestamp(account.CreatedAt),
+	}
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		CreatedAt:     timestamp(transfer.CreatedAt),
+	}
+}
+
+func convertEntry(entry db.Entry) *pb.Entry {
+	return &pb.Entry{
+		Id:        entry.ID,
+		AccountId: entry.AccountID,
+		Amount:    entry.Amount,
+		CreatedAt: timestamp(entry.CreatedAt),
+	}
+}
+
+func convertTransferReview(review db.TransferReview) *pb.TransferReview {
+	return &pb.TransferReview{
+		Id:            review.ID,
+		FromAccountId: review.FromAccountID,
+		ToAccountId:   review.ToAccountID,
+		Amount:        review.Amount,
+		Reasons:       review.Reasons,
+		Status:        review.Status,
+		CreatedAt:     timestamp(review.CreatedAt),
+	}
+}
+
+func convertTransferResult(result db.TransferTxResult) *pb.TransferResult {
+	return &pb.TransferResult{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}
+}
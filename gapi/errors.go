@@ -0,0 +1,42 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// internalError hides err from the client behind a generic Internal status and logs it instead,
+// as api.respondError does for 5xx responses
+func internalError(ctx context.Context, err error) error {
+	logError(ctx, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func logError(ctx context.Context, err error) {
+	slog.ErrorContext(ctx, "grpc error", "error", err)
+}
+
+// invalidArgumentError is the InvalidArgument status of a request that failed validation,
+// with the failing fields as BadRequest details
+func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(codes.InvalidArgument, "request failed validation")
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// fieldViolation describes one request field that failed validation
+func fieldViolation(field string, err error) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprint(err),
+	}
+}
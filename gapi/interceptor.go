@@ -0,0 +1,292 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"simple_bank/internal/db"
+	"simple_bank/internal/logging"
+	"simple_bank/internal/token"
+	"simple_bank/internal/totp"
+	"simple_bank/internal/tracing"
+	"simple_bank/pb"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader     = "authorization"
+	authorizationTypeBearer = "bearer"
+	// otpHeader carries a second factor, for calls that need a step-up
+	otpHeader       = "x-otp"
+	requestIDHeader = "x-request-id"
+)
+
+// publicMethods can be called without an access token
+var publicMethods = []string{
+	pb.SimpleBank_CreateUser_FullMethodName,
+	pb.SimpleBank_LoginUser_FullMethodName,
+}
+
+// methodPermissions are the permissions callers need on top of a valid token, as on the matching HTTP routes
+var methodPermissions = map[string]string{
+	pb.SimpleBank_CreateAccount_FullMethodName:  db.PermBanking,
+	pb.SimpleBank_GetAccount_FullMethodName:     db.PermBanking,
+	pb.SimpleBank_ListAccounts_FullMethodName:   db.PermBanking,
+	pb.SimpleBank_CreateTransfer_FullMethodName: db.PermBanking,
+}
+
+// readMethods don't change state, so they are left out of the audit log like HTTP GETs
+var readMethods = []string{
+	pb.SimpleBank_GetUser_FullMethodName,
+	pb.SimpleBank_GetAccount_FullMethodName,
+	pb.SimpleBank_ListAccounts_FullMethodName,
+}
+
+type authPayloadKey struct{}
+
+type stepUpKey struct{}
+
+// authPayload returns the token payload authInterceptor verified for the call
+func authPayload(ctx context.Context) *token.Payload {
+	return ctx.Value(authPayloadKey{}).(*token.Payload)
+}
+
+// steppedUp reports whether the call came with a valid second factor
+func steppedUp(ctx context.Context) bool {
+	verified, _ := ctx.Value(stepUpKey{}).(bool)
+	return verified
+}
+
+// tracingInterceptor starts a span per call, continuing the trace of the traceparent metadata when the client
+// sent one, as tracingMiddleware does for HTTP
+func tracingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+		service, method, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+		ctx, span := tracing.Tracer().Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.RPCSystemGRPC, semconv.RPCService(service), semconv.RPCMethod(method)),
+		)
+		defer span.End()
+
+		rsp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+		if err != nil {
+			span.RecordError(err)
+			if code == codes.Internal || code == codes.Unknown {
+				span.SetStatus(otelcodes.Error, code.String())
+			}
+		}
+
+		return rsp, err
+	}
+}
+
+// metadataCarrier reads the trace context propagated in incoming metadata
+type metadataCarrier metadata.MD
+
+func (carrier metadataCarrier) Get(key string) string {
+	if values := metadata.MD(carrier).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (carrier metadataCarrier) Set(key, value string) {
+	metadata.MD(carrier).Set(key, value)
+}
+
+func (carrier metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier))
+	for key := range carrier {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// loggingInterceptor gives every call an ID, the client's x-request-id when it sent one, returns it in the
+// x-request-id header metadata and puts it in the context, then logs the call once it has been handled,
+// as requestIDMiddleware and loggerMiddleware do for HTTP
+func loggingInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		requestID := firstMetadata(ctx, requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+		ctx = logging.WithFields(ctx, &logging.Fields{RequestID: requestID})
+
+		rsp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		level := slog.LevelInfo
+		switch code {
+		case codes.OK:
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			level = slog.LevelError
+		default:
+			level = slog.LevelWarn
+		}
+
+		logger.LogAttrs(ctx, level, "grpc request",
+			slog.String("method", info.FullMethod),
+			slog.String("code", code.String()),
+			slog.Duration("latency", time.Since(start)),
+		)
+
+		return rsp, err
+	}
+}
+
+// auditInterceptor, placed after loggingInterceptor, puts the caller's audit info in the context, so store transactions can attribute
+// their audit rows, and appends every state-changing call to the audit log once it has been handled.
+// authInterceptor fills in the actor on authenticated methods.
+func auditInterceptor(store db.Store) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		auditInfo := &db.AuditInfo{}
+		if fields := logging.FieldsFrom(ctx); fields != nil {
+			auditInfo.RequestID = fields.RequestID
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			auditInfo.IP, _, _ = net.SplitHostPort(p.Addr.String())
+		}
+		ctx = db.WithAuditInfo(ctx, auditInfo)
+
+		rsp, err := handler(ctx, req)
+
+		if !slices.Contains(readMethods, info.FullMethod) {
+			// the client may already be gone, but the call it made still has to be recorded
+			auditErr := store.RecordAudit(context.WithoutCancel(ctx), db.AuditRecord{
+				Action:       "grpc.request",
+				ResourceType: "rpc",
+				ResourceID:   info.FullMethod,
+				After:        map[string]any{"code": status.Code(err).String()},
+			})
+			if auditErr != nil {
+				logError(ctx, auditErr)
+			}
+		}
+
+		return rsp, err
+	}
+}
+
+// authInterceptor rejects calls to all but the public methods without a valid bearer token in the authorization
+// metadata, or with one issued before the user last changed their password, as authMiddleware does for HTTP.
+// A second factor in the x-otp metadata is checked as well, and marks the call as stepped up.
+func authInterceptor(tokenMaker token.Maker, store db.Store, totpCipher *totp.Cipher) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if slices.Contains(publicMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		fields := strings.Fields(firstMetadata(ctx, authorizationHeader))
+		if len(fields) < 2 {
+			return nil, status.Error(codes.Unauthenticated, "missing or malformed authorization metadata")
+		}
+
+		if authorizationType := strings.ToLower(fields[0]); authorizationType != authorizationTypeBearer {
+			return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization type %s", authorizationType)
+		}
+
+		payload, err := tokenMaker.VerifyToken(fields[1])
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		passwordChangedAt, err := store.GetUserPasswordChangedAt(ctx, payload.Username)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+			return nil, internalError(ctx, err)
+		}
+
+		if passwordChangedAt.Valid && payload.IssuedAt.Before(passwordChangedAt.Time) {
+			return nil, status.Error(codes.Unauthenticated, "token was issued before the last password change")
+		}
+
+		if code := firstMetadata(ctx, otpHeader); code != "" {
+			userTOTP, enrolled, err := db.ConfirmedTOTP(ctx, store, totpCipher, payload.Username)
+			if err != nil {
+				return nil, internalError(ctx, err)
+			}
+
+			if !enrolled {
+				return nil, status.Error(codes.Unauthenticated, "two-factor authentication is not enabled")
+			}
+
+			err = db.CheckSecondFactor(ctx, store, userTOTP, code)
+			if err != nil {
+				if errors.Is(err, db.ErrInvalidOTP) {
+					return nil, status.Error(codes.Unauthenticated, err.Error())
+				}
+				if errors.Is(err, db.ErrOTPLocked) {
+					return nil, status.Error(codes.ResourceExhausted, err.Error())
+				}
+				return nil, internalError(ctx, err)
+			}
+
+			ctx = context.WithValue(ctx, stepUpKey{}, true)
+		}
+
+		ctx = context.WithValue(ctx, authPayloadKey{}, payload)
+		if auditInfo := db.AuditInfoFrom(ctx); auditInfo != nil {
+			auditInfo.Actor = payload.Username
+		}
+		if fields := logging.FieldsFrom(ctx); fields != nil {
+			fields.Username = payload.Username
+		}
+		return handler(ctx, req)
+	}
+}
+
+// permissionInterceptor, placed after authInterceptor, only lets callers through whose roles grant
+// the permission of the method they call
+func permissionInterceptor(store db.Store) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		permission, ok := methodPermissions[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		username := authPayload(ctx).Username
+		permissions, err := store.ListUserPermissions(ctx, username)
+		if err != nil {
+			return nil, internalError(ctx, err)
+		}
+
+		if !slices.Contains(permissions, permission) {
+			return nil, status.Errorf(codes.PermissionDenied, "user %s lacks the %s permission", username, permission)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
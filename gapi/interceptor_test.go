@@ -0,0 +1,211 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/internal/logging"
+	"simple_bank/pb"
+	"simple_bank/util"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuthInterceptor(t *testing.T) {
+	username := util.RandomOwner()
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mock_db.MockStore)
+		setupCtx   func(t *testing.T, server *Server) context.Context
+		code       codes.Code
+	}{
+		{
+			name:       "OK",
+			buildStubs: func(store *mock_db.MockStore) {},
+			setupCtx: func(t *testing.T, server *Server) context.Context {
+				return withAuthorization(t, server, username)
+			},
+			code: codes.OK,
+		},
+		{
+			name:       "NoAuthorization",
+			buildStubs: func(store *mock_db.MockStore) {},
+			setupCtx: func(t *testing.T, server *Server) context.Context {
+				return context.Background()
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:       "UnsupportedAuthorization",
+			buildStubs: func(store *mock_db.MockStore) {},
+			setupCtx: func(t *testing.T, server *Server) context.Context {
+				return metadata.AppendToOutgoingContext(context.Background(), authorizationHeader, "basic dXNlcjpwYXNz")
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:       "ExpiredToken",
+			buildStubs: func(store *mock_db.MockStore) {},
+			setupCtx: func(t *testing.T, server *Server) context.Context {
+				accessToken, _, err := server.tokenMaker.CreateToken(username, -time.Minute)
+				require.NoError(t, err)
+				return metadata.AppendToOutgoingContext(context.Background(), authorizationHeader, "bearer "+accessToken)
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "TokenBeforePasswordChange",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().
+					GetUserPasswordChangedAt(gomock.Any(), gomock.Eq(username)).
+					Times(1).
+					Return(pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true}, nil)
+			},
+			setupCtx: func(t *testing.T, server *Server) context.Context {
+				return withAuthorization(t, server, username)
+			},
+			code: codes.Unauthenticated,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).AnyTimes().Return(db.User{Username: username}, nil)
+
+			server, client := newTestServer(t, store)
+			rsp, err := client.GetUser(tc.setupCtx(t, server), &pb.GetUserRequest{})
+			requireCode(t, err, tc.code)
+			if tc.code == codes.OK {
+				require.Equal(t, username, rsp.GetUser().GetUsername())
+			}
+		})
+	}
+}
+
+func TestPermissionInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	username := util.RandomOwner()
+	store := mock_db.NewMockStore(ctrl)
+	store.EXPECT().ListUserPermissions(gomock.Any(), gomock.Eq(username)).Times(1).Return([]string{db.PermBackOffice}, nil)
+	store.EXPECT().ListAccountsByHolder(gomock.Any(), gomock.Any()).Times(0)
+
+	server, client := newTestServer(t, store)
+	_, err := client.ListAccounts(withAuthorization(t, server, username), &pb.ListAccountsRequest{PageId: 1, PageSize: 5})
+	requireCode(t, err, codes.PermissionDenied)
+}
+
+func TestAuditInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	store.EXPECT().
+		RecordAudit(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, rec db.AuditRecord) error {
+			require.Equal(t, pb.SimpleBank_LoginUser_FullMethodName, rec.ResourceID)
+			require.Equal(t, "InvalidArgument", rec.After.(map[string]any)["code"])
+
+			info := db.AuditInfoFrom(ctx)
+			require.NotNil(t, info)
+			require.Equal(t, "req-1", info.RequestID)
+			return nil
+		})
+
+	_, client := newTestServer(t, store)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestIDHeader, "req-1")
+	_, err := client.LoginUser(ctx, &pb.LoginUserRequest{}, grpc.Header(&header))
+	requireCode(t, err, codes.InvalidArgument)
+	require.Equal(t, []string{"req-1"}, header.Get(requestIDHeader))
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	username := util.RandomOwner()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_db.NewMockStore(ctrl)
+	store.EXPECT().ListAccountsByHolder(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("connection reset"))
+
+	var logs bytes.Buffer
+	logger, err := logging.New(&logs, "info")
+	require.NoError(t, err)
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	server, client := newTestServer(t, store)
+
+	ctx := metadata.AppendToOutgoingContext(withAuthorization(t, server, username), requestIDHeader, "req-7")
+	_, err = client.ListAccounts(ctx, &pb.ListAccountsRequest{PageId: 1, PageSize: 5})
+	requireCode(t, err, codes.Internal)
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var failure, request map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &failure))
+	require.Equal(t, "connection reset", failure["error"])
+	require.Equal(t, "req-7", failure["request_id"])
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &request))
+	require.Equal(t, "ERROR", request["level"])
+	require.Equal(t, pb.SimpleBank_ListAccounts_FullMethodName, request["method"])
+	require.Equal(t, "Internal", request["code"])
+	require.Equal(t, "req-7", request["request_id"])
+	require.Equal(t, username, request["user"])
+}
+
+func TestTracingInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, client := newTestServer(t, mock_db.NewMockStore(ctrl))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, err := client.GetUser(ctx, &pb.GetUserRequest{})
+	requireCode(t, err, codes.Unauthenticated)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, pb.SimpleBank_GetUser_FullMethodName, spans[0].Name())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
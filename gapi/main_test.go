@@ -0,0 +1,76 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/pb"
+	"simple_bank/util"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testTOTPKey encrypts the TOTP secrets of test servers, so stubs can return secrets sealed with it
+const testTOTPKey = "0123456789abcdef0123456789abcdef"
+
+// newTestServer serves a server with a random token key around the given store over an in-memory connection,
+// through the same interceptors as StartServer, and returns it with a client connected to it
+func newTestServer(t *testing.T, store db.Store) (*Server, pb.SimpleBankClient) {
+	config := util.Config{
+		TokenSymmetricKey:   util.RandomString(32),
+		TOTPEncryptionKey:   testTOTPKey,
+		AccessTokenDuration: time.Minute,
+	}
+
+	// as for api.Server, calls are audited, tokens are checked against the password change time,
+	// logins look for a second factor and callers are customers unless a test says otherwise
+	if mockStore, ok := store.(*mock_db.MockStore); ok {
+		mockStore.EXPECT().RecordAudit(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+		mockStore.EXPECT().GetUserPasswordChangedAt(gomock.Any(), gomock.Any()).AnyTimes().Return(pgtype.Timestamptz{}, nil)
+		mockStore.EXPECT().GetUserTOTP(gomock.Any(), gomock.Any()).AnyTimes().Return(db.UserTotp{}, db.ErrRecordNotFound)
+		mockStore.EXPECT().ListUserPermissions(gomock.Any(), gomock.Any()).AnyTimes().Return([]string{db.PermBanking}, nil)
+	}
+
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.grpcServer.Serve(listener)
+	t.Cleanup(server.grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return server, pb.NewSimpleBankClient(conn)
+}
+
+// withAuthorization returns a context that sends a fresh access token for username
+func withAuthorization(t *testing.T, server *Server, username string) context.Context {
+	accessToken, _, err := server.tokenMaker.CreateToken(username, time.Minute)
+	require.NoError(t, err)
+
+	return metadata.AppendToOutgoingContext(context.Background(),
+		authorizationHeader, fmt.Sprintf("%s %s", authorizationTypeBearer, accessToken))
+}
+
+func requireCode(t *testing.T, err error, code codes.Code) {
+	require.Equal(t, code, status.Code(err), "error: %v", err)
+}
@@ -0,0 +1,140 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"simple_bank/internal/db"
+	"simple_bank/internal/kyc"
+	"simple_bank/pb"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// anyHolder lets every holder of an account through, including viewers
+var anyHolder = []string{db.HolderOwner, db.HolderSignatory, db.HolderViewer}
+
+// authorizeAccount checks username holds the account with one of the given roles
+func (server *Server) authorizeAccount(ctx context.Context, username string, accountID int64, roles ...string) error {
+	holder, err := server.store.GetAccountHolder(ctx, db.GetAccountHolderParams{
+		AccountID: accountID,
+		Username:  username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return status.Errorf(codes.PermissionDenied, "account [%d] doesn't belong to the authenticated user", accountID)
+		}
+		return internalError(ctx, err)
+	}
+
+	if !slices.Contains(roles, holder.Role) {
+		return status.Errorf(codes.PermissionDenied, "a %s of account [%d] is not allowed to do this", holder.Role, accountID)
+	}
+
+	return nil
+}
+
+// userTier looks up a user and the KYC limits that apply to them
+func (server *Server) userTier(ctx context.Context, username string) (db.User, kyc.Tier, error) {
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.User{}, kyc.Tier{}, status.Errorf(codes.PermissionDenied, "user %s is not registered", username)
+		}
+		return db.User{}, kyc.Tier{}, internalError(ctx, err)
+	}
+
+	return user, kyc.TierFor(user.KycTier), nil
+}
+
+// CreateAccount opens an account owned by the caller
+func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	violations := validateFields(
+		field{"currency", req.GetCurrency(), "required,currency"},
+		field{"country_code", req.GetCountryCode(), "required"},
+	)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	username := authPayload(ctx).Username
+	_, tier, err := server.userTier(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := server.store.CountAccountsByOwner(ctx, username)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	if count >= tier.MaxAccounts {
+		return nil, status.Errorf(codes.PermissionDenied, "kyc tier %d allows at most %d open accounts", tier.Level, tier.MaxAccounts)
+	}
+
+	account, err := server.store.CreateAccountTx(ctx, db.CreateAccountParams{
+		Owner:       username,
+		Currency:    req.GetCurrency(),
+		CountryCode: req.GetCountryCode(),
+		Balance:     0,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Error(codes.AlreadyExists, "an account in this currency already exists")
+		}
+		return nil, internalError(ctx, err)
+	}
+
+	return &pb.CreateAccountResponse{Account: convertAccount(account)}, nil
+}
+
+// GetAccount returns an account the caller holds
+func (server *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	violations := validateFields(field{"id", req.GetId(), "required,min=1"})
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	err := server.authorizeAccount(ctx, authPayload(ctx).Username, req.GetId(), anyHolder...)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account [%d] not found", req.GetId())
+		}
+		return nil, internalError(ctx, err)
+	}
+
+	return &pb.GetAccountResponse{Account: convertAccount(account)}, nil
+}
+
+// ListAccounts lists the accounts the caller holds
+func (server *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	violations := validateFields(
+		field{"page_id", req.GetPageId(), "required,min=1"},
+		field{"page_size", req.GetPageSize(), "required,min=5,max=10"},
+	)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	accounts, err := server.store.ListAccountsByHolder(ctx, db.ListAccountsByHolderParams{
+		Username: authPayload(ctx).Username,
+		Limit:    req.GetPageSize(),
+		Offset:   (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	rsp := &pb.ListAccountsResponse{}
+	for _, account := range accounts {
+		rsp.Accounts = append(rsp.Accounts, convertAccount(account))
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,130 @@
+package gapi
+
+import (
+	"database/sql"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/pb"
+	"simple_bank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomAccount() db.Account {
+	return db.Account{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    util.RandomOwner(),
+		Balance:  util.RandomMoney(),
+		Currency: util.RandomCurrency(),
+		Status:   db.AccountActive,
+	}
+}
+
+func expectHolder(store *mock_db.MockStore, accountID int64, username, role string) {
+	store.EXPECT().
+		GetAccountHolder(gomock.Any(), gomock.Eq(db.GetAccountHolderParams{
+			AccountID: accountID,
+			Username:  username,
+		})).
+		Times(1).
+		Return(db.AccountHolder{AccountID: accountID, Username: username, Role: role}, nil)
+}
+
+func TestGetAccountRPC(t *testing.T) {
+	account := randomAccount()
+
+	testCases := []struct {
+		name       string
+		accountID  int64
+		buildStubs func(store *mock_db.MockStore)
+		check      func(t *testing.T, rsp *pb.GetAccountResponse, err error)
+	}{
+		{
+			name:      "OK",
+			accountID: account.ID,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderViewer)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			},
+			check: func(t *testing.T, rsp *pb.GetAccountResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account.ID, rsp.GetAccount().GetId())
+				require.Equal(t, account.Owner, rsp.GetAccount().GetOwner())
+				require.Equal(t, account.Balance, rsp.GetAccount().GetBalance())
+				require.Equal(t, account.Currency, rsp.GetAccount().GetCurrency())
+			},
+		},
+		{
+			name:      "NotFound",
+			accountID: account.ID,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderViewer)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, db.ErrRecordNotFound)
+			},
+			check: func(t *testing.T, rsp *pb.GetAccountResponse, err error) {
+				requireCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name:      "InternalError",
+			accountID: account.ID,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account.ID, account.Owner, db.HolderViewer)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, rsp *pb.GetAccountResponse, err error) {
+				requireCode(t, err, codes.Internal)
+				require.NotContains(t, status.Convert(err).Message(), sql.ErrConnDone.Error())
+			},
+		},
+		{
+			name:      "NotHolder",
+			accountID: account.ID,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetAccountHolder(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountHolder{}, db.ErrRecordNotFound)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.GetAccountResponse, err error) {
+				requireCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name:      "InvalidID",
+			accountID: 0,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.GetAccountResponse, err error) {
+				requireCode(t, err, codes.InvalidArgument)
+
+				var fields []string
+				for _, detail := range status.Convert(err).Details() {
+					for _, violation := range detail.(*errdetails.BadRequest).GetFieldViolations() {
+						fields = append(fields, violation.GetField())
+					}
+				}
+				require.Equal(t, []string{"id"}, fields)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server, client := newTestServer(t, store)
+			rsp, err := client.GetAccount(withAuthorization(t, server, account.Owner), &pb.GetAccountRequest{Id: tc.accountID})
+			tc.check(t, rsp, err)
+		})
+	}
+}
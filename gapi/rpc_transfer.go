@@ -0,0 +1,207 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"simple_bank/internal/db"
+	"simple_bank/internal/fraud"
+	"simple_bank/internal/kyc"
+	"simple_bank/internal/metrics"
+	"simple_bank/pb"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateTransfer screens and posts a transfer from an account the caller owns or signs for,
+// under the same payee, KYC, email and step-up rules as the HTTP API.
+// A transfer flagged by screening is held for review instead.
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (rsp *pb.CreateTransferResponse, err error) {
+	// refused and failed calls are counted by their status code, posted and held ones below
+	defer func() {
+		if err != nil {
+			metrics.ObserveTransferFailed(failureReason(err))
+		}
+	}()
+
+	violations := validateFields(
+		field{"from_account_id", req.GetFromAccountId(), "required,min=1"},
+		field{"to_account_id", req.GetToAccountId(), "required,min=1"},
+		field{"amount", req.GetAmount(), "required,min=1"},
+		field{"currency", req.GetCurrency(), "required,currency"},
+	)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// viewers can see an account but only owners and signatories can move its money
+	username := authPayload(ctx).Username
+	err = server.authorizeAccount(ctx, username, req.GetFromAccountId(), db.HolderOwner, db.HolderSignatory)
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.checkPayee(ctx, username, req)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	toAccount, err := server.validAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if !db.CanSend(fromAccount.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "account [%d] is %s: %v", fromAccount.ID, fromAccount.Status, db.ErrAccountCannotSend)
+	}
+
+	if !db.CanReceive(toAccount.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "account [%d] is %s: %v", toAccount.ID, toAccount.Status, db.ErrAccountCannotReceive)
+	}
+
+	user, tier, err := server.userTier(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAmount() > tier.MaxTransferAmount {
+		return nil, status.Errorf(codes.PermissionDenied, "kyc tier %d allows transfers of at most %d", tier.Level, tier.MaxTransferAmount)
+	}
+
+	if !user.IsEmailVerified && req.GetAmount() > kyc.MaxUnverifiedEmailTransfer {
+		return nil, status.Errorf(codes.FailedPrecondition, "verify your email address to send more than %d", kyc.MaxUnverifiedEmailTransfer)
+	}
+
+	threshold := server.config.StepUpTransferThreshold
+	if threshold > 0 && req.GetAmount() >= threshold && !steppedUp(ctx) {
+		return nil, status.Errorf(codes.Unauthenticated, "transfers of %d or more need a two-factor code in the %s metadata", threshold, otpHeader)
+	}
+
+	decision, err := server.screener.Screen(ctx, fraud.Transfer{
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Amount:      req.GetAmount(),
+	})
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	// suspicious transfers are queued for an admin instead of being posted
+	if decision.Flagged {
+		review, err := server.store.CreateTransferReview(ctx, db.CreateTransferReviewParams{
+			FromAccountID: req.GetFromAccountId(),
+			ToAccountID:   req.GetToAccountId(),
+			Amount:        req.GetAmount(),
+			Reasons:       decision.Reasons,
+		})
+		if err != nil {
+			return nil, internalError(ctx, err)
+		}
+
+		metrics.ObserveTransferHeld()
+		return &pb.CreateTransferResponse{
+			Outcome: &pb.CreateTransferResponse_Review{Review: convertTransferReview(review)},
+		}, nil
+	}
+
+	result, err := server.store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   req.GetToAccountId(),
+		Amount:        req.GetAmount(),
+	})
+	if err != nil {
+		// the status may have changed between the checks above and the transaction
+		if errors.Is(err, db.ErrAccountCannotSend) || errors.Is(err, db.ErrAccountCannotReceive) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, internalError(ctx, err)
+	}
+
+	metrics.ObserveTransferCreated(fromAccount.Currency, req.GetAmount())
+	return &pb.CreateTransferResponse{
+		Outcome: &pb.CreateTransferResponse_Result{Result: convertTransferResult(result)},
+	}, nil
+}
+
+// checkPayee lets a transfer go to a verified beneficiary or one of the caller's own accounts,
+// and to any other account only once the request confirms the new payee, as payeeAccount does for HTTP.
+// Confirming one takes a second factor from users with two-factor authentication.
+func (server *Server) checkPayee(ctx context.Context, username string, req *pb.CreateTransferRequest) error {
+	if req.GetConfirmNewPayee() {
+		return server.newPayeeStepUp(ctx, username)
+	}
+
+	beneficiary, err := server.store.GetBeneficiaryByAccount(ctx, db.GetBeneficiaryByAccountParams{
+		Username:  username,
+		AccountID: req.GetToAccountId(),
+	})
+	if err == nil && beneficiary.Verified {
+		return nil
+	}
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return internalError(ctx, err)
+	}
+
+	// moving money between the caller's own accounts needs no confirmation
+	_, err = server.store.GetAccountHolder(ctx, db.GetAccountHolderParams{
+		AccountID: req.GetToAccountId(),
+		Username:  username,
+	})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, db.ErrRecordNotFound) {
+		return internalError(ctx, err)
+	}
+
+	return status.Error(codes.FailedPrecondition, "transfers to a new payee must be confirmed with confirm_new_payee")
+}
+
+// newPayeeStepUp asks users with two-factor authentication for a second factor before money goes to a new payee,
+// so a stolen access token alone can't send it anywhere. Users without it go through on the token alone.
+func (server *Server) newPayeeStepUp(ctx context.Context, username string) error {
+	if steppedUp(ctx) {
+		return nil
+	}
+
+	enrolled, err := db.HasTOTP(ctx, server.store, username)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	if enrolled {
+		return status.Errorf(codes.Unauthenticated, "a new payee needs a two-factor code in the %s metadata", otpHeader)
+	}
+	return nil
+}
+
+func (server *Server) validAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, internalError(ctx, err)
+	}
+
+	if account.Currency != currency {
+		return account, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+	}
+
+	return account, nil
+}
+
+var wordStart = regexp.MustCompile(`(.)([A-Z])`)
+
+// failureReason is the status code of a failed call in snake case, e.g. failed_precondition,
+// the form the HTTP API's error codes take
+func failureReason(err error) string {
+	return strings.ToLower(wordStart.ReplaceAllString(status.Code(err).String(), "${1}_${2}"))
+}
@@ -0,0 +1,171 @@
+package gapi
+
+import (
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/internal/kyc"
+	"simple_bank/pb"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateTransferRPC(t *testing.T) {
+	account1 := randomAccount()
+	account2 := randomAccount()
+	account2.ID = account1.ID + 1
+	account2.Currency = account1.Currency
+	account2.CountryCode = account1.CountryCode
+
+	unverified := db.User{Username: account1.Owner, KycStatus: kyc.StatusUnverified}
+
+	testCases := []struct {
+		name            string
+		amount          int64
+		confirmNewPayee bool
+		buildStubs      func(store *mock_db.MockStore)
+		check           func(t *testing.T, rsp *pb.CreateTransferResponse, err error)
+	}{
+		{
+			name:            "OK",
+			amount:          10,
+			confirmNewPayee: true,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(unverified, nil)
+				store.EXPECT().CountTransfersFromAccountSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        10,
+					})).
+					Times(1).
+					Return(db.TransferTxResult{Transfer: db.Transfer{ID: 1, Amount: 10}}, nil)
+			},
+			check: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(10), rsp.GetResult().GetTransfer().GetAmount())
+				require.Nil(t, rsp.GetReview())
+			},
+		},
+		{
+			name:   "NewPayee",
+			amount: 10,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetBeneficiaryByAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Beneficiary{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					GetAccountHolder(gomock.Any(), gomock.Eq(db.GetAccountHolderParams{AccountID: account2.ID, Username: account1.Owner})).
+					Times(1).
+					Return(db.AccountHolder{}, db.ErrRecordNotFound)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				requireCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name:            "NewPayeeStepUpRequired",
+			amount:          10,
+			confirmNewPayee: true,
+			buildStubs: func(store *mock_db.MockStore) {
+				enrolled := db.UserTotp{Username: account1.Owner, ConfirmedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}}
+
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetUserTOTP(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(enrolled, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				requireCode(t, err, codes.Unauthenticated)
+				require.Contains(t, status.Convert(err).Message(), otpHeader)
+			},
+		},
+		{
+			name:            "TierTransferLimit",
+			amount:          kyc.TierFor(0).MaxTransferAmount + 1,
+			confirmNewPayee: true,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(account1.Owner)).Times(1).Return(unverified, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				requireCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name:            "ViewerCannotSend",
+			amount:          10,
+			confirmNewPayee: true,
+			buildStubs: func(store *mock_db.MockStore) {
+				expectHolder(store, account1.ID, account1.Owner, db.HolderViewer)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				requireCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name:            "FrozenFromAccount",
+			amount:          10,
+			confirmNewPayee: true,
+			buildStubs: func(store *mock_db.MockStore) {
+				frozen := account1
+				frozen.Status = db.AccountFrozen
+
+				expectHolder(store, account1.ID, account1.Owner, db.HolderOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(frozen, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				requireCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name:            "NegativeAmount",
+			amount:          -10,
+			confirmNewPayee: true,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetAccountHolder(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				requireCode(t, err, codes.InvalidArgument)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server, client := newTestServer(t, store)
+			rsp, err := client.CreateTransfer(withAuthorization(t, server, account1.Owner), &pb.CreateTransferRequest{
+				FromAccountId:   account1.ID,
+				ToAccountId:     account2.ID,
+				Amount:          tc.amount,
+				Currency:        account1.Currency,
+				ConfirmNewPayee: tc.confirmNewPayee,
+			})
+			tc.check(t, rsp, err)
+		})
+	}
+}
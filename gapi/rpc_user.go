@@ -0,0 +1,128 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"simple_bank/internal/db"
+	"simple_bank/internal/tasks"
+	"simple_bank/pb"
+	"simple_bank/util"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	violations := validateFields(
+		field{"username", req.GetUsername(), "required,alphanum"},
+		field{"password", req.GetPassword(), "required,min=6"},
+		field{"full_name", req.GetFullName(), "required"},
+		field{"email", req.GetEmail(), "required,email"},
+	)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hashedPassword, err := util.HashPassword(req.GetPassword())
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	user, err := server.store.CreateUserTx(ctx, db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:          req.GetUsername(),
+			HashedPassword:    hashedPassword,
+			FullName:          req.GetFullName(),
+			Email:             req.GetEmail(),
+			PasswordChangedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		},
+		// the verification email is only queued if the user is committed
+		AfterCreate: func(q *db.Queries, user db.User) error {
+			return tasks.EnqueueVerifyEmail(ctx, q, user.Username)
+		},
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "username or email is already taken")
+		}
+		return nil, internalError(ctx, err)
+	}
+
+	return &pb.CreateUserResponse{User: convertUser(user)}, nil
+}
+
+// errInvalidCredentials answers both an unknown username and a wrong password
+var errInvalidCredentials = status.Error(codes.Unauthenticated, "incorrect username or password")
+
+func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
+	violations := validateFields(
+		field{"username", req.GetUsername(), "required,alphanum"},
+		field{"password", req.GetPassword(), "required,min=6"},
+	)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// an unknown user and a wrong password get the same answer, so logins can't probe for usernames
+	user, err := server.store.GetUser(ctx, req.GetUsername())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, errInvalidCredentials
+		}
+		return nil, internalError(ctx, err)
+	}
+
+	err = util.CheckPassword(req.GetPassword(), user.HashedPassword)
+	if err != nil {
+		return nil, errInvalidCredentials
+	}
+
+	userTOTP, enrolled, err := db.ConfirmedTOTP(ctx, server.store, server.totpCipher, user.Username)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	if enrolled {
+		if req.GetOtp() == "" {
+			return nil, status.Error(codes.Unauthenticated, "a two-factor code is required")
+		}
+
+		err = db.CheckSecondFactor(ctx, server.store, userTOTP, req.GetOtp())
+		if err != nil {
+			if errors.Is(err, db.ErrInvalidOTP) {
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+			if errors.Is(err, db.ErrOTPLocked) {
+				return nil, status.Error(codes.ResourceExhausted, err.Error())
+			}
+			return nil, internalError(ctx, err)
+		}
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.AccessTokenDuration)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	return &pb.LoginUserResponse{
+		User:                 convertUser(user),
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: timestamppb.New(accessPayload.ExpiredAt),
+	}, nil
+}
+
+// GetUser returns the caller's own profile
+func (server *Server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	user, err := server.store.GetUser(ctx, authPayload(ctx).Username)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, internalError(ctx, err)
+	}
+
+	return &pb.GetUserResponse{User: convertUser(user)}, nil
+}
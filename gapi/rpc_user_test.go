@@ -0,0 +1,81 @@
+package gapi
+
+import (
+	"context"
+	"simple_bank/internal/db"
+	mock_db "simple_bank/internal/db/mock"
+	"simple_bank/pb"
+	"simple_bank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginUserRPC(t *testing.T) {
+	password := util.RandomString(6)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+	user := db.User{Username: util.RandomOwner(), HashedPassword: hashedPassword}
+
+	testCases := []struct {
+		name       string
+		password   string
+		buildStubs func(store *mock_db.MockStore)
+		check      func(t *testing.T, rsp *pb.LoginUserResponse, err error)
+	}{
+		{
+			name:     "OK",
+			password: password,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+			},
+			check: func(t *testing.T, rsp *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, rsp.GetAccessToken())
+			},
+		},
+		{
+			name:     "UserNotFound",
+			password: password,
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{}, db.ErrRecordNotFound)
+			},
+			check: func(t *testing.T, rsp *pb.LoginUserResponse, err error) {
+				requireCode(t, err, codes.Unauthenticated)
+				require.Equal(t, status.Convert(errInvalidCredentials).Message(), status.Convert(err).Message())
+			},
+		},
+		{
+			name:     "IncorrectPassword",
+			password: "incorrect",
+			buildStubs: func(store *mock_db.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+			},
+			check: func(t *testing.T, rsp *pb.LoginUserResponse, err error) {
+				requireCode(t, err, codes.Unauthenticated)
+				require.Equal(t, status.Convert(errInvalidCredentials).Message(), status.Convert(err).Message())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_db.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			_, client := newTestServer(t, store)
+			rsp, err := client.LoginUser(context.Background(), &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: tc.password,
+			})
+			tc.check(t, rsp, err)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"simple_bank/internal/db"
+	"simple_bank/internal/fraud"
+	"simple_bank/internal/token"
+	"simple_bank/internal/totp"
+	"simple_bank/pb"
+	"simple_bank/util"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server is a gRPC server for the users, accounts and transfers of the HTTP API.
+// It shares the store, token format, validation rules and permissions with api.Server,
+// so internal services can call either one.
+type Server struct {
+	pb.UnimplementedSimpleBankServer
+	config     util.Config
+	store      db.Store
+	tokenMaker token.Maker
+	totpCipher *totp.Cipher
+	screener   *fraud.Engine
+	grpcServer *grpc.Server
+}
+
+// NewServer creates a gRPC server around store. Tokens issued by api.Server are accepted as long as
+// both are configured with the same TOKEN_SYMMETRIC_KEY.
+func NewServer(config util.Config, store db.Store) (*Server, error) {
+	tokenMaker, err := token.NewJWTMaker(config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create token maker: %w", err)
+	}
+
+	totpCipher, err := totp.NewCipher(config.TOTPEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create TOTP cipher: %w", err)
+	}
+
+	server := &Server{
+		config:     config,
+		store:      store,
+		tokenMaker: tokenMaker,
+		totpCipher: totpCipher,
+		screener:   fraud.NewEngine(store, fraud.DefaultRules()...),
+	}
+	server.grpcServer = server.newGRPCServer()
+
+	return server, nil
+}
+
+// newGRPCServer registers server on a grpc.Server that traces, logs, audits, authenticates and authorizes every call
+func (server *Server) newGRPCServer() *grpc.Server {
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		tracingInterceptor(),
+		loggingInterceptor(slog.Default()),
+		auditInterceptor(server.store),
+		authInterceptor(server.tokenMaker, server.store, server.totpCipher),
+		permissionInterceptor(server.store),
+	))
+	pb.RegisterSimpleBankServer(grpcServer, server)
+	// lets tools such as grpcurl discover the service
+	reflection.Register(grpcServer)
+
+	return grpcServer
+}
+
+// StartServer runs the gRPC server on a specific address until Shutdown is called,
+// when it returns nil
+func (server *Server) StartServer(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("cannot listen on %s: %w", address, err)
+	}
+
+	slog.Info("gRPC server listening", "address", listener.Addr().String())
+	err = server.grpcServer.Serve(listener)
+	if errors.Is(err, grpc.ErrServerStopped) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops accepting calls and waits for in-flight ones, such as transfers, to finish.
+// Once ctx is done the remaining calls are cancelled and its error is returned.
+func (server *Server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		server.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"errors"
+	"fmt"
+	"simple_bank/util"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// validate checks request fields against the binding rules of the matching api.Server requests
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterValidation("currency", validCurrency)
+	return v
+}
+
+var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	currency, ok := fieldLevel.Field().Interface().(string)
+	return ok && util.IsSupportedCurrency(currency)
+}
+
+// field is a request field with the rules it has to pass, written like binding tags
+type field struct {
+	name  string
+	value any
+	rules string
+}
+
+// validateFields returns a violation for every field that breaks one of its rules, nil if none does
+func validateFields(fields ...field) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, f := range fields {
+		err := validate.Var(f.value, f.rules)
+		if err == nil {
+			continue
+		}
+
+		var validationErrs validator.ValidationErrors
+		if !errors.As(err, &validationErrs) {
+			violations = append(violations, fieldViolation(f.name, err))
+			continue
+		}
+
+		for _, fieldErr := range validationErrs {
+			rule := fieldErr.Tag()
+			if fieldErr.Param() != "" {
+				rule += "=" + fieldErr.Param()
+			}
+			violations = append(violations, fieldViolation(f.name, fmt.Errorf("%s failed the %s rule", f.name, rule)))
+		}
+	}
+
+	return violations
+}
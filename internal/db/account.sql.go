@@ -7,13 +7,15 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, country_code, created_at, updated_at
+RETURNING id, owner, balance, currency, country_code, status, frozen_by, closed_at, created_at, updated_at
 `
 
 type AddAccountBalanceParams struct {
@@ -30,19 +32,34 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CountryCode,
+		&i.Status,
+		&i.FrozenBy,
+		&i.ClosedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const countAccountsByOwner = `-- name: CountAccountsByOwner :one
+SELECT count(*) FROM accounts
+WHERE owner = $1 AND status <> 'closed'
+`
+
+func (q *Queries) CountAccountsByOwner(ctx context.Context, owner string) (int64, error) {
+	row := q.db.QueryRow(ctx, countAccountsByOwner, owner)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
   owner, balance, currency, country_code
 ) VALUES (
   $1, $2, $3, $4
 )
-RETURNING id, owner, balance, currency, country_code, created_at, updated_at
+RETURNING id, owner, balance, currency, country_code, status, frozen_by, closed_at, created_at, updated_at
 `
 
 type CreateAccountParams struct {
@@ -66,6 +83,9 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CountryCode,
+		&i.Status,
+		&i.FrozenBy,
+		&i.ClosedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -83,7 +103,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, country_code, created_at, updated_at FROM accounts
+SELECT id, owner, balance, currency, country_code, status, frozen_by, closed_at, created_at, updated_at FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
@@ -97,6 +117,9 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CountryCode,
+		&i.Status,
+		&i.FrozenBy,
+		&i.ClosedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -104,7 +127,7 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, country_code, created_at, updated_at FROM accounts
+SELECT id, owner, balance, currency, country_code, status, frozen_by, closed_at, created_at, updated_at FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE
 `
@@ -118,6 +141,9 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CountryCode,
+		&i.Status,
+		&i.FrozenBy,
+		&i.ClosedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -125,7 +151,7 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, country_code, created_at, updated_at FROM accounts
+SELECT id, owner, balance, currency, country_code, status, frozen_by, closed_at, created_at, updated_at FROM accounts
 ORDER BY id
 LIMIT $1
 OFFSET $2
@@ -151,6 +177,55 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CountryCode,
+			&i.Status,
+			&i.FrozenBy,
+			&i.ClosedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsByHolder = `-- name: ListAccountsByHolder :many
+SELECT accounts.id, accounts.owner, accounts.balance, accounts.currency, accounts.country_code, accounts.status, accounts.frozen_by, accounts.closed_at, accounts.created_at, accounts.updated_at FROM accounts
+JOIN account_holders ON account_holders.account_id = accounts.id
+WHERE account_holders.username = $1
+ORDER BY accounts.id
+LIMIT $2
+OFFSET $3
+`
+
+type ListAccountsByHolderParams struct {
+	Username string
+	Limit    int32
+	Offset   int32
+}
+
+func (q *Queries) ListAccountsByHolder(ctx context.Context, arg ListAccountsByHolderParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsByHolder, arg.Username, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CountryCode,
+			&i.Status,
+			&i.FrozenBy,
+			&i.ClosedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -172,7 +247,7 @@ SET owner = $2,
     country_code = $5,
     updated_at = now()
 WHERE id = $1
-RETURNING id, owner, balance, currency, country_code, created_at, updated_at
+RETURNING id, owner, balance, currency, country_code, status, frozen_by, closed_at, created_at, updated_at
 `
 
 type UpdateAccountParams struct {
@@ -193,3 +268,37 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) er
 	)
 	return err
 }
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = $1,
+    frozen_by = $2,
+    closed_at = CASE WHEN $1::varchar = 'closed' THEN now() ELSE closed_at END,
+    updated_at = now()
+WHERE id = $3
+RETURNING id, owner, balance, currency, country_code, status, frozen_by, closed_at, created_at, updated_at
+`
+
+type UpdateAccountStatusParams struct {
+	Status   string
+	FrozenBy pgtype.Text
+	ID       int64
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountStatus, arg.Status, arg.FrozenBy, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CountryCode,
+		&i.Status,
+		&i.FrozenBy,
+		&i.ClosedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Roles a user can hold on an account.
+// Owners manage the account and its holders, signatories can also send money, viewers can only read.
+const (
+	HolderOwner     = "owner"
+	HolderSignatory = "signatory"
+	HolderViewer    = "viewer"
+)
+
+// Actions recorded in account_holder_events
+const (
+	HolderAdded   = "added"
+	HolderRemoved = "removed"
+)
+
+// ErrLastOwner is returned when removing a holder would leave an account without an owner
+var ErrLastOwner = errors.New("account must keep at least one owner")
+
+// CreateAccountTx creates an account and registers its owner as the first holder
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	var account Account
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		account, err = q.CreateAccount(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateAccountHolder(ctx, CreateAccountHolderParams{
+			AccountID: account.ID,
+			Username:  arg.Owner,
+			Role:      HolderOwner,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.enqueueEvent(ctx, "account", account.ID, EventAccountCreated, account)
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Action:       AuditAccountCreate,
+			ResourceType: "account",
+			ResourceID:   account.ID,
+			After:        account,
+		})
+	})
+
+	return account, err
+}
+
+// AddAccountHolderTxParams contains the input parameters of the add holder transaction.
+// Actor is the holder making the change and is recorded in the audit trail.
+type AddAccountHolderTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	Actor     string `json:"actor"`
+}
+
+// AddAccountHolderTx adds a holder to an account and records who added them
+func (store *SQLStore) AddAccountHolderTx(ctx context.Context, arg AddAccountHolderTxParams) (AccountHolder, error) {
+	var holder AccountHolder
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		holder, err = q.CreateAccountHolder(ctx, CreateAccountHolderParams{
+			AccountID: arg.AccountID,
+			Username:  arg.Username,
+			Role:      arg.Role,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateAccountHolderEvent(ctx, CreateAccountHolderEventParams{
+			AccountID: arg.AccountID,
+			Username:  arg.Username,
+			Role:      arg.Role,
+			Action:    HolderAdded,
+			Actor:     arg.Actor,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Actor:        arg.Actor,
+			Action:       AuditHolderAdd,
+			ResourceType: "account_holder",
+			ResourceID:   fmt.Sprintf("%d/%s", arg.AccountID, arg.Username),
+			After:        holder,
+		})
+	})
+
+	return holder, err
+}
+
+// RemoveAccountHolderTxParams contains the input parameters of the remove holder transaction
+type RemoveAccountHolderTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	Actor     string `json:"actor"`
+}
+
+// RemoveAccountHolderTx removes a holder from an account and records who removed them.
+// The account row is locked first so two owners cannot remove each other at the same time.
+func (store *SQLStore) RemoveAccountHolderTx(ctx context.Context, arg RemoveAccountHolderTxParams) (AccountHolder, error) {
+	var holder AccountHolder
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		holder, err = q.DeleteAccountHolder(ctx, DeleteAccountHolderParams{
+			AccountID: arg.AccountID,
+			Username:  arg.Username,
+		})
+		if err != nil {
+			return err
+		}
+
+		if holder.Role == HolderOwner {
+			owners, err := q.CountAccountOwners(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+
+			if owners == 0 {
+				return ErrLastOwner
+			}
+		}
+
+		_, err = q.CreateAccountHolderEvent(ctx, CreateAccountHolderEventParams{
+			AccountID: arg.AccountID,
+			Username:  holder.Username,
+			Role:      holder.Role,
+			Action:    HolderRemoved,
+			Actor:     arg.Actor,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Actor:        arg.Actor,
+			Action:       AuditHolderRemove,
+			ResourceType: "account_holder",
+			ResourceID:   fmt.Sprintf("%d/%s", arg.AccountID, arg.Username),
+			Before:       holder,
+		})
+	})
+
+	return holder, err
+}
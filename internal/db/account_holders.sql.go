@@ -0,0 +1,198 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: account_holders.sql
+
+package db
+
+import (
+	"context"
+)
+
+const countAccountOwners = `-- name: CountAccountOwners :one
+SELECT count(*) FROM account_holders
+WHERE account_id = $1 AND role = 'owner'
+`
+
+func (q *Queries) CountAccountOwners(ctx context.Context, accountID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countAccountOwners, accountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAccountHolder = `-- name: CreateAccountHolder :one
+INSERT INTO account_holders (
+  account_id, username, role
+) VALUES (
+  $1, $2, $3
+)
+RETURNING account_id, username, role, created_at
+`
+
+type CreateAccountHolderParams struct {
+	AccountID int64
+	Username  string
+	Role      string
+}
+
+// ACCOUNT HOLDERS
+func (q *Queries) CreateAccountHolder(ctx context.Context, arg CreateAccountHolderParams) (AccountHolder, error) {
+	row := q.db.QueryRow(ctx, createAccountHolder, arg.AccountID, arg.Username, arg.Role)
+	var i AccountHolder
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createAccountHolderEvent = `-- name: CreateAccountHolderEvent :one
+INSERT INTO account_holder_events (
+  account_id, username, role, action, actor
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING id, account_id, username, role, action, actor, created_at
+`
+
+type CreateAccountHolderEventParams struct {
+	AccountID int64
+	Username  string
+	Role      string
+	Action    string
+	Actor     string
+}
+
+func (q *Queries) CreateAccountHolderEvent(ctx context.Context, arg CreateAccountHolderEventParams) (AccountHolderEvent, error) {
+	row := q.db.QueryRow(ctx, createAccountHolderEvent,
+		arg.AccountID,
+		arg.Username,
+		arg.Role,
+		arg.Action,
+		arg.Actor,
+	)
+	var i AccountHolderEvent
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.Action,
+		&i.Actor,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteAccountHolder = `-- name: DeleteAccountHolder :one
+DELETE FROM account_holders
+WHERE account_id = $1 AND username = $2
+RETURNING account_id, username, role, created_at
+`
+
+type DeleteAccountHolderParams struct {
+	AccountID int64
+	Username  string
+}
+
+func (q *Queries) DeleteAccountHolder(ctx context.Context, arg DeleteAccountHolderParams) (AccountHolder, error) {
+	row := q.db.QueryRow(ctx, deleteAccountHolder, arg.AccountID, arg.Username)
+	var i AccountHolder
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAccountHolder = `-- name: GetAccountHolder :one
+SELECT account_id, username, role, created_at FROM account_holders
+WHERE account_id = $1 AND username = $2 LIMIT 1
+`
+
+type GetAccountHolderParams struct {
+	AccountID int64
+	Username  string
+}
+
+func (q *Queries) GetAccountHolder(ctx context.Context, arg GetAccountHolderParams) (AccountHolder, error) {
+	row := q.db.QueryRow(ctx, getAccountHolder, arg.AccountID, arg.Username)
+	var i AccountHolder
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountHolderEvents = `-- name: ListAccountHolderEvents :many
+SELECT id, account_id, username, role, action, actor, created_at FROM account_holder_events
+WHERE account_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListAccountHolderEvents(ctx context.Context, accountID int64) ([]AccountHolderEvent, error) {
+	rows, err := q.db.Query(ctx, listAccountHolderEvents, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountHolderEvent{}
+	for rows.Next() {
+		var i AccountHolderEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Username,
+			&i.Role,
+			&i.Action,
+			&i.Actor,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountHolders = `-- name: ListAccountHolders :many
+SELECT account_id, username, role, created_at FROM account_holders
+WHERE account_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListAccountHolders(ctx context.Context, accountID int64) ([]AccountHolder, error) {
+	rows, err := q.db.Query(ctx, listAccountHolders, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountHolder{}
+	for rows.Next() {
+		var i AccountHolder
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Username,
+			&i.Role,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
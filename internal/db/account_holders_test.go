@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"simple_bank/util"
+)
+
+// createRandomHeldAccount creates an account owned by a registered user so holders can reference it
+func createRandomHeldAccount(t *testing.T, store Store) (Account, User) {
+	owner := createRandomUser(t)
+
+	account, err := store.CreateAccountTx(context.Background(), CreateAccountParams{
+		Owner:       owner.Username,
+		Currency:    util.RandomCurrency(),
+		CountryCode: int32(util.RandomInt(1, 6)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, owner.Username, account.Owner)
+
+	return account, owner
+}
+
+func TestCreateAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+	account, owner := createRandomHeldAccount(t, store)
+
+	holder, err := testQueries.GetAccountHolder(context.Background(), GetAccountHolderParams{
+		AccountID: account.ID,
+		Username:  owner.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HolderOwner, holder.Role)
+
+	accounts, err := testQueries.ListAccountsByHolder(context.Background(), ListAccountsByHolderParams{
+		Username: owner.Username,
+		Limit:    5,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+}
+
+func TestAddAndRemoveAccountHolderTx(t *testing.T) {
+	store := NewStore(testDB)
+	account, owner := createRandomHeldAccount(t, store)
+	signatory := createRandomUser(t)
+
+	holder, err := store.AddAccountHolderTx(context.Background(), AddAccountHolderTxParams{
+		AccountID: account.ID,
+		Username:  signatory.Username,
+		Role:      HolderSignatory,
+		Actor:     owner.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HolderSignatory, holder.Role)
+
+	// the same user cannot be added twice
+	_, err = store.AddAccountHolderTx(context.Background(), AddAccountHolderTxParams{
+		AccountID: account.ID,
+		Username:  signatory.Username,
+		Role:      HolderViewer,
+		Actor:     owner.Username,
+	})
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+
+	removed, err := store.RemoveAccountHolderTx(context.Background(), RemoveAccountHolderTxParams{
+		AccountID: account.ID,
+		Username:  signatory.Username,
+		Actor:     owner.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, signatory.Username, removed.Username)
+
+	events, err := testQueries.ListAccountHolderEvents(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, HolderAdded, events[0].Action)
+	require.Equal(t, HolderRemoved, events[1].Action)
+	for _, event := range events {
+		require.Equal(t, signatory.Username, event.Username)
+		require.Equal(t, owner.Username, event.Actor)
+	}
+}
+
+func TestRemoveLastOwner(t *testing.T) {
+	store := NewStore(testDB)
+	account, owner := createRandomHeldAccount(t, store)
+
+	_, err := store.RemoveAccountHolderTx(context.Background(), RemoveAccountHolderTxParams{
+		AccountID: account.ID,
+		Username:  owner.Username,
+		Actor:     owner.Username,
+	})
+	require.ErrorIs(t, err, ErrLastOwner)
+
+	// the rollback keeps the owner in place
+	_, err = testQueries.GetAccountHolder(context.Background(), GetAccountHolderParams{
+		AccountID: account.ID,
+		Username:  owner.Username,
+	})
+	require.NoError(t, err)
+}
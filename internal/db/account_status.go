@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Lifecycle statuses of an account.
+// A frozen account can neither send nor receive money, a debit blocked account can only receive,
+// and a closed account is kept for its history but never moves money again.
+const (
+	AccountActive       = "active"
+	AccountFrozen       = "frozen"
+	AccountDebitBlocked = "debit_blocked"
+	AccountClosed       = "closed"
+)
+
+// Who froze an account. Customers freeze and unfreeze their own accounts,
+// but a freeze by bank staff can only be lifted by staff.
+const (
+	FrozenByCustomer = "customer"
+	FrozenByStaff    = "staff"
+)
+
+var (
+	ErrFrozenByStaff        = errors.New("account was frozen by the bank, only bank staff can change its status")
+	ErrAccountCannotSend    = errors.New("account cannot send money")
+	ErrAccountCannotReceive = errors.New("account cannot receive money")
+	ErrAccountClosed        = errors.New("account is closed")
+	ErrSweepAccountRequired = errors.New("a sweep account is required to close an account with a balance")
+	ErrNegativeBalance      = errors.New("account with a negative balance cannot be closed")
+	ErrAccountCannotClose   = errors.New("frozen or debit blocked account cannot be closed")
+	ErrCurrencyMismatch     = errors.New("accounts have different currencies")
+)
+
+// CanSend reports whether money may leave an account with the given status
+func CanSend(status string) bool {
+	return status == AccountActive
+}
+
+// CanReceive reports whether money may arrive in an account with the given status
+func CanReceive(status string) bool {
+	return status == AccountActive || status == AccountDebitBlocked
+}
+
+// checkTransferAllowed returns an error wrapping ErrAccountCannotSend or ErrAccountCannotReceive
+// when either side's status forbids the transfer
+func checkTransferAllowed(fromAccount, toAccount Account) error {
+	if !CanSend(fromAccount.Status) {
+		return fmt.Errorf("account [%d] is %s: %w", fromAccount.ID, fromAccount.Status, ErrAccountCannotSend)
+	}
+
+	if !CanReceive(toAccount.Status) {
+		return fmt.Errorf("account [%d] is %s: %w", toAccount.ID, toAccount.Status, ErrAccountCannotReceive)
+	}
+
+	return nil
+}
+
+// lockAccounts locks both accounts for update, always in ascending ID order like addMoney,
+// so concurrent transactions on the same pair cannot deadlock. Accounts are returned in argument order.
+func lockAccounts(ctx context.Context, q *Queries, accountID1 int64, accountID2 int64) (account1 Account, account2 Account, err error) {
+	if accountID1 > accountID2 {
+		account2, account1, err = lockAccounts(ctx, q, accountID2, accountID1)
+		return
+	}
+
+	account1, err = q.GetAccountForUpdate(ctx, accountID1)
+	if err != nil {
+		return
+	}
+
+	account2, err = q.GetAccountForUpdate(ctx, accountID2)
+	return
+}
+
+// UpdateAccountStatusTxParams contains the input parameters of UpdateAccountStatusTx
+type UpdateAccountStatusTxParams struct {
+	ID     int64
+	Status string
+	// SetBy is FrozenByCustomer or FrozenByStaff, whoever is changing the status
+	SetBy string
+}
+
+// UpdateAccountStatusTx moves an open account to another status, recording who froze it.
+// It fails with ErrAccountClosed for closed accounts, which only CloseAccountTx may produce,
+// and with ErrFrozenByStaff when a customer tries to change the status of an account staff froze.
+func (store *SQLStore) UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (Account, error) {
+	var account Account
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetAccountForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if before.Status == AccountClosed {
+			return ErrAccountClosed
+		}
+
+		if before.Status == AccountFrozen && before.FrozenBy.String == FrozenByStaff && arg.SetBy != FrozenByStaff {
+			return ErrFrozenByStaff
+		}
+
+		account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:       arg.ID,
+			Status:   arg.Status,
+			FrozenBy: pgtype.Text{String: arg.SetBy, Valid: arg.Status == AccountFrozen},
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.enqueueEvent(ctx, "account", account.ID, EventAccountStatusChanged, account)
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Action:       AuditAccountStatus,
+			ResourceType: "account",
+			ResourceID:   account.ID,
+			Before:       before,
+			After:        account,
+		})
+	})
+
+	return account, err
+}
@@ -4,17 +4,17 @@ import (
 	"context"
 	"testing"
 	"time"
-	
-	"github.com/stretchr/testify/require"
+
 	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
 	"simple_bank/util"
 )
 
 func createRandomAccount(t *testing.T) Account {
-	arg := CreateAccountParams {
-		Owner: util.RandomOwner(),
-		Balance: util.RandomMoney(),
-		Currency: util.RandomCurrency(),
+	arg := CreateAccountParams{
+		Owner:       util.RandomOwner(),
+		Balance:     util.RandomMoney(),
+		Currency:    util.RandomCurrency(),
 		CountryCode: int32(util.RandomInt(1, 6)),
 	}
 
@@ -55,12 +55,12 @@ func TestGetAccount(t *testing.T) {
 func TestUpdateAccount(t *testing.T) {
 	account1 := createRandomAccount(t)
 
-	arg := UpdateAccountParams {
-		ID: account1.ID,
-		Balance: util.RandomMoney(),
-		Owner:        account1.Owner,
-		Currency:     account1.Currency,
-		CountryCode:  account1.CountryCode,
+	arg := UpdateAccountParams{
+		ID:          account1.ID,
+		Balance:     util.RandomMoney(),
+		Owner:       account1.Owner,
+		Currency:    account1.Currency,
+		CountryCode: account1.CountryCode,
 	}
 
 	err := testQueries.UpdateAccount(context.Background(), arg)
@@ -83,7 +83,7 @@ func TestUpdateAccount(t *testing.T) {
 
 func TestDeleteAccount(t *testing.T) {
 	account1 := createRandomAccount(t)
-	err:= testQueries.DeleteAccount(context.Background(), account1.ID)
+	err := testQueries.DeleteAccount(context.Background(), account1.ID)
 	require.NoError(t, err)
 
 	account2, err := testQueries.GetAccount(context.Background(), account1.ID)
@@ -97,8 +97,8 @@ func TestListAccounts(t *testing.T) {
 		createRandomAccount(t)
 	}
 
-	arg := ListAccountsParams {
-		Limit: 5,
+	arg := ListAccountsParams{
+		Limit:  5,
 		Offset: 5,
 	}
 
@@ -109,4 +109,153 @@ func TestListAccounts(t *testing.T) {
 	for _, account := range accounts {
 		require.NotEmpty(t, account)
 	}
-}
\ No newline at end of file
+}
+
+func TestUpdateAccountStatus(t *testing.T) {
+	account1 := createRandomAccount(t)
+	require.Equal(t, AccountActive, account1.Status)
+
+	account2, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		ID:     account1.ID,
+		Status: AccountFrozen,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountFrozen, account2.Status)
+	require.False(t, account2.ClosedAt.Valid)
+}
+
+func TestUpdateAccountStatusTxFrozenByStaff(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+
+	frozen, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		ID:     account.ID,
+		Status: AccountFrozen,
+		SetBy:  FrozenByStaff,
+	})
+	require.NoError(t, err)
+	require.Equal(t, FrozenByStaff, frozen.FrozenBy.String)
+
+	// the customer can neither lift the freeze nor loosen it to a debit block
+	for _, status := range []string{AccountActive, AccountDebitBlocked, AccountFrozen} {
+		_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+			ID:     account.ID,
+			Status: status,
+			SetBy:  FrozenByCustomer,
+		})
+		require.ErrorIs(t, err, ErrFrozenByStaff)
+	}
+
+	active, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		ID:     account.ID,
+		Status: AccountActive,
+		SetBy:  FrozenByStaff,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountActive, active.Status)
+	require.False(t, active.FrozenBy.Valid)
+}
+
+func TestDeleteAccountKeepsHistory(t *testing.T) {
+	account := createRandomAccount(t)
+	createRandomEntry(t, account)
+
+	err := testQueries.DeleteAccount(context.Background(), account.ID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "foreign")
+}
+
+func TestCloseAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createRandomAccount(t)
+	sweepAccount := createRandomAccount(t)
+	if sweepAccount.Currency != account.Currency {
+		sweepAccount.Currency = account.Currency
+		err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+			ID:          sweepAccount.ID,
+			Owner:       sweepAccount.Owner,
+			Balance:     sweepAccount.Balance,
+			Currency:    sweepAccount.Currency,
+			CountryCode: sweepAccount.CountryCode,
+		})
+		require.NoError(t, err)
+	}
+
+	// a balance cannot be left behind
+	_, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrSweepAccountRequired)
+
+	result, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:        account.ID,
+		SweepToAccountID: sweepAccount.ID,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, AccountClosed, result.Account.Status)
+	require.True(t, result.Account.ClosedAt.Valid)
+	require.Zero(t, result.Account.Balance)
+
+	require.NotNil(t, result.Sweep)
+	require.Equal(t, account.Balance, result.Sweep.Transfer.Amount)
+	require.Equal(t, sweepAccount.Balance+account.Balance, result.Sweep.ToAccount.Balance)
+
+	// the ledger history survives the closure
+	entries, err := testQueries.ListEntriesByAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrAccountClosed)
+}
+
+func TestCreateAccountAfterClosing(t *testing.T) {
+	account := createRandomAccount(t)
+	arg := CreateAccountParams{
+		Owner:       account.Owner,
+		Currency:    account.Currency,
+		CountryCode: account.CountryCode,
+	}
+
+	// an owner has one open account per currency
+	_, err := testQueries.CreateAccount(context.Background(), arg)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+
+	// closing it frees the currency
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{ID: account.ID, Status: AccountClosed})
+	require.NoError(t, err)
+
+	reopened, err := testQueries.CreateAccount(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEqual(t, account.ID, reopened.ID)
+	require.Equal(t, AccountActive, reopened.Status)
+}
+
+func TestCloseAccountTxRestricted(t *testing.T) {
+	store := NewStore(testDB)
+
+	for _, status := range []string{AccountFrozen, AccountDebitBlocked} {
+		account := createRandomAccount(t)
+		_, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{ID: account.ID, Status: status})
+		require.NoError(t, err)
+
+		_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+		require.ErrorIs(t, err, ErrAccountCannotClose)
+	}
+}
+
+func TestCountAccountsByOwner(t *testing.T) {
+	account := createRandomAccount(t)
+
+	count, err := testQueries.CountAccountsByOwner(context.Background(), account.Owner)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	// closed accounts do not count towards the tier limit
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{ID: account.ID, Status: AccountClosed})
+	require.NoError(t, err)
+
+	count, err = testQueries.CountAccountsByOwner(context.Background(), account.Owner)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
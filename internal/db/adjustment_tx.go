@@ -0,0 +1,202 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GL accounts a manual adjustment can be posted against, one of each per currency
+const (
+	GLSuspense    = "suspense"
+	GLAdjustments = "adjustments"
+)
+
+// Reason codes a manual adjustment must carry
+const (
+	AdjustmentGoodwill        = "goodwill"
+	AdjustmentFeeRefund       = "fee_refund"
+	AdjustmentErrorCorrection = "error_correction"
+	AdjustmentChargeback      = "chargeback"
+	AdjustmentWriteOff        = "write_off"
+)
+
+// Statuses a manual adjustment moves through. Only pending adjustments can be decided.
+const (
+	AdjustmentPending  = "pending_approval"
+	AdjustmentApproved = "approved"
+	AdjustmentRejected = "rejected"
+)
+
+var (
+	ErrAdjustmentOverdraws  = errors.New("adjustment would leave a negative balance")
+	ErrAdjustmentNotPending = errors.New("adjustment is not pending approval")
+	ErrSelfApproval         = errors.New("an adjustment must be decided by someone other than its requester")
+)
+
+// RequestAdjustmentTx records a manual adjustment for approval. No money moves until DecideAdjustmentTx approves it.
+func (store *SQLStore) RequestAdjustmentTx(ctx context.Context, arg CreateLedgerAdjustmentParams) (LedgerAdjustment, error) {
+	var adjustment LedgerAdjustment
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if account.Status == AccountClosed {
+			return ErrAccountClosed
+		}
+
+		adjustment, err = q.CreateLedgerAdjustment(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Actor:        arg.RequestedBy,
+			Action:       AuditAdjustmentRequest,
+			ResourceType: "ledger_adjustment",
+			ResourceID:   adjustment.ID,
+			After:        adjustment,
+		})
+	})
+
+	return adjustment, err
+}
+
+// DecideAdjustmentTxParams contains the input parameters of the adjustment decision
+type DecideAdjustmentTxParams struct {
+	AdjustmentID int64  `json:"adjustment_id"`
+	Approve      bool   `json:"approve"`
+	DecidedBy    string `json:"decided_by"`
+}
+
+// DecideAdjustmentTxResult is the result of the adjustment decision.
+// The entries and balances are only populated when the adjustment was approved.
+type DecideAdjustmentTxResult struct {
+	Adjustment LedgerAdjustment `json:"adjustment"`
+	Account    *Account         `json:"account,omitempty"`
+	Entry      *Entry           `json:"entry,omitempty"`
+	GLAccount  *GlAccount       `json:"gl_account,omitempty"`
+	GLEntry    *GlEntry         `json:"gl_entry,omitempty"`
+}
+
+// DecideAdjustmentTx approves or rejects a pending adjustment on behalf of someone other than its requester.
+// Approval posts two balanced entries, one to the customer account and the opposite one to the GL account
+// of the account's currency, in the same database transaction that marks the adjustment as decided.
+// Frozen accounts can still be adjusted, closed ones can't.
+func (store *SQLStore) DecideAdjustmentTx(ctx context.Context, arg DecideAdjustmentTxParams) (DecideAdjustmentTxResult, error) {
+	var result DecideAdjustmentTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		adjustment, err := q.GetLedgerAdjustmentForUpdate(ctx, arg.AdjustmentID)
+		if err != nil {
+			return err
+		}
+
+		if adjustment.Status != AdjustmentPending {
+			return ErrAdjustmentNotPending
+		}
+
+		if adjustment.RequestedBy == arg.DecidedBy {
+			return ErrSelfApproval
+		}
+
+		update := DecideLedgerAdjustmentParams{
+			ID:        adjustment.ID,
+			Status:    AdjustmentRejected,
+			DecidedBy: pgtype.Text{String: arg.DecidedBy, Valid: true},
+		}
+
+		if arg.Approve {
+			err = postAdjustment(ctx, q, adjustment, &result)
+			if err != nil {
+				return err
+			}
+
+			update.Status = AdjustmentApproved
+			update.EntryID = pgtype.Int8{Int64: result.Entry.ID, Valid: true}
+			update.GlEntryID = pgtype.Int8{Int64: result.GLEntry.ID, Valid: true}
+		}
+
+		result.Adjustment, err = q.DecideLedgerAdjustment(ctx, update)
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Actor:        arg.DecidedBy,
+			Action:       AuditAdjustmentDecide,
+			ResourceType: "ledger_adjustment",
+			ResourceID:   adjustment.ID,
+			Before:       adjustment,
+			After:        result,
+		})
+	})
+
+	return result, err
+}
+
+// postAdjustment moves the adjustment's amount between the customer account and its GL account
+func postAdjustment(ctx context.Context, q *Queries, adjustment LedgerAdjustment, result *DecideAdjustmentTxResult) error {
+	account, err := q.GetAccountForUpdate(ctx, adjustment.AccountID)
+	if err != nil {
+		return err
+	}
+
+	if account.Status == AccountClosed {
+		return ErrAccountClosed
+	}
+
+	if account.Balance+adjustment.Amount < 0 {
+		return ErrAdjustmentOverdraws
+	}
+
+	glAccount, err := q.GetGLAccountForUpdate(ctx, GetGLAccountForUpdateParams{
+		Code:     adjustment.GlCode,
+		Currency: account.Currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	entry, err := q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: account.ID,
+		Amount:    adjustment.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	glEntry, err := q.CreateGLEntry(ctx, CreateGLEntryParams{
+		GlAccountID: glAccount.ID,
+		Amount:      -adjustment.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	account, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		ID:     account.ID,
+		Amount: adjustment.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	glAccount, err = q.AddGLAccountBalance(ctx, AddGLAccountBalanceParams{
+		ID:     glAccount.ID,
+		Amount: -adjustment.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	result.Account = &account
+	result.Entry = &entry
+	result.GLAccount = &glAccount
+	result.GLEntry = &glEntry
+	return nil
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requestRandomAdjustment(t *testing.T, store Store, account Account, amount int64) LedgerAdjustment {
+	arg := CreateLedgerAdjustmentParams{
+		AccountID:   account.ID,
+		GlCode:      GLSuspense,
+		Amount:      amount,
+		ReasonCode:  AdjustmentErrorCorrection,
+		Note:        "double posted fee",
+		RequestedBy: "maker",
+	}
+
+	adjustment, err := store.RequestAdjustmentTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, AdjustmentPending, adjustment.Status)
+	require.Equal(t, arg.Amount, adjustment.Amount)
+	require.False(t, adjustment.EntryID.Valid)
+
+	return adjustment
+}
+
+func TestDecideAdjustmentTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+	adjustment := requestRandomAdjustment(t, store, account, -account.Balance)
+
+	// requesting moves no money
+	unchanged, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, unchanged.Balance)
+
+	_, err = store.DecideAdjustmentTx(context.Background(), DecideAdjustmentTxParams{
+		AdjustmentID: adjustment.ID,
+		Approve:      true,
+		DecidedBy:    adjustment.RequestedBy,
+	})
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	result, err := store.DecideAdjustmentTx(context.Background(), DecideAdjustmentTxParams{
+		AdjustmentID: adjustment.ID,
+		Approve:      true,
+		DecidedBy:    "checker",
+	})
+	require.NoError(t, err)
+	require.Equal(t, AdjustmentApproved, result.Adjustment.Status)
+	require.Equal(t, "checker", result.Adjustment.DecidedBy.String)
+	require.Zero(t, result.Account.Balance)
+
+	// the two entries balance
+	require.Equal(t, -account.Balance, result.Entry.Amount)
+	require.Equal(t, account.Balance, result.GLEntry.Amount)
+	require.Equal(t, GLSuspense, result.GLAccount.Code)
+	require.Equal(t, account.Currency, result.GLAccount.Currency)
+	require.Equal(t, result.Entry.ID, result.Adjustment.EntryID.Int64)
+	require.Equal(t, result.GLEntry.ID, result.Adjustment.GlEntryID.Int64)
+
+	_, err = store.DecideAdjustmentTx(context.Background(), DecideAdjustmentTxParams{
+		AdjustmentID: adjustment.ID,
+		DecidedBy:    "checker",
+	})
+	require.ErrorIs(t, err, ErrAdjustmentNotPending)
+}
+
+func TestDecideAdjustmentTxOverdraw(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+	adjustment := requestRandomAdjustment(t, store, account, -account.Balance-1)
+
+	_, err := store.DecideAdjustmentTx(context.Background(), DecideAdjustmentTxParams{
+		AdjustmentID: adjustment.ID,
+		Approve:      true,
+		DecidedBy:    "checker",
+	})
+	require.ErrorIs(t, err, ErrAdjustmentOverdraws)
+
+	// it can still be rejected
+	result, err := store.DecideAdjustmentTx(context.Background(), DecideAdjustmentTxParams{
+		AdjustmentID: adjustment.ID,
+		DecidedBy:    "checker",
+	})
+	require.NoError(t, err)
+	require.Equal(t, AdjustmentRejected, result.Adjustment.Status)
+	require.Nil(t, result.Entry)
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Actions recorded in audit_events by the store
+const (
+	AuditAccountCreate        = "account.create"
+	AuditAccountClose         = "account.close"
+	AuditAccountStatus        = "account.status"
+	AuditHolderAdd            = "account_holder.add"
+	AuditHolderRemove         = "account_holder.remove"
+	AuditTransferCreate       = "transfer.create"
+	AuditTransferReviewDecide = "transfer_review.decide"
+	AuditAdjustmentRequest    = "ledger_adjustment.request"
+	AuditAdjustmentDecide     = "ledger_adjustment.decide"
+	AuditUserVerifyEmail      = "user.verify_email"
+	AuditUserPasswordChange   = "user.password_change"
+	AuditUserPasswordReset    = "user.password_reset"
+	AuditUserTOTPEnable       = "user.totp_enable"
+	AuditUserTOTPDisable      = "user.totp_disable"
+)
+
+// AuditAnonymous is the actor recorded when a change isn't made by an authenticated user
+const AuditAnonymous = "anonymous"
+
+// AuditInfo describes who is making the changes in a request.
+// The API layer puts it in the request context so store transactions can attribute their audit rows.
+type AuditInfo struct {
+	Actor     string
+	IP        string
+	RequestID string
+}
+
+type auditInfoKey struct{}
+
+// WithAuditInfo returns a copy of ctx carrying info
+func WithAuditInfo(ctx context.Context, info *AuditInfo) context.Context {
+	return context.WithValue(ctx, auditInfoKey{}, info)
+}
+
+// AuditInfoFrom returns the audit info carried by ctx, or nil if there is none
+func AuditInfoFrom(ctx context.Context) *AuditInfo {
+	info, _ := ctx.Value(auditInfoKey{}).(*AuditInfo)
+	return info
+}
+
+// AuditRecord is a single change to be written to audit_events.
+// Before and After are marshalled to JSON and may be nil. Actor overrides the actor from the context.
+type AuditRecord struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   any
+	Before       any
+	After        any
+}
+
+// RecordAudit appends rec to audit_events, filling the actor, IP and request ID from ctx.
+// Called on the Queries of a transaction, the audit row commits or rolls back with the change.
+func (q *Queries) RecordAudit(ctx context.Context, rec AuditRecord) error {
+	arg := CreateAuditEventParams{
+		Actor:        rec.Actor,
+		Action:       rec.Action,
+		ResourceType: rec.ResourceType,
+		ResourceID:   fmt.Sprint(rec.ResourceID),
+	}
+
+	if info := AuditInfoFrom(ctx); info != nil {
+		if arg.Actor == "" {
+			arg.Actor = info.Actor
+		}
+		arg.Ip = info.IP
+		arg.RequestID = info.RequestID
+	}
+	if arg.Actor == "" {
+		arg.Actor = AuditAnonymous
+	}
+
+	var err error
+	if arg.Before, err = auditSnapshot(rec.Before); err != nil {
+		return err
+	}
+	if arg.After, err = auditSnapshot(rec.After); err != nil {
+		return err
+	}
+
+	_, err = q.CreateAuditEvent(ctx, arg)
+	return err
+}
+
+func auditSnapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal audit snapshot: %w", err)
+	}
+
+	return data, nil
+}
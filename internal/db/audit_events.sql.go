@@ -0,0 +1,116 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: audit_events.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createAuditEvent = `-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+  actor, action, resource_type, resource_id, before, after, ip, request_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, actor, action, resource_type, resource_id, before, after, ip, request_id, created_at
+`
+
+type CreateAuditEventParams struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Before       json.RawMessage
+	After        json.RawMessage
+	Ip           string
+	RequestID    string
+}
+
+// AUDIT EVENTS
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error) {
+	row := q.db.QueryRow(ctx, createAuditEvent,
+		arg.Actor,
+		arg.Action,
+		arg.ResourceType,
+		arg.ResourceID,
+		arg.Before,
+		arg.After,
+		arg.Ip,
+		arg.RequestID,
+	)
+	var i AuditEvent
+	err := row.Scan(
+		&i.ID,
+		&i.Actor,
+		&i.Action,
+		&i.ResourceType,
+		&i.ResourceID,
+		&i.Before,
+		&i.After,
+		&i.Ip,
+		&i.RequestID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT id, actor, action, resource_type, resource_id, before, after, ip, request_id, created_at FROM audit_events
+WHERE ($1::varchar IS NULL OR actor = $1)
+  AND ($2::varchar IS NULL OR resource_type = $2)
+  AND ($3::varchar IS NULL OR resource_id = $3)
+ORDER BY id DESC
+LIMIT $5
+OFFSET $4
+`
+
+type ListAuditEventsParams struct {
+	Actor        pgtype.Text
+	ResourceType pgtype.Text
+	ResourceID   pgtype.Text
+	Offset       int32
+	Limit        int32
+}
+
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, listAuditEvents,
+		arg.Actor,
+		arg.ResourceType,
+		arg.ResourceID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditEvent{}
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.Before,
+			&i.After,
+			&i.Ip,
+			&i.RequestID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
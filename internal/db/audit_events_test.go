@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"simple_bank/util"
+)
+
+func TestRecordAudit(t *testing.T) {
+	account := createRandomAccount(t)
+	actor := util.RandomOwner()
+
+	ctx := WithAuditInfo(context.Background(), &AuditInfo{
+		Actor:     actor,
+		IP:        "127.0.0.1",
+		RequestID: util.RandomString(12),
+	})
+
+	err := testQueries.RecordAudit(ctx, AuditRecord{
+		Action:       AuditAccountCreate,
+		ResourceType: "account",
+		ResourceID:   account.ID,
+		After:        account,
+	})
+	require.NoError(t, err)
+
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Actor:  pgtype.Text{String: actor, Valid: true},
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	event := events[0]
+	require.Equal(t, AuditAccountCreate, event.Action)
+	require.Equal(t, "127.0.0.1", event.Ip)
+	require.Nil(t, event.Before)
+	require.NotEmpty(t, event.After)
+
+	// the log is append-only
+	_, err = testDB.Exec(context.Background(), "DELETE FROM audit_events WHERE id = $1", event.ID)
+	require.Error(t, err)
+
+	// rolled back, so a missing trigger can't wipe the log of the test database
+	tx, err := testDB.Begin(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback(context.Background())
+	_, err = tx.Exec(context.Background(), "TRUNCATE audit_events")
+	require.Error(t, err)
+}
+
+func TestTransferTxWritesAudit(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		ResourceType: pgtype.Text{String: "transfer", Valid: true},
+		ResourceID:   pgtype.Text{String: strconv.FormatInt(result.Transfer.ID, 10), Valid: true},
+		Limit:        5,
+		Offset:       0,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, AuditAnonymous, events[0].Actor)
+}
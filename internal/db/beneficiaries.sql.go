@@ -0,0 +1,207 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: beneficiaries.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createBeneficiary = `-- name: CreateBeneficiary :one
+INSERT INTO beneficiaries (
+  username, nickname, account_id
+) VALUES (
+  $1, $2, $3
+)
+RETURNING id, username, nickname, account_id, verified, created_at
+`
+
+type CreateBeneficiaryParams struct {
+	Username  string
+	Nickname  string
+	AccountID int64
+}
+
+// BENEFICIARIES
+func (q *Queries) CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error) {
+	row := q.db.QueryRow(ctx, createBeneficiary, arg.Username, arg.Nickname, arg.AccountID)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Verified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteBeneficiary = `-- name: DeleteBeneficiary :one
+DELETE FROM beneficiaries
+WHERE id = $1 AND username = $2
+RETURNING id, username, nickname, account_id, verified, created_at
+`
+
+type DeleteBeneficiaryParams struct {
+	ID       int64
+	Username string
+}
+
+func (q *Queries) DeleteBeneficiary(ctx context.Context, arg DeleteBeneficiaryParams) (Beneficiary, error) {
+	row := q.db.QueryRow(ctx, deleteBeneficiary, arg.ID, arg.Username)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Verified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getBeneficiary = `-- name: GetBeneficiary :one
+SELECT id, username, nickname, account_id, verified, created_at FROM beneficiaries
+WHERE id = $1 AND username = $2 LIMIT 1
+`
+
+type GetBeneficiaryParams struct {
+	ID       int64
+	Username string
+}
+
+func (q *Queries) GetBeneficiary(ctx context.Context, arg GetBeneficiaryParams) (Beneficiary, error) {
+	row := q.db.QueryRow(ctx, getBeneficiary, arg.ID, arg.Username)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Verified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getBeneficiaryByAccount = `-- name: GetBeneficiaryByAccount :one
+SELECT id, username, nickname, account_id, verified, created_at FROM beneficiaries
+WHERE username = $1 AND account_id = $2 LIMIT 1
+`
+
+type GetBeneficiaryByAccountParams struct {
+	Username  string
+	AccountID int64
+}
+
+func (q *Queries) GetBeneficiaryByAccount(ctx context.Context, arg GetBeneficiaryByAccountParams) (Beneficiary, error) {
+	row := q.db.QueryRow(ctx, getBeneficiaryByAccount, arg.Username, arg.AccountID)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Verified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listBeneficiaries = `-- name: ListBeneficiaries :many
+SELECT id, username, nickname, account_id, verified, created_at FROM beneficiaries
+WHERE username = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3
+`
+
+type ListBeneficiariesParams struct {
+	Username string
+	Limit    int32
+	Offset   int32
+}
+
+func (q *Queries) ListBeneficiaries(ctx context.Context, arg ListBeneficiariesParams) ([]Beneficiary, error) {
+	rows, err := q.db.Query(ctx, listBeneficiaries, arg.Username, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Beneficiary{}
+	for rows.Next() {
+		var i Beneficiary
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Nickname,
+			&i.AccountID,
+			&i.Verified,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateBeneficiaryNickname = `-- name: UpdateBeneficiaryNickname :one
+UPDATE beneficiaries
+SET nickname = $3
+WHERE id = $1 AND username = $2
+RETURNING id, username, nickname, account_id, verified, created_at
+`
+
+type UpdateBeneficiaryNicknameParams struct {
+	ID       int64
+	Username string
+	Nickname string
+}
+
+func (q *Queries) UpdateBeneficiaryNickname(ctx context.Context, arg UpdateBeneficiaryNicknameParams) (Beneficiary, error) {
+	row := q.db.QueryRow(ctx, updateBeneficiaryNickname, arg.ID, arg.Username, arg.Nickname)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Verified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const verifyBeneficiary = `-- name: VerifyBeneficiary :one
+UPDATE beneficiaries
+SET verified = true
+WHERE id = $1 AND username = $2
+RETURNING id, username, nickname, account_id, verified, created_at
+`
+
+type VerifyBeneficiaryParams struct {
+	ID       int64
+	Username string
+}
+
+func (q *Queries) VerifyBeneficiary(ctx context.Context, arg VerifyBeneficiaryParams) (Beneficiary, error) {
+	row := q.db.QueryRow(ctx, verifyBeneficiary, arg.ID, arg.Username)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Verified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
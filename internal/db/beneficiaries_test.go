@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"simple_bank/util"
+)
+
+func createRandomBeneficiary(t *testing.T, user User) Beneficiary {
+	account := createRandomAccount(t)
+
+	arg := CreateBeneficiaryParams{
+		Username:  user.Username,
+		Nickname:  util.RandomOwner(),
+		AccountID: account.ID,
+	}
+
+	beneficiary, err := testQueries.CreateBeneficiary(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Username, beneficiary.Username)
+	require.Equal(t, arg.Nickname, beneficiary.Nickname)
+	require.Equal(t, arg.AccountID, beneficiary.AccountID)
+	require.False(t, beneficiary.Verified)
+	require.NotZero(t, beneficiary.ID)
+
+	return beneficiary
+}
+
+func TestCreateBeneficiary(t *testing.T) {
+	createRandomBeneficiary(t, createRandomUser(t))
+}
+
+func TestCreateDuplicateBeneficiary(t *testing.T) {
+	beneficiary := createRandomBeneficiary(t, createRandomUser(t))
+
+	_, err := testQueries.CreateBeneficiary(context.Background(), CreateBeneficiaryParams{
+		Username:  beneficiary.Username,
+		Nickname:  util.RandomOwner(),
+		AccountID: beneficiary.AccountID,
+	})
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+}
+
+func TestGetBeneficiaryScopedToUser(t *testing.T) {
+	beneficiary := createRandomBeneficiary(t, createRandomUser(t))
+	other := createRandomUser(t)
+
+	found, err := testQueries.GetBeneficiary(context.Background(), GetBeneficiaryParams{
+		ID:       beneficiary.ID,
+		Username: beneficiary.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, beneficiary, found)
+
+	_, err = testQueries.GetBeneficiary(context.Background(), GetBeneficiaryParams{
+		ID:       beneficiary.ID,
+		Username: other.Username,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestVerifyBeneficiary(t *testing.T) {
+	beneficiary := createRandomBeneficiary(t, createRandomUser(t))
+
+	verified, err := testQueries.VerifyBeneficiary(context.Background(), VerifyBeneficiaryParams{
+		ID:       beneficiary.ID,
+		Username: beneficiary.Username,
+	})
+	require.NoError(t, err)
+	require.True(t, verified.Verified)
+
+	found, err := testQueries.GetBeneficiaryByAccount(context.Background(), GetBeneficiaryByAccountParams{
+		Username:  beneficiary.Username,
+		AccountID: beneficiary.AccountID,
+	})
+	require.NoError(t, err)
+	require.True(t, found.Verified)
+}
+
+func TestListAndDeleteBeneficiaries(t *testing.T) {
+	user := createRandomUser(t)
+	for i := 0; i < 3; i++ {
+		createRandomBeneficiary(t, user)
+	}
+
+	beneficiaries, err := testQueries.ListBeneficiaries(context.Background(), ListBeneficiariesParams{
+		Username: user.Username,
+		Limit:    5,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Len(t, beneficiaries, 3)
+
+	deleted, err := testQueries.DeleteBeneficiary(context.Background(), DeleteBeneficiaryParams{
+		ID:       beneficiaries[0].ID,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, beneficiaries[0].ID, deleted.ID)
+
+	_, err = testQueries.GetBeneficiary(context.Background(), GetBeneficiaryParams{
+		ID:       deleted.ID,
+		Username: user.Username,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
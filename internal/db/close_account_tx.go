@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// CloseAccountTxParams contains the input parameters of the close account transaction.
+// SweepToAccountID receives the remaining balance and may be zero when the balance is already zero.
+type CloseAccountTxParams struct {
+	AccountID        int64 `json:"account_id"`
+	SweepToAccountID int64 `json:"sweep_to_account_id"`
+}
+
+// CloseAccountTxResult is the result of the close account transaction.
+// Sweep is only populated when a balance had to be moved out.
+type CloseAccountTxResult struct {
+	Account Account           `json:"account"`
+	Sweep   *TransferTxResult `json:"sweep,omitempty"`
+}
+
+// CloseAccountTx sweeps the remaining balance of an account to another account and marks it closed.
+// Only active accounts can be closed. Entries and transfers are left in place, so the account's history survives the closure.
+func (store *SQLStore) CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error) {
+	var result CloseAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var account, sweepAccount Account
+		var err error
+
+		if arg.SweepToAccountID == 0 {
+			account, err = q.GetAccountForUpdate(ctx, arg.AccountID)
+		} else {
+			account, sweepAccount, err = lockAccounts(ctx, q, arg.AccountID, arg.SweepToAccountID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if account.Status == AccountClosed {
+			return ErrAccountClosed
+		}
+
+		// closing would let the balance out of an account that may not send, so the restriction is lifted first
+		if !CanSend(account.Status) {
+			return fmt.Errorf("account [%d] is %s: %w", account.ID, account.Status, ErrAccountCannotClose)
+		}
+
+		if account.Balance < 0 {
+			return ErrNegativeBalance
+		}
+
+		if account.Balance > 0 {
+			if arg.SweepToAccountID == 0 {
+				return ErrSweepAccountRequired
+			}
+
+			if sweepAccount.Currency != account.Currency {
+				return fmt.Errorf("account [%d] is in %s, not %s: %w", sweepAccount.ID, sweepAccount.Currency, account.Currency, ErrCurrencyMismatch)
+			}
+
+			err = checkTransferAllowed(account, sweepAccount)
+			if err != nil {
+				return err
+			}
+
+			sweep, err := postTransfer(ctx, q, TransferTxParams{
+				FromAccountID: account.ID,
+				ToAccountID:   sweepAccount.ID,
+				Amount:        account.Balance,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = q.enqueueEvent(ctx, "transfer", sweep.Transfer.ID, EventTransferCreated, sweep)
+			if err != nil {
+				return err
+			}
+
+			result.Sweep = &sweep
+		}
+
+		result.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:     account.ID,
+			Status: AccountClosed,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.enqueueEvent(ctx, "account", account.ID, EventAccountClosed, result.Account)
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Action:       AuditAccountClose,
+			ResourceType: "account",
+			ResourceID:   account.ID,
+			Before:       account,
+			After:        result,
+		})
+	})
+
+	return result, err
+}
@@ -7,6 +7,8 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createEntry = `-- name: CreateEntry :one
@@ -63,6 +65,45 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 	return i, err
 }
 
+const listEntries = `-- name: ListEntries :many
+SELECT id, account_id, amount, created_at FROM entries
+WHERE ($1::bigint IS NULL OR account_id = $1)
+ORDER BY id DESC
+LIMIT $3
+OFFSET $2
+`
+
+type ListEntriesParams struct {
+	AccountID pgtype.Int8
+	Offset    int32
+	Limit     int32
+}
+
+func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
+	rows, err := q.db.Query(ctx, listEntries, arg.AccountID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEntriesByAccount = `-- name: ListEntriesByAccount :many
 SELECT id, account_id, amount, created_at FROM entries
 WHERE account_id = $1
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrRecordNotFound is returned by :one queries that match no row
+var ErrRecordNotFound = pgx.ErrNoRows
+
+// Postgres error codes the API reacts to
+const (
+	ForeignKeyViolation = "23503"
+	UniqueViolation     = "23505"
+	CheckViolation      = "23514"
+	UndefinedTable      = "42P01"
+)
+
+// ErrorCode returns the Postgres error code of err, or an empty string if err did not come from Postgres
+func ErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+
+	return ""
+}
@@ -0,0 +1,19 @@
+package db
+
+import "context"
+
+// Ping checks a connection to the database can be acquired and used
+func (store *SQLStore) Ping(ctx context.Context) error {
+	return store.db.Ping(ctx)
+}
+
+// MigrationVersion returns the version of the last migration applied to the database,
+// and whether it failed halfway and left the schema dirty.
+// It returns ErrRecordNotFound when none was ever applied, schema_migrations existing or not.
+func (store *SQLStore) MigrationVersion(ctx context.Context) (version int64, dirty bool, err error) {
+	err = store.db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if ErrorCode(err) == UndefinedTable {
+		return 0, false, ErrRecordNotFound
+	}
+	return version, dirty, err
+}
@@ -0,0 +1,21 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationVersion(t *testing.T) {
+	store := NewStore(testDB)
+
+	// the test database is built by the migrations, up to the newest one
+	latest, err := LatestMigration()
+	require.NoError(t, err)
+
+	version, dirty, err := store.MigrationVersion(context.Background())
+	require.NoError(t, err)
+	require.False(t, dirty)
+	require.Equal(t, latest, version)
+}
@@ -0,0 +1,260 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: jobs.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimJobs = `-- name: ClaimJobs :many
+UPDATE jobs
+SET status = 'running',
+    attempts = attempts + 1,
+    locked_at = now()
+WHERE id IN (
+  SELECT id FROM jobs AS pending
+  WHERE pending.status = 'pending'
+    AND pending.run_at <= now()
+    AND pending.kind = ANY($1::varchar[])
+  ORDER BY pending.run_at, pending.id
+  LIMIT $2
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, kind, payload, status, unique_key, attempts, max_attempts, run_at, locked_at, last_error, finished_at, created_at
+`
+
+type ClaimJobsParams struct {
+	Kinds []string
+	Limit int32
+}
+
+func (q *Queries) ClaimJobs(ctx context.Context, arg ClaimJobsParams) ([]Job, error) {
+	rows, err := q.db.Query(ctx, claimJobs, arg.Kinds, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Job{}
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Payload,
+			&i.Status,
+			&i.UniqueKey,
+			&i.Attempts,
+			&i.MaxAttempts,
+			&i.RunAt,
+			&i.LockedAt,
+			&i.LastError,
+			&i.FinishedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const completeJob = `-- name: CompleteJob :exec
+UPDATE jobs
+SET status = 'succeeded',
+    locked_at = NULL,
+    finished_at = now()
+WHERE id = $1
+`
+
+func (q *Queries) CompleteJob(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, completeJob, id)
+	return err
+}
+
+const enqueueJob = `-- name: EnqueueJob :one
+INSERT INTO jobs (
+  kind, payload, unique_key, max_attempts, run_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (unique_key) WHERE status IN ('pending', 'running') DO NOTHING
+RETURNING id, kind, payload, status, unique_key, attempts, max_attempts, run_at, locked_at, last_error, finished_at, created_at
+`
+
+type EnqueueJobParams struct {
+	Kind        string
+	Payload     json.RawMessage
+	UniqueKey   pgtype.Text
+	MaxAttempts int32
+	RunAt       pgtype.Timestamptz
+}
+
+// JOBS
+func (q *Queries) EnqueueJob(ctx context.Context, arg EnqueueJobParams) (Job, error) {
+	row := q.db.QueryRow(ctx, enqueueJob,
+		arg.Kind,
+		arg.Payload,
+		arg.UniqueKey,
+		arg.MaxAttempts,
+		arg.RunAt,
+	)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Payload,
+		&i.Status,
+		&i.UniqueKey,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LockedAt,
+		&i.LastError,
+		&i.FinishedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const failJob = `-- name: FailJob :exec
+UPDATE jobs
+SET status = 'failed',
+    locked_at = NULL,
+    last_error = $1,
+    finished_at = now()
+WHERE id = $2
+`
+
+type FailJobParams struct {
+	LastError pgtype.Text
+	ID        int64
+}
+
+func (q *Queries) FailJob(ctx context.Context, arg FailJobParams) error {
+	_, err := q.db.Exec(ctx, failJob, arg.LastError, arg.ID)
+	return err
+}
+
+const getJob = `-- name: GetJob :one
+SELECT id, kind, payload, status, unique_key, attempts, max_attempts, run_at, locked_at, last_error, finished_at, created_at FROM jobs
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetJob(ctx context.Context, id int64) (Job, error) {
+	row := q.db.QueryRow(ctx, getJob, id)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Payload,
+		&i.Status,
+		&i.UniqueKey,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LockedAt,
+		&i.LastError,
+		&i.FinishedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listJobs = `-- name: ListJobs :many
+SELECT id, kind, payload, status, unique_key, attempts, max_attempts, run_at, locked_at, last_error, finished_at, created_at FROM jobs
+WHERE ($1::varchar IS NULL OR status = $1)
+  AND ($2::varchar IS NULL OR kind = $2)
+ORDER BY id DESC
+LIMIT $4
+OFFSET $3
+`
+
+type ListJobsParams struct {
+	Status pgtype.Text
+	Kind   pgtype.Text
+	Offset int32
+	Limit  int32
+}
+
+func (q *Queries) ListJobs(ctx context.Context, arg ListJobsParams) ([]Job, error) {
+	rows, err := q.db.Query(ctx, listJobs,
+		arg.Status,
+		arg.Kind,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Job{}
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Payload,
+			&i.Status,
+			&i.UniqueKey,
+			&i.Attempts,
+			&i.MaxAttempts,
+			&i.RunAt,
+			&i.LockedAt,
+			&i.LastError,
+			&i.FinishedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const requeueStaleJobs = `-- name: RequeueStaleJobs :execrows
+UPDATE jobs
+SET status = 'pending',
+    locked_at = NULL
+WHERE status = 'running'
+  AND locked_at < $1
+`
+
+func (q *Queries) RequeueStaleJobs(ctx context.Context, lockedBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueStaleJobs, lockedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const retryJob = `-- name: RetryJob :exec
+UPDATE jobs
+SET status = 'pending',
+    locked_at = NULL,
+    run_at = $1,
+    last_error = $2
+WHERE id = $3
+`
+
+type RetryJobParams struct {
+	RunAt     pgtype.Timestamptz
+	LastError pgtype.Text
+	ID        int64
+}
+
+func (q *Queries) RetryJob(ctx context.Context, arg RetryJobParams) error {
+	_, err := q.db.Exec(ctx, retryJob, arg.RunAt, arg.LastError, arg.ID)
+	return err
+}
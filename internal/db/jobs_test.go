@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"simple_bank/util"
+)
+
+func enqueueRandomJob(t *testing.T, kind string, runAt time.Time) Job {
+	job, err := testQueries.EnqueueJob(context.Background(), EnqueueJobParams{
+		Kind:        kind,
+		Payload:     json.RawMessage(`{}`),
+		MaxAttempts: 3,
+		RunAt:       pgtype.Timestamptz{Time: runAt, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending", job.Status)
+
+	return job
+}
+
+func TestClaimJobs(t *testing.T) {
+	kind := "test_" + util.RandomString(8)
+	due := enqueueRandomJob(t, kind, time.Now().Add(-time.Second))
+	enqueueRandomJob(t, kind, time.Now().Add(time.Hour))
+
+	claimed, err := testQueries.ClaimJobs(context.Background(), ClaimJobsParams{
+		Kinds: []string{kind},
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, due.ID, claimed[0].ID)
+	require.Equal(t, "running", claimed[0].Status)
+	require.Equal(t, int32(1), claimed[0].Attempts)
+
+	// a claimed job is not handed out twice
+	claimed, err = testQueries.ClaimJobs(context.Background(), ClaimJobsParams{
+		Kinds: []string{kind},
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	require.NoError(t, testQueries.CompleteJob(context.Background(), due.ID))
+	job, err := testQueries.GetJob(context.Background(), due.ID)
+	require.NoError(t, err)
+	require.Equal(t, "succeeded", job.Status)
+	require.True(t, job.FinishedAt.Valid)
+}
+
+func TestEnqueueJobUniqueKey(t *testing.T) {
+	arg := EnqueueJobParams{
+		Kind:        "test_" + util.RandomString(8),
+		Payload:     json.RawMessage(`{}`),
+		UniqueKey:   pgtype.Text{String: util.RandomString(12), Valid: true},
+		MaxAttempts: 3,
+		RunAt:       pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+
+	first, err := testQueries.EnqueueJob(context.Background(), arg)
+	require.NoError(t, err)
+
+	_, err = testQueries.EnqueueJob(context.Background(), arg)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	// once the first job is done the key is free again
+	require.NoError(t, testQueries.CompleteJob(context.Background(), first.ID))
+	_, err = testQueries.EnqueueJob(context.Background(), arg)
+	require.NoError(t, err)
+}
@@ -0,0 +1,319 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: ledger_adjustments.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addGLAccountBalance = `-- name: AddGLAccountBalance :one
+UPDATE gl_accounts
+SET balance = balance + $1
+WHERE id = $2
+RETURNING id, code, currency, balance, created_at
+`
+
+type AddGLAccountBalanceParams struct {
+	Amount int64
+	ID     int64
+}
+
+func (q *Queries) AddGLAccountBalance(ctx context.Context, arg AddGLAccountBalanceParams) (GlAccount, error) {
+	row := q.db.QueryRow(ctx, addGLAccountBalance, arg.Amount, arg.ID)
+	var i GlAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Currency,
+		&i.Balance,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createGLEntry = `-- name: CreateGLEntry :one
+INSERT INTO gl_entries (
+  gl_account_id, amount
+) VALUES (
+  $1, $2
+)
+RETURNING id, gl_account_id, amount, created_at
+`
+
+type CreateGLEntryParams struct {
+	GlAccountID int64
+	Amount      int64
+}
+
+func (q *Queries) CreateGLEntry(ctx context.Context, arg CreateGLEntryParams) (GlEntry, error) {
+	row := q.db.QueryRow(ctx, createGLEntry, arg.GlAccountID, arg.Amount)
+	var i GlEntry
+	err := row.Scan(
+		&i.ID,
+		&i.GlAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createLedgerAdjustment = `-- name: CreateLedgerAdjustment :one
+INSERT INTO ledger_adjustments (
+  account_id, gl_code, amount, reason_code, note, requested_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING id, account_id, gl_code, amount, reason_code, note, status, requested_by, decided_by, decided_at, entry_id, gl_entry_id, created_at
+`
+
+type CreateLedgerAdjustmentParams struct {
+	AccountID   int64
+	GlCode      string
+	Amount      int64
+	ReasonCode  string
+	Note        string
+	RequestedBy string
+}
+
+func (q *Queries) CreateLedgerAdjustment(ctx context.Context, arg CreateLedgerAdjustmentParams) (LedgerAdjustment, error) {
+	row := q.db.QueryRow(ctx, createLedgerAdjustment,
+		arg.AccountID,
+		arg.GlCode,
+		arg.Amount,
+		arg.ReasonCode,
+		arg.Note,
+		arg.RequestedBy,
+	)
+	var i LedgerAdjustment
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.GlCode,
+		&i.Amount,
+		&i.ReasonCode,
+		&i.Note,
+		&i.Status,
+		&i.RequestedBy,
+		&i.DecidedBy,
+		&i.DecidedAt,
+		&i.EntryID,
+		&i.GlEntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const decideLedgerAdjustment = `-- name: DecideLedgerAdjustment :one
+UPDATE ledger_adjustments
+SET status = $2,
+    decided_by = $3,
+    entry_id = $4,
+    gl_entry_id = $5,
+    decided_at = now()
+WHERE id = $1
+RETURNING id, account_id, gl_code, amount, reason_code, note, status, requested_by, decided_by, decided_at, entry_id, gl_entry_id, created_at
+`
+
+type DecideLedgerAdjustmentParams struct {
+	ID        int64
+	Status    string
+	DecidedBy pgtype.Text
+	EntryID   pgtype.Int8
+	GlEntryID pgtype.Int8
+}
+
+func (q *Queries) DecideLedgerAdjustment(ctx context.Context, arg DecideLedgerAdjustmentParams) (LedgerAdjustment, error) {
+	row := q.db.QueryRow(ctx, decideLedgerAdjustment,
+		arg.ID,
+		arg.Status,
+		arg.DecidedBy,
+		arg.EntryID,
+		arg.GlEntryID,
+	)
+	var i LedgerAdjustment
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.GlCode,
+		&i.Amount,
+		&i.ReasonCode,
+		&i.Note,
+		&i.Status,
+		&i.RequestedBy,
+		&i.DecidedBy,
+		&i.DecidedAt,
+		&i.EntryID,
+		&i.GlEntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGLAccountForUpdate = `-- name: GetGLAccountForUpdate :one
+SELECT id, code, currency, balance, created_at FROM gl_accounts
+WHERE code = $1 AND currency = $2 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+type GetGLAccountForUpdateParams struct {
+	Code     string
+	Currency string
+}
+
+func (q *Queries) GetGLAccountForUpdate(ctx context.Context, arg GetGLAccountForUpdateParams) (GlAccount, error) {
+	row := q.db.QueryRow(ctx, getGLAccountForUpdate, arg.Code, arg.Currency)
+	var i GlAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Currency,
+		&i.Balance,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLedgerAdjustment = `-- name: GetLedgerAdjustment :one
+SELECT id, account_id, gl_code, amount, reason_code, note, status, requested_by, decided_by, decided_at, entry_id, gl_entry_id, created_at FROM ledger_adjustments
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetLedgerAdjustment(ctx context.Context, id int64) (LedgerAdjustment, error) {
+	row := q.db.QueryRow(ctx, getLedgerAdjustment, id)
+	var i LedgerAdjustment
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.GlCode,
+		&i.Amount,
+		&i.ReasonCode,
+		&i.Note,
+		&i.Status,
+		&i.RequestedBy,
+		&i.DecidedBy,
+		&i.DecidedAt,
+		&i.EntryID,
+		&i.GlEntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLedgerAdjustmentForUpdate = `-- name: GetLedgerAdjustmentForUpdate :one
+SELECT id, account_id, gl_code, amount, reason_code, note, status, requested_by, decided_by, decided_at, entry_id, gl_entry_id, created_at FROM ledger_adjustments
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetLedgerAdjustmentForUpdate(ctx context.Context, id int64) (LedgerAdjustment, error) {
+	row := q.db.QueryRow(ctx, getLedgerAdjustmentForUpdate, id)
+	var i LedgerAdjustment
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.GlCode,
+		&i.Amount,
+		&i.ReasonCode,
+		&i.Note,
+		&i.Status,
+		&i.RequestedBy,
+		&i.DecidedBy,
+		&i.DecidedAt,
+		&i.EntryID,
+		&i.GlEntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listGLAccounts = `-- name: ListGLAccounts :many
+SELECT id, code, currency, balance, created_at FROM gl_accounts
+ORDER BY code, currency
+`
+
+// LEDGER ADJUSTMENTS
+func (q *Queries) ListGLAccounts(ctx context.Context) ([]GlAccount, error) {
+	rows, err := q.db.Query(ctx, listGLAccounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GlAccount{}
+	for rows.Next() {
+		var i GlAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Currency,
+			&i.Balance,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLedgerAdjustments = `-- name: ListLedgerAdjustments :many
+SELECT id, account_id, gl_code, amount, reason_code, note, status, requested_by, decided_by, decided_at, entry_id, gl_entry_id, created_at FROM ledger_adjustments
+WHERE ($1::varchar IS NULL OR status = $1)
+  AND ($2::bigint IS NULL OR account_id = $2)
+ORDER BY id DESC
+LIMIT $4
+OFFSET $3
+`
+
+type ListLedgerAdjustmentsParams struct {
+	Status    pgtype.Text
+	AccountID pgtype.Int8
+	Offset    int32
+	Limit     int32
+}
+
+func (q *Queries) ListLedgerAdjustments(ctx context.Context, arg ListLedgerAdjustmentsParams) ([]LedgerAdjustment, error) {
+	rows, err := q.db.Query(ctx, listLedgerAdjustments,
+		arg.Status,
+		arg.AccountID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LedgerAdjustment{}
+	for rows.Next() {
+		var i LedgerAdjustment
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.GlCode,
+			&i.Amount,
+			&i.ReasonCode,
+			&i.Note,
+			&i.Status,
+			&i.RequestedBy,
+			&i.DecidedBy,
+			&i.DecidedAt,
+			&i.EntryID,
+			&i.GlEntryID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -7,6 +7,8 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createMerchant = `-- name: CreateMerchant :one
@@ -66,6 +68,33 @@ func (q *Queries) GetMerchant(ctx context.Context, id int64) (Merchant, error) {
 	return i, err
 }
 
+const listMerchantIDsByOrder = `-- name: ListMerchantIDsByOrder :many
+SELECT DISTINCT products.merchant_id FROM order_items
+JOIN products ON products.id = order_items.product_id
+WHERE order_items.order_id = $1
+ORDER BY products.merchant_id
+`
+
+func (q *Queries) ListMerchantIDsByOrder(ctx context.Context, orderID pgtype.Int4) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listMerchantIDsByOrder, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var merchant_id int32
+		if err := rows.Scan(&merchant_id); err != nil {
+			return nil, err
+		}
+		items = append(items, merchant_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listMerchants = `-- name: ListMerchants :many
 SELECT id, merchant_name, country_code, created_at, admin_id FROM merchants
 ORDER BY merchant_name
@@ -97,6 +126,38 @@ func (q *Queries) ListMerchants(ctx context.Context) ([]Merchant, error) {
 	return items, nil
 }
 
+const listMerchantsByAdminAccount = `-- name: ListMerchantsByAdminAccount :many
+SELECT id, merchant_name, country_code, created_at, admin_id FROM merchants
+WHERE admin_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListMerchantsByAdminAccount(ctx context.Context, adminID int32) ([]Merchant, error) {
+	rows, err := q.db.Query(ctx, listMerchantsByAdminAccount, adminID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Merchant{}
+	for rows.Next() {
+		var i Merchant
+		if err := rows.Scan(
+			&i.ID,
+			&i.MerchantName,
+			&i.CountryCode,
+			&i.CreatedAt,
+			&i.AdminID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateMerchant = `-- name: UpdateMerchant :exec
 UPDATE merchants
 SET merchant_name = $2,
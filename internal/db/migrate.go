@@ -0,0 +1,60 @@
+package db
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+	pgxmigrate "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// migrationFiles are the up and down migrations shipped with this build
+//
+//go:embed migration/*.sql
+var migrationFiles embed.FS
+
+// NewMigrator creates a golang-migrate migrator for the migrations shipped with this build, run through pool.
+// They are tracked in schema_migrations, so the migrate CLI works on the same database.
+func NewMigrator(pool *pgxpool.Pool) (*migrate.Migrate, error) {
+	source, err := iofs.New(migrationFiles, "migration")
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := pgxmigrate.WithInstance(stdlib.OpenDBFromPool(pool), &pgxmigrate.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.NewWithInstance("iofs", source, "pgx5", driver)
+}
+
+// LatestMigration returns the version of the newest migration shipped with this build,
+// the version a database this build can serve must be at
+func LatestMigration() (int64, error) {
+	source, err := iofs.New(migrationFiles, "migration")
+	if err != nil {
+		return 0, err
+	}
+	defer source.Close()
+
+	latest, err := source.First()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		next, err := source.Next(latest)
+		if errors.Is(err, fs.ErrNotExist) {
+			return int64(latest), nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		latest = next
+	}
+}
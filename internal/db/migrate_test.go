@@ -0,0 +1,40 @@
+package db
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestMigration(t *testing.T) {
+	entries, err := migrationFiles.ReadDir("migration")
+	require.NoError(t, err)
+
+	// every migration can be reverted, and the newest file is the version readiness expects
+	ups := map[string]bool{}
+	downs := map[string]bool{}
+	var newest int64
+	for _, entry := range entries {
+		name := entry.Name()
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			ups[strings.TrimSuffix(name, ".up.sql")] = true
+		case strings.HasSuffix(name, ".down.sql"):
+			downs[strings.TrimSuffix(name, ".down.sql")] = true
+		default:
+			t.Fatalf("unexpected file %s", path.Join("migration", name))
+		}
+
+		version, err := strconv.ParseInt(strings.SplitN(name, "_", 2)[0], 10, 64)
+		require.NoError(t, err, name)
+		newest = max(newest, version)
+	}
+	require.Equal(t, ups, downs)
+
+	latest, err := LatestMigration()
+	require.NoError(t, err)
+	require.Equal(t, newest, latest)
+}
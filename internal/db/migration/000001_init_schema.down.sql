@@ -17,7 +17,6 @@ DROP CONSTRAINT IF EXISTS "transfers_from_account_id_fkey";
 ALTER TABLE IF EXISTS "transfers"
 DROP CONSTRAINT IF EXISTS "transfers_to_account_id_fkey";
 
-transfers
 ALTER TABLE IF EXISTS "entries"
 DROP CONSTRAINT IF EXISTS "entries_account_id_fkey";
 
@@ -0,0 +1,25 @@
+CREATE TABLE "transfer_reviews" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"from_account_id" bigint NOT NULL,
+	"to_account_id" bigint NOT NULL,
+	"amount" bigint NOT NULL CHECK (amount > 0),
+	"reasons" text[] NOT NULL DEFAULT '{}',
+	"status" varchar NOT NULL DEFAULT 'pending_review',
+	"transfer_id" bigint,
+	"reviewed_by" varchar,
+	"reviewed_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfer_reviews" ("status");
+
+COMMENT ON COLUMN "transfer_reviews"."status" IS 'pending_review, approved or rejected';
+
+ALTER TABLE "transfer_reviews"
+ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_reviews"
+ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_reviews"
+ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
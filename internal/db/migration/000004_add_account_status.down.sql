@@ -0,0 +1,22 @@
+ALTER TABLE "transfer_reviews" DROP CONSTRAINT IF EXISTS "transfer_reviews_to_account_id_fkey";
+ALTER TABLE "transfer_reviews"
+ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_reviews" DROP CONSTRAINT IF EXISTS "transfer_reviews_from_account_id_fkey";
+ALTER TABLE "transfer_reviews"
+ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfers_to_account_id_fkey";
+ALTER TABLE "transfers"
+ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfers_from_account_id_fkey";
+ALTER TABLE "transfers"
+ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "entries" DROP CONSTRAINT IF EXISTS "entries_account_id_fkey";
+ALTER TABLE "entries"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "closed_at";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "status";
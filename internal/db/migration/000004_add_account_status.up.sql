@@ -0,0 +1,27 @@
+ALTER TABLE "accounts"
+ADD COLUMN "status" varchar NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'frozen', 'debit_blocked', 'closed'));
+
+ALTER TABLE "accounts" ADD COLUMN "closed_at" timestamptz;
+
+COMMENT ON COLUMN "accounts"."status" IS 'active, frozen, debit_blocked or closed';
+
+-- Accounts are closed, never deleted, so the ledger must not cascade away with them
+ALTER TABLE "entries" DROP CONSTRAINT IF EXISTS "entries_account_id_fkey";
+ALTER TABLE "entries"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfers_from_account_id_fkey";
+ALTER TABLE "transfers"
+ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfers_to_account_id_fkey";
+ALTER TABLE "transfers"
+ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "transfer_reviews" DROP CONSTRAINT IF EXISTS "transfer_reviews_from_account_id_fkey";
+ALTER TABLE "transfer_reviews"
+ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "transfer_reviews" DROP CONSTRAINT IF EXISTS "transfer_reviews_to_account_id_fkey";
+ALTER TABLE "transfer_reviews"
+ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
@@ -0,0 +1,11 @@
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_owner_fkey";
+
+DROP INDEX IF EXISTS "users_username_idx";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "id";
+ALTER TABLE "users" ADD CONSTRAINT "user_entities_pkey" PRIMARY KEY ("username");
+
+ALTER TABLE "users" RENAME CONSTRAINT "users_email_key" TO "user_entities_email_key";
+ALTER TABLE "users" RENAME COLUMN "username" TO "user_name";
+ALTER TABLE "users" RENAME TO "user_entities";
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("owner") REFERENCES "user_entities" ("user_name") DEFERRABLE INITIALLY IMMEDIATE;
@@ -0,0 +1,15 @@
+-- 000002 created the users as user_entities(user_name), while sql/schema, the queries
+-- and every later migration use users(username), keyed by id
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_owner_fkey";
+
+ALTER TABLE "user_entities" RENAME TO "users";
+ALTER TABLE "users" RENAME COLUMN "user_name" TO "username";
+ALTER TABLE "users" RENAME CONSTRAINT "user_entities_email_key" TO "users_email_key";
+
+ALTER TABLE "users" DROP CONSTRAINT "user_entities_pkey";
+ALTER TABLE "users" ADD COLUMN "id" BIGSERIAL PRIMARY KEY NOT NULL;
+
+-- usernames are used to log in and as account owners
+CREATE UNIQUE INDEX ON "users" ("username");
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username") DEFERRABLE INITIALLY IMMEDIATE;
@@ -0,0 +1,6 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "kyc_verified_at";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "kyc_tier";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "kyc_status";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "national_id";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "address";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "date_of_birth";
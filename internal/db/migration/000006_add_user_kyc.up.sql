@@ -0,0 +1,9 @@
+ALTER TABLE "users" ADD COLUMN "date_of_birth" date;
+ALTER TABLE "users" ADD COLUMN "address" varchar;
+ALTER TABLE "users" ADD COLUMN "national_id" varchar;
+ALTER TABLE "users"
+ADD COLUMN "kyc_status" varchar NOT NULL DEFAULT 'unverified' CHECK (kyc_status IN ('unverified', 'verified', 'rejected'));
+ALTER TABLE "users" ADD COLUMN "kyc_tier" int NOT NULL DEFAULT 0;
+ALTER TABLE "users" ADD COLUMN "kyc_verified_at" timestamptz;
+
+COMMENT ON COLUMN "users"."kyc_tier" IS 'limits account count and transfer size, see internal/kyc';
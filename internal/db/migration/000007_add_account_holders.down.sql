@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "account_holder_events";
+
+DROP TABLE IF EXISTS "account_holders";
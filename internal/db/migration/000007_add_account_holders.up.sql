@@ -0,0 +1,39 @@
+CREATE TABLE "account_holders" (
+	"account_id" bigint NOT NULL,
+	"username" varchar NOT NULL,
+	"role" varchar NOT NULL CHECK (role IN ('owner', 'signatory', 'viewer')),
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	PRIMARY KEY ("account_id", "username")
+);
+
+CREATE TABLE "account_holder_events" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"account_id" bigint NOT NULL,
+	"username" varchar NOT NULL,
+	"role" varchar NOT NULL,
+	"action" varchar NOT NULL CHECK (action IN ('added', 'removed')),
+	"actor" varchar NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "account_holders" ("username");
+
+CREATE INDEX ON "account_holder_events" ("account_id");
+
+COMMENT ON COLUMN "account_holders"."role" IS 'owner, signatory or viewer';
+
+COMMENT ON COLUMN "account_holder_events"."actor" IS 'username of the holder who made the change';
+
+ALTER TABLE "account_holders"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "account_holders"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_holder_events"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+-- every existing account starts out with its single owner as the only holder
+INSERT INTO "account_holders" ("account_id", "username", "role")
+SELECT "id", "owner", 'owner' FROM "accounts"
+WHERE "owner" IN (SELECT "username" FROM "users");
@@ -0,0 +1,22 @@
+CREATE TABLE "beneficiaries" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"username" varchar NOT NULL,
+	"nickname" varchar NOT NULL,
+	"account_id" bigint NOT NULL,
+	"verified" boolean NOT NULL DEFAULT false,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "beneficiaries" ("username", "account_id");
+
+CREATE UNIQUE INDEX ON "beneficiaries" ("username", "nickname");
+
+COMMENT ON COLUMN "beneficiaries"."username" IS 'user who saved the payee';
+
+COMMENT ON COLUMN "beneficiaries"."verified" IS 'the user confirmed the payee, transfers to it skip the new payee confirmation';
+
+ALTER TABLE "beneficiaries"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "beneficiaries"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
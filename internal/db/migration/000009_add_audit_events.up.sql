@@ -0,0 +1,30 @@
+CREATE TABLE "audit_events" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"actor" varchar NOT NULL,
+	"action" varchar NOT NULL,
+	"resource_type" varchar NOT NULL,
+	"resource_id" varchar NOT NULL,
+	"before" jsonb,
+	"after" jsonb,
+	"ip" varchar NOT NULL DEFAULT '',
+	"request_id" varchar NOT NULL DEFAULT '',
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_events" ("actor");
+
+CREATE INDEX ON "audit_events" ("resource_type", "resource_id");
+
+COMMENT ON TABLE "audit_events" IS 'append-only, updates and deletes are rejected by audit_events_immutable';
+
+COMMENT ON COLUMN "audit_events"."actor" IS 'username that made the change, or anonymous';
+
+CREATE FUNCTION audit_events_immutable() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_no_update
+BEFORE UPDATE OR DELETE ON "audit_events"
+FOR EACH ROW EXECUTE FUNCTION audit_events_immutable();
@@ -0,0 +1,15 @@
+CREATE TABLE "outbox" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"aggregate_type" varchar NOT NULL,
+	"aggregate_id" varchar NOT NULL,
+	"event_type" varchar NOT NULL,
+	"payload" jsonb NOT NULL,
+	"attempts" int NOT NULL DEFAULT 0,
+	"last_error" varchar,
+	"published_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "outbox" ("id") WHERE "published_at" IS NULL;
+
+COMMENT ON TABLE "outbox" IS 'domain events written in the same transaction as the change, published in id order by the dispatcher';
@@ -0,0 +1,44 @@
+CREATE TABLE "webhook_endpoints" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"merchant_id" bigint NOT NULL,
+	"url" varchar NOT NULL,
+	"secret" varchar NOT NULL,
+	"event_types" text[] NOT NULL DEFAULT '{}',
+	"active" boolean NOT NULL DEFAULT true,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"endpoint_id" bigint NOT NULL,
+	"event_id" bigint NOT NULL,
+	"event_type" varchar NOT NULL,
+	"payload" jsonb NOT NULL,
+	"status" varchar NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'dead')),
+	"attempts" int NOT NULL DEFAULT 0,
+	"next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+	"last_error" varchar,
+	"response_status" int,
+	"delivered_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "webhook_endpoints" ("merchant_id");
+
+CREATE UNIQUE INDEX ON "webhook_deliveries" ("endpoint_id", "event_id");
+
+CREATE INDEX ON "webhook_deliveries" ("next_attempt_at") WHERE "status" = 'pending';
+
+COMMENT ON COLUMN "webhook_endpoints"."secret" IS 'HMAC-SHA256 key used to sign deliveries';
+
+COMMENT ON COLUMN "webhook_endpoints"."event_types" IS 'event types to deliver, empty for all';
+
+COMMENT ON COLUMN "webhook_deliveries"."event_id" IS 'outbox event that triggered the delivery';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or dead once retries are exhausted';
+
+ALTER TABLE "webhook_endpoints"
+ADD FOREIGN KEY ("merchant_id") REFERENCES "merchants" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_deliveries"
+ADD FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id") ON DELETE CASCADE;
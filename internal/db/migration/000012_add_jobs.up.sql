@@ -0,0 +1,22 @@
+CREATE TABLE "jobs" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"kind" varchar NOT NULL,
+	"payload" jsonb NOT NULL DEFAULT '{}',
+	"status" varchar NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+	"unique_key" varchar,
+	"attempts" int NOT NULL DEFAULT 0,
+	"max_attempts" int NOT NULL DEFAULT 5,
+	"run_at" timestamptz NOT NULL DEFAULT (now()),
+	"locked_at" timestamptz,
+	"last_error" varchar,
+	"finished_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "jobs" ("run_at") WHERE "status" = 'pending';
+
+CREATE UNIQUE INDEX "jobs_unique_key_idx" ON "jobs" ("unique_key") WHERE "status" IN ('pending', 'running');
+
+COMMENT ON COLUMN "jobs"."unique_key" IS 'at most one pending or running job per key';
+
+COMMENT ON COLUMN "jobs"."status" IS 'pending, running, succeeded or failed once max_attempts is used up';
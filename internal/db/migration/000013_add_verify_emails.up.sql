@@ -0,0 +1,18 @@
+ALTER TABLE "users" ADD COLUMN "is_email_verified" bool NOT NULL DEFAULT false;
+
+CREATE TABLE "verify_emails" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"username" varchar NOT NULL,
+	"email" varchar NOT NULL,
+	"secret_code" varchar NOT NULL,
+	"is_used" bool NOT NULL DEFAULT false,
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	"expired_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
+);
+
+CREATE INDEX ON "verify_emails" ("username");
+
+COMMENT ON COLUMN "verify_emails"."email" IS 'address the code was sent to, the user may change theirs later';
+
+ALTER TABLE "verify_emails"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
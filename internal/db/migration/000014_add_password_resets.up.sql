@@ -0,0 +1,17 @@
+CREATE TABLE "password_resets" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"username" varchar NOT NULL,
+	"token_hash" varchar NOT NULL,
+	"used_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	"expired_at" timestamptz NOT NULL DEFAULT (now() + interval '30 minutes')
+);
+
+CREATE UNIQUE INDEX ON "password_resets" ("token_hash");
+
+CREATE INDEX ON "password_resets" ("username");
+
+COMMENT ON COLUMN "password_resets"."token_hash" IS 'SHA-256 of the emailed token, the token itself is never stored';
+
+ALTER TABLE "password_resets"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
@@ -0,0 +1,31 @@
+CREATE TABLE "user_totps" (
+	"username" varchar PRIMARY KEY NOT NULL,
+	"secret" varchar NOT NULL,
+	"confirmed_at" timestamptz,
+	"last_used_step" bigint NOT NULL DEFAULT 0,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "recovery_codes" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"username" varchar NOT NULL,
+	"code_hash" varchar NOT NULL,
+	"used_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "recovery_codes" ("username", "code_hash");
+
+COMMENT ON COLUMN "user_totps"."secret" IS 'base32 TOTP key, needed in the clear to compute codes';
+
+COMMENT ON COLUMN "user_totps"."confirmed_at" IS 'set once the user proved their app has the secret, a second factor is only required after that';
+
+COMMENT ON COLUMN "user_totps"."last_used_step" IS 'time step of the last accepted code, a code is never accepted twice';
+
+COMMENT ON COLUMN "recovery_codes"."code_hash" IS 'SHA-256 of the normalized code';
+
+ALTER TABLE "user_totps"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "recovery_codes"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "merchant_admins";
+
+DROP TABLE IF EXISTS "user_roles";
+
+DROP TABLE IF EXISTS "role_permissions";
+
+DROP TABLE IF EXISTS "permissions";
+
+DROP TABLE IF EXISTS "roles";
+
+COMMENT ON COLUMN "merchants"."admin_id" IS NULL;
@@ -0,0 +1,91 @@
+CREATE TABLE "roles" (
+	"name" varchar PRIMARY KEY NOT NULL,
+	"description" varchar NOT NULL DEFAULT ''
+);
+
+CREATE TABLE "permissions" (
+	"name" varchar PRIMARY KEY NOT NULL,
+	"description" varchar NOT NULL DEFAULT ''
+);
+
+CREATE TABLE "role_permissions" (
+	"role" varchar NOT NULL,
+	"permission" varchar NOT NULL,
+	PRIMARY KEY ("role", "permission")
+);
+
+CREATE TABLE "user_roles" (
+	"username" varchar NOT NULL,
+	"role" varchar NOT NULL,
+	"granted_by" varchar NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	PRIMARY KEY ("username", "role")
+);
+
+CREATE TABLE "merchant_admins" (
+	"merchant_id" bigint NOT NULL,
+	"username" varchar NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	PRIMARY KEY ("merchant_id", "username")
+);
+
+CREATE INDEX ON "merchant_admins" ("username");
+
+COMMENT ON COLUMN "merchants"."admin_id" IS 'account that receives the merchant''s payments, the users administering the merchant are in merchant_admins';
+
+COMMENT ON COLUMN "user_roles"."granted_by" IS 'username of the admin who granted the role, or system';
+
+ALTER TABLE "role_permissions"
+ADD FOREIGN KEY ("role") REFERENCES "roles" ("name") ON DELETE CASCADE;
+
+ALTER TABLE "role_permissions"
+ADD FOREIGN KEY ("permission") REFERENCES "permissions" ("name") ON DELETE CASCADE;
+
+ALTER TABLE "user_roles"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "user_roles"
+ADD FOREIGN KEY ("role") REFERENCES "roles" ("name") ON DELETE CASCADE;
+
+ALTER TABLE "merchant_admins"
+ADD FOREIGN KEY ("merchant_id") REFERENCES "merchants" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "merchant_admins"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+INSERT INTO "roles" ("name", "description") VALUES
+	('customer', 'holds accounts, saves payees and sends transfers'),
+	('merchant_admin', 'manages the merchants listed for them in merchant_admins'),
+	('bank_staff', 'reviews held transfers and reads the audit log'),
+	('bank_admin', 'manages users and their roles');
+
+INSERT INTO "permissions" ("name", "description") VALUES
+	('banking.use', 'open accounts, manage payees and send transfers'),
+	('merchants.manage', 'manage webhooks of administered merchants'),
+	('transfer_reviews.decide', 'list, approve and reject held transfers'),
+	('audit_events.read', 'search the audit log'),
+	('users.manage', 'manage users'),
+	('roles.manage', 'grant and revoke roles');
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+	('customer', 'banking.use'),
+	('merchant_admin', 'merchants.manage'),
+	('bank_staff', 'transfer_reviews.decide'),
+	('bank_staff', 'audit_events.read'),
+	('bank_admin', 'transfer_reviews.decide'),
+	('bank_admin', 'audit_events.read'),
+	('bank_admin', 'users.manage'),
+	('bank_admin', 'roles.manage');
+
+-- existing users keep banking as before
+INSERT INTO "user_roles" ("username", "role", "granted_by")
+SELECT "username", 'customer', 'system' FROM "users";
+
+-- whoever owned a merchant's admin account administered the merchant until now
+INSERT INTO "merchant_admins" ("merchant_id", "username")
+SELECT "merchants"."id", "accounts"."owner" FROM "merchants"
+JOIN "accounts" ON "accounts"."id" = "merchants"."admin_id"
+JOIN "users" ON "users"."username" = "accounts"."owner";
+
+INSERT INTO "user_roles" ("username", "role", "granted_by")
+SELECT DISTINCT "username", 'merchant_admin', 'system' FROM "merchant_admins";
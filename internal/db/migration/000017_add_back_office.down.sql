@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "jobs_status_kind_idx";
+
+DELETE FROM "permissions"
+WHERE "name" IN ('back_office.access', 'accounts.freeze', 'ledger.adjust');
@@ -0,0 +1,13 @@
+INSERT INTO "permissions" ("name", "description") VALUES
+	('back_office.access', 'look up users, accounts, the ledger, outbox events and jobs under /admin'),
+	('accounts.freeze', 'freeze and unfreeze any account'),
+	('ledger.adjust', 'post manual balance adjustments');
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+	('bank_staff', 'back_office.access'),
+	('bank_staff', 'accounts.freeze'),
+	('bank_admin', 'back_office.access'),
+	('bank_admin', 'accounts.freeze'),
+	('bank_admin', 'ledger.adjust');
+
+CREATE INDEX "jobs_status_kind_idx" ON "jobs" ("status", "kind");
@@ -0,0 +1,15 @@
+DELETE FROM "role_permissions"
+WHERE "role" = 'bank_staff' AND "permission" = 'ledger.adjust';
+
+DELETE FROM "permissions"
+WHERE "name" = 'ledger.approve';
+
+UPDATE "permissions"
+SET "description" = 'post manual balance adjustments'
+WHERE "name" = 'ledger.adjust';
+
+DROP TABLE IF EXISTS "ledger_adjustments";
+
+DROP TABLE IF EXISTS "gl_entries";
+
+DROP TABLE IF EXISTS "gl_accounts";
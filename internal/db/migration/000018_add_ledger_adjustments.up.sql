@@ -0,0 +1,75 @@
+CREATE TABLE "gl_accounts" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"code" varchar NOT NULL CHECK (code IN ('suspense', 'adjustments')),
+	"currency" varchar NOT NULL,
+	"balance" bigint NOT NULL DEFAULT 0,
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	UNIQUE ("code", "currency")
+);
+
+CREATE TABLE "gl_entries" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"gl_account_id" bigint NOT NULL,
+	"amount" bigint NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "ledger_adjustments" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"account_id" bigint NOT NULL,
+	"gl_code" varchar NOT NULL CHECK (gl_code IN ('suspense', 'adjustments')),
+	"amount" bigint NOT NULL CHECK (amount <> 0),
+	"reason_code" varchar NOT NULL CHECK (reason_code IN ('goodwill', 'fee_refund', 'error_correction', 'chargeback', 'write_off')),
+	"note" varchar NOT NULL DEFAULT '',
+	"status" varchar NOT NULL DEFAULT 'pending_approval' CHECK (status IN ('pending_approval', 'approved', 'rejected')),
+	"requested_by" varchar NOT NULL,
+	"decided_by" varchar,
+	"decided_at" timestamptz,
+	"entry_id" bigint,
+	"gl_entry_id" bigint,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "gl_entries" ("gl_account_id");
+
+CREATE INDEX ON "ledger_adjustments" ("status");
+
+CREATE INDEX ON "ledger_adjustments" ("account_id");
+
+COMMENT ON TABLE "gl_accounts" IS 'the bank''s own ledger accounts, one per code and currency, on the other side of manual adjustments';
+
+COMMENT ON COLUMN "ledger_adjustments"."amount" IS 'credited to the customer account when positive, debited when negative; the GL account gets the opposite';
+
+COMMENT ON COLUMN "ledger_adjustments"."status" IS 'pending_approval, approved or rejected; decided_by must differ from requested_by';
+
+ALTER TABLE "gl_entries"
+ADD FOREIGN KEY ("gl_account_id") REFERENCES "gl_accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "ledger_adjustments"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "ledger_adjustments"
+ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "ledger_adjustments"
+ADD FOREIGN KEY ("gl_entry_id") REFERENCES "gl_entries" ("id");
+
+INSERT INTO "gl_accounts" ("code", "currency") VALUES
+	('suspense', 'USD'),
+	('suspense', 'EUR'),
+	('suspense', 'CAD'),
+	('adjustments', 'USD'),
+	('adjustments', 'EUR'),
+	('adjustments', 'CAD');
+
+INSERT INTO "permissions" ("name", "description") VALUES
+	('ledger.approve', 'approve or reject manual adjustments requested by someone else');
+
+-- staff may now request adjustments, which only take effect once a bank admin approves them
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+	('bank_staff', 'ledger.adjust'),
+	('bank_admin', 'ledger.approve');
+
+UPDATE "permissions"
+SET "description" = 'request manual adjustments'
+WHERE "name" = 'ledger.adjust';
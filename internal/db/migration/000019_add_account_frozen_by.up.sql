@@ -0,0 +1,7 @@
+ALTER TABLE "accounts"
+ADD COLUMN "frozen_by" varchar CHECK (frozen_by IN ('customer', 'staff'));
+
+COMMENT ON COLUMN "accounts"."frozen_by" IS 'customer or staff while the account is frozen; only staff can lift a staff freeze';
+
+-- who froze the accounts frozen so far isn't known, so only staff may lift those freezes
+UPDATE "accounts" SET "frozen_by" = 'staff' WHERE "status" = 'frozen';
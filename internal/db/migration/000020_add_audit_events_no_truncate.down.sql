@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON "audit_events";
+
+COMMENT ON TABLE "audit_events" IS 'append-only, updates and deletes are rejected by audit_events_immutable';
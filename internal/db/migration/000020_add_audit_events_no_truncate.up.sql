@@ -0,0 +1,6 @@
+-- row triggers don't fire on TRUNCATE, which would otherwise empty the audit log in one statement
+CREATE TRIGGER audit_events_no_truncate
+BEFORE TRUNCATE ON "audit_events"
+FOR EACH STATEMENT EXECUTE FUNCTION audit_events_immutable();
+
+COMMENT ON TABLE "audit_events" IS 'append-only, updates, deletes and truncates are rejected by audit_events_immutable';
@@ -0,0 +1,4 @@
+ALTER TABLE "user_totps" DROP COLUMN IF EXISTS "locked_until";
+ALTER TABLE "user_totps" DROP COLUMN IF EXISTS "failed_attempts";
+
+COMMENT ON COLUMN "user_totps"."secret" IS 'base32 TOTP key, needed in the clear to compute codes';
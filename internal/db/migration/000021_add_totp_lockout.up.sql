@@ -0,0 +1,8 @@
+ALTER TABLE "user_totps" ADD COLUMN "failed_attempts" int NOT NULL DEFAULT 0;
+ALTER TABLE "user_totps" ADD COLUMN "locked_until" timestamptz;
+
+COMMENT ON COLUMN "user_totps"."secret" IS 'base32 TOTP key, sealed with AES-GCM under TOTP_ENCRYPTION_KEY as codes need it in the clear';
+
+COMMENT ON COLUMN "user_totps"."failed_attempts" IS 'invalid codes since the last valid one or lockout';
+
+COMMENT ON COLUMN "user_totps"."locked_until" IS 'no code is accepted before then, after too many invalid ones';
@@ -0,0 +1,5 @@
+-- closed accounts are kept for their history, so only open ones may clash on currency;
+-- the index keeps the constraint's name, which the API turns into a readable error
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "owner_currency_key";
+
+CREATE UNIQUE INDEX "owner_currency_key" ON "accounts" ("owner", "currency") WHERE "status" <> 'closed';
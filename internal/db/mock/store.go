@@ -57,6 +57,230 @@ func (mr *MockStoreMockRecorder) AddAccountBalance(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountBalance", reflect.TypeOf((*MockStore)(nil).AddAccountBalance), ctx, arg)
 }
 
+// AddAccountHolderTx mocks base method.
+func (m *MockStore) AddAccountHolderTx(ctx context.Context, arg db.AddAccountHolderTxParams) (db.AccountHolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAccountHolderTx", ctx, arg)
+	ret0, _ := ret[0].(db.AccountHolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAccountHolderTx indicates an expected call of AddAccountHolderTx.
+func (mr *MockStoreMockRecorder) AddAccountHolderTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountHolderTx", reflect.TypeOf((*MockStore)(nil).AddAccountHolderTx), ctx, arg)
+}
+
+// AddGLAccountBalance mocks base method.
+func (m *MockStore) AddGLAccountBalance(ctx context.Context, arg db.AddGLAccountBalanceParams) (db.GlAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGLAccountBalance", ctx, arg)
+	ret0, _ := ret[0].(db.GlAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGLAccountBalance indicates an expected call of AddGLAccountBalance.
+func (mr *MockStoreMockRecorder) AddGLAccountBalance(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGLAccountBalance", reflect.TypeOf((*MockStore)(nil).AddGLAccountBalance), ctx, arg)
+}
+
+// AddMerchantAdminTx mocks base method.
+func (m *MockStore) AddMerchantAdminTx(ctx context.Context, arg db.CreateMerchantAdminParams) (db.MerchantAdmin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMerchantAdminTx", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantAdmin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMerchantAdminTx indicates an expected call of AddMerchantAdminTx.
+func (mr *MockStoreMockRecorder) AddMerchantAdminTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMerchantAdminTx", reflect.TypeOf((*MockStore)(nil).AddMerchantAdminTx), ctx, arg)
+}
+
+// ClaimDueWebhookDeliveries mocks base method.
+func (m *MockStore) ClaimDueWebhookDeliveries(ctx context.Context, arg db.ClaimDueWebhookDeliveriesParams) ([]db.ClaimDueWebhookDeliveriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]db.ClaimDueWebhookDeliveriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueWebhookDeliveries indicates an expected call of ClaimDueWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ClaimDueWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ClaimDueWebhookDeliveries), ctx, arg)
+}
+
+// ClaimJobs mocks base method.
+func (m *MockStore) ClaimJobs(ctx context.Context, arg db.ClaimJobsParams) ([]db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJobs", ctx, arg)
+	ret0, _ := ret[0].([]db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJobs indicates an expected call of ClaimJobs.
+func (mr *MockStoreMockRecorder) ClaimJobs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJobs", reflect.TypeOf((*MockStore)(nil).ClaimJobs), ctx, arg)
+}
+
+// CloseAccountTx mocks base method.
+func (m *MockStore) CloseAccountTx(ctx context.Context, arg db.CloseAccountTxParams) (db.CloseAccountTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAccountTx", ctx, arg)
+	ret0, _ := ret[0].(db.CloseAccountTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAccountTx indicates an expected call of CloseAccountTx.
+func (mr *MockStoreMockRecorder) CloseAccountTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAccountTx", reflect.TypeOf((*MockStore)(nil).CloseAccountTx), ctx, arg)
+}
+
+// CompleteJob mocks base method.
+func (m *MockStore) CompleteJob(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteJob", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteJob indicates an expected call of CompleteJob.
+func (mr *MockStoreMockRecorder) CompleteJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteJob", reflect.TypeOf((*MockStore)(nil).CompleteJob), ctx, id)
+}
+
+// ConfirmTOTPTx mocks base method.
+func (m *MockStore) ConfirmTOTPTx(ctx context.Context, arg db.ConfirmTOTPTxParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPTx", ctx, arg)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTPTx indicates an expected call of ConfirmTOTPTx.
+func (mr *MockStoreMockRecorder) ConfirmTOTPTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPTx", reflect.TypeOf((*MockStore)(nil).ConfirmTOTPTx), ctx, arg)
+}
+
+// ConfirmUserTOTP mocks base method.
+func (m *MockStore) ConfirmUserTOTP(ctx context.Context, arg db.ConfirmUserTOTPParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmUserTOTP", ctx, arg)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmUserTOTP indicates an expected call of ConfirmUserTOTP.
+func (mr *MockStoreMockRecorder) ConfirmUserTOTP(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmUserTOTP", reflect.TypeOf((*MockStore)(nil).ConfirmUserTOTP), ctx, arg)
+}
+
+// CountAccountOwners mocks base method.
+func (m *MockStore) CountAccountOwners(ctx context.Context, accountID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccountOwners", ctx, accountID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccountOwners indicates an expected call of CountAccountOwners.
+func (mr *MockStoreMockRecorder) CountAccountOwners(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccountOwners", reflect.TypeOf((*MockStore)(nil).CountAccountOwners), ctx, accountID)
+}
+
+// CountAccountsByOwner mocks base method.
+func (m *MockStore) CountAccountsByOwner(ctx context.Context, owner string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccountsByOwner", ctx, owner)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccountsByOwner indicates an expected call of CountAccountsByOwner.
+func (mr *MockStoreMockRecorder) CountAccountsByOwner(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccountsByOwner", reflect.TypeOf((*MockStore)(nil).CountAccountsByOwner), ctx, owner)
+}
+
+// CountMerchantAdminsByUser mocks base method.
+func (m *MockStore) CountMerchantAdminsByUser(ctx context.Context, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMerchantAdminsByUser", ctx, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMerchantAdminsByUser indicates an expected call of CountMerchantAdminsByUser.
+func (mr *MockStoreMockRecorder) CountMerchantAdminsByUser(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMerchantAdminsByUser", reflect.TypeOf((*MockStore)(nil).CountMerchantAdminsByUser), ctx, username)
+}
+
+// CountTransfersBetweenAccounts mocks base method.
+func (m *MockStore) CountTransfersBetweenAccounts(ctx context.Context, arg db.CountTransfersBetweenAccountsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransfersBetweenAccounts", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransfersBetweenAccounts indicates an expected call of CountTransfersBetweenAccounts.
+func (mr *MockStoreMockRecorder) CountTransfersBetweenAccounts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransfersBetweenAccounts", reflect.TypeOf((*MockStore)(nil).CountTransfersBetweenAccounts), ctx, arg)
+}
+
+// CountTransfersFromAccountSince mocks base method.
+func (m *MockStore) CountTransfersFromAccountSince(ctx context.Context, arg db.CountTransfersFromAccountSinceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransfersFromAccountSince", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransfersFromAccountSince indicates an expected call of CountTransfersFromAccountSince.
+func (mr *MockStoreMockRecorder) CountTransfersFromAccountSince(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransfersFromAccountSince", reflect.TypeOf((*MockStore)(nil).CountTransfersFromAccountSince), ctx, arg)
+}
+
+// CountTransfersToCountry mocks base method.
+func (m *MockStore) CountTransfersToCountry(ctx context.Context, arg db.CountTransfersToCountryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransfersToCountry", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransfersToCountry indicates an expected call of CountTransfersToCountry.
+func (mr *MockStoreMockRecorder) CountTransfersToCountry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransfersToCountry", reflect.TypeOf((*MockStore)(nil).CountTransfersToCountry), ctx, arg)
+}
+
 // CreateAccount mocks base method.
 func (m *MockStore) CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +296,81 @@ func (mr *MockStoreMockRecorder) CreateAccount(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), ctx, arg)
 }
 
+// CreateAccountHolder mocks base method.
+func (m *MockStore) CreateAccountHolder(ctx context.Context, arg db.CreateAccountHolderParams) (db.AccountHolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountHolder", ctx, arg)
+	ret0, _ := ret[0].(db.AccountHolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountHolder indicates an expected call of CreateAccountHolder.
+func (mr *MockStoreMockRecorder) CreateAccountHolder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountHolder", reflect.TypeOf((*MockStore)(nil).CreateAccountHolder), ctx, arg)
+}
+
+// CreateAccountHolderEvent mocks base method.
+func (m *MockStore) CreateAccountHolderEvent(ctx context.Context, arg db.CreateAccountHolderEventParams) (db.AccountHolderEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountHolderEvent", ctx, arg)
+	ret0, _ := ret[0].(db.AccountHolderEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountHolderEvent indicates an expected call of CreateAccountHolderEvent.
+func (mr *MockStoreMockRecorder) CreateAccountHolderEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountHolderEvent", reflect.TypeOf((*MockStore)(nil).CreateAccountHolderEvent), ctx, arg)
+}
+
+// CreateAccountTx mocks base method.
+func (m *MockStore) CreateAccountTx(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountTx", ctx, arg)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountTx indicates an expected call of CreateAccountTx.
+func (mr *MockStoreMockRecorder) CreateAccountTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountTx", reflect.TypeOf((*MockStore)(nil).CreateAccountTx), ctx, arg)
+}
+
+// CreateAuditEvent mocks base method.
+func (m *MockStore) CreateAuditEvent(ctx context.Context, arg db.CreateAuditEventParams) (db.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEvent", ctx, arg)
+	ret0, _ := ret[0].(db.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditEvent indicates an expected call of CreateAuditEvent.
+func (mr *MockStoreMockRecorder) CreateAuditEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEvent", reflect.TypeOf((*MockStore)(nil).CreateAuditEvent), ctx, arg)
+}
+
+// CreateBeneficiary mocks base method.
+func (m *MockStore) CreateBeneficiary(ctx context.Context, arg db.CreateBeneficiaryParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBeneficiary", ctx, arg)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBeneficiary indicates an expected call of CreateBeneficiary.
+func (mr *MockStoreMockRecorder) CreateBeneficiary(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBeneficiary", reflect.TypeOf((*MockStore)(nil).CreateBeneficiary), ctx, arg)
+}
+
 // CreateCountry mocks base method.
 func (m *MockStore) CreateCountry(ctx context.Context, arg db.CreateCountryParams) (db.Country, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +401,36 @@ func (mr *MockStoreMockRecorder) CreateEntry(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), ctx, arg)
 }
 
+// CreateGLEntry mocks base method.
+func (m *MockStore) CreateGLEntry(ctx context.Context, arg db.CreateGLEntryParams) (db.GlEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGLEntry", ctx, arg)
+	ret0, _ := ret[0].(db.GlEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGLEntry indicates an expected call of CreateGLEntry.
+func (mr *MockStoreMockRecorder) CreateGLEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGLEntry", reflect.TypeOf((*MockStore)(nil).CreateGLEntry), ctx, arg)
+}
+
+// CreateLedgerAdjustment mocks base method.
+func (m *MockStore) CreateLedgerAdjustment(ctx context.Context, arg db.CreateLedgerAdjustmentParams) (db.LedgerAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLedgerAdjustment", ctx, arg)
+	ret0, _ := ret[0].(db.LedgerAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLedgerAdjustment indicates an expected call of CreateLedgerAdjustment.
+func (mr *MockStoreMockRecorder) CreateLedgerAdjustment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLedgerAdjustment", reflect.TypeOf((*MockStore)(nil).CreateLedgerAdjustment), ctx, arg)
+}
+
 // CreateMerchant mocks base method.
 func (m *MockStore) CreateMerchant(ctx context.Context, arg db.CreateMerchantParams) (db.Merchant, error) {
 	m.ctrl.T.Helper()
@@ -117,6 +446,21 @@ func (mr *MockStoreMockRecorder) CreateMerchant(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchant", reflect.TypeOf((*MockStore)(nil).CreateMerchant), ctx, arg)
 }
 
+// CreateMerchantAdmin mocks base method.
+func (m *MockStore) CreateMerchantAdmin(ctx context.Context, arg db.CreateMerchantAdminParams) (db.MerchantAdmin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchantAdmin", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantAdmin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchantAdmin indicates an expected call of CreateMerchantAdmin.
+func (mr *MockStoreMockRecorder) CreateMerchantAdmin(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantAdmin", reflect.TypeOf((*MockStore)(nil).CreateMerchantAdmin), ctx, arg)
+}
+
 // CreateOrder mocks base method.
 func (m *MockStore) CreateOrder(ctx context.Context, arg db.CreateOrderParams) (db.Order, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +490,51 @@ func (mr *MockStoreMockRecorder) CreateOrderItem(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItem", reflect.TypeOf((*MockStore)(nil).CreateOrderItem), ctx, arg)
 }
 
+// CreateOrderTx mocks base method.
+func (m *MockStore) CreateOrderTx(ctx context.Context, arg db.CreateOrderParams) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderTx", ctx, arg)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderTx indicates an expected call of CreateOrderTx.
+func (mr *MockStoreMockRecorder) CreateOrderTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderTx", reflect.TypeOf((*MockStore)(nil).CreateOrderTx), ctx, arg)
+}
+
+// CreateOutboxEvent mocks base method.
+func (m *MockStore) CreateOutboxEvent(ctx context.Context, arg db.CreateOutboxEventParams) (db.Outbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutboxEvent", ctx, arg)
+	ret0, _ := ret[0].(db.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOutboxEvent indicates an expected call of CreateOutboxEvent.
+func (mr *MockStoreMockRecorder) CreateOutboxEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxEvent", reflect.TypeOf((*MockStore)(nil).CreateOutboxEvent), ctx, arg)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockStore) CreatePasswordReset(ctx context.Context, arg db.CreatePasswordResetParams) (db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", ctx, arg)
+	ret0, _ := ret[0].(db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockStoreMockRecorder) CreatePasswordReset(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockStore)(nil).CreatePasswordReset), ctx, arg)
+}
+
 // CreateProduct mocks base method.
 func (m *MockStore) CreateProduct(ctx context.Context, arg db.CreateProductParams) (db.Product, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +550,21 @@ func (mr *MockStoreMockRecorder) CreateProduct(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), ctx, arg)
 }
 
+// CreateRecoveryCode mocks base method.
+func (m *MockStore) CreateRecoveryCode(ctx context.Context, arg db.CreateRecoveryCodeParams) (db.RecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecoveryCode", ctx, arg)
+	ret0, _ := ret[0].(db.RecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecoveryCode indicates an expected call of CreateRecoveryCode.
+func (mr *MockStoreMockRecorder) CreateRecoveryCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecoveryCode", reflect.TypeOf((*MockStore)(nil).CreateRecoveryCode), ctx, arg)
+}
+
 // CreateTransfer mocks base method.
 func (m *MockStore) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.Transfer, error) {
 	m.ctrl.T.Helper()
@@ -176,62 +580,226 @@ func (mr *MockStoreMockRecorder) CreateTransfer(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), ctx, arg)
 }
 
-// DeleteAccount mocks base method.
-func (m *MockStore) DeleteAccount(ctx context.Context, id int64) error {
+// CreateTransferReview mocks base method.
+func (m *MockStore) CreateTransferReview(ctx context.Context, arg db.CreateTransferReviewParams) (db.TransferReview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateTransferReview", ctx, arg)
+	ret0, _ := ret[0].(db.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockStoreMockRecorder) DeleteAccount(ctx, id any) *gomock.Call {
+// CreateTransferReview indicates an expected call of CreateTransferReview.
+func (mr *MockStoreMockRecorder) CreateTransferReview(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferReview", reflect.TypeOf((*MockStore)(nil).CreateTransferReview), ctx, arg)
 }
 
-// DeleteCountry mocks base method.
-func (m *MockStore) DeleteCountry(ctx context.Context, code int32) error {
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCountry", ctx, code)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteCountry indicates an expected call of DeleteCountry.
-func (mr *MockStoreMockRecorder) DeleteCountry(ctx, code any) *gomock.Call {
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCountry", reflect.TypeOf((*MockStore)(nil).DeleteCountry), ctx, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
 }
 
-// DeleteEntry mocks base method.
-func (m *MockStore) DeleteEntry(ctx context.Context, id int64) error {
+// CreateUserTx mocks base method.
+func (m *MockStore) CreateUserTx(ctx context.Context, arg db.CreateUserTxParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEntry", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateUserTx", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteEntry indicates an expected call of DeleteEntry.
-func (mr *MockStoreMockRecorder) DeleteEntry(ctx, id any) *gomock.Call {
+// CreateUserTx indicates an expected call of CreateUserTx.
+func (mr *MockStoreMockRecorder) CreateUserTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockStore)(nil).DeleteEntry), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), ctx, arg)
 }
 
-// DeleteMerchant mocks base method.
-func (m *MockStore) DeleteMerchant(ctx context.Context, id int64) error {
+// CreateVerifyEmail mocks base method.
+func (m *MockStore) CreateVerifyEmail(ctx context.Context, arg db.CreateVerifyEmailParams) (db.VerifyEmail, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMerchant", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateVerifyEmail", ctx, arg)
+	ret0, _ := ret[0].(db.VerifyEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteMerchant indicates an expected call of DeleteMerchant.
+// CreateVerifyEmail indicates an expected call of CreateVerifyEmail.
+func (mr *MockStoreMockRecorder) CreateVerifyEmail(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerifyEmail", reflect.TypeOf((*MockStore)(nil).CreateVerifyEmail), ctx, arg)
+}
+
+// CreateWebhookDelivery mocks base method.
+func (m *MockStore) CreateWebhookDelivery(ctx context.Context, arg db.CreateWebhookDeliveryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery.
+func (mr *MockStoreMockRecorder) CreateWebhookDelivery(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockStore)(nil).CreateWebhookDelivery), ctx, arg)
+}
+
+// CreateWebhookEndpoint mocks base method.
+func (m *MockStore) CreateWebhookEndpoint(ctx context.Context, arg db.CreateWebhookEndpointParams) (db.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookEndpoint", ctx, arg)
+	ret0, _ := ret[0].(db.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookEndpoint indicates an expected call of CreateWebhookEndpoint.
+func (mr *MockStoreMockRecorder) CreateWebhookEndpoint(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEndpoint", reflect.TypeOf((*MockStore)(nil).CreateWebhookEndpoint), ctx, arg)
+}
+
+// DecideAdjustmentTx mocks base method.
+func (m *MockStore) DecideAdjustmentTx(ctx context.Context, arg db.DecideAdjustmentTxParams) (db.DecideAdjustmentTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideAdjustmentTx", ctx, arg)
+	ret0, _ := ret[0].(db.DecideAdjustmentTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideAdjustmentTx indicates an expected call of DecideAdjustmentTx.
+func (mr *MockStoreMockRecorder) DecideAdjustmentTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideAdjustmentTx", reflect.TypeOf((*MockStore)(nil).DecideAdjustmentTx), ctx, arg)
+}
+
+// DecideLedgerAdjustment mocks base method.
+func (m *MockStore) DecideLedgerAdjustment(ctx context.Context, arg db.DecideLedgerAdjustmentParams) (db.LedgerAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideLedgerAdjustment", ctx, arg)
+	ret0, _ := ret[0].(db.LedgerAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideLedgerAdjustment indicates an expected call of DecideLedgerAdjustment.
+func (mr *MockStoreMockRecorder) DecideLedgerAdjustment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideLedgerAdjustment", reflect.TypeOf((*MockStore)(nil).DecideLedgerAdjustment), ctx, arg)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockStore) DeleteAccount(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStoreMockRecorder) DeleteAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), ctx, id)
+}
+
+// DeleteAccountHolder mocks base method.
+func (m *MockStore) DeleteAccountHolder(ctx context.Context, arg db.DeleteAccountHolderParams) (db.AccountHolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccountHolder", ctx, arg)
+	ret0, _ := ret[0].(db.AccountHolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAccountHolder indicates an expected call of DeleteAccountHolder.
+func (mr *MockStoreMockRecorder) DeleteAccountHolder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountHolder", reflect.TypeOf((*MockStore)(nil).DeleteAccountHolder), ctx, arg)
+}
+
+// DeleteBeneficiary mocks base method.
+func (m *MockStore) DeleteBeneficiary(ctx context.Context, arg db.DeleteBeneficiaryParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBeneficiary", ctx, arg)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBeneficiary indicates an expected call of DeleteBeneficiary.
+func (mr *MockStoreMockRecorder) DeleteBeneficiary(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBeneficiary", reflect.TypeOf((*MockStore)(nil).DeleteBeneficiary), ctx, arg)
+}
+
+// DeleteCountry mocks base method.
+func (m *MockStore) DeleteCountry(ctx context.Context, code int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCountry", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCountry indicates an expected call of DeleteCountry.
+func (mr *MockStoreMockRecorder) DeleteCountry(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCountry", reflect.TypeOf((*MockStore)(nil).DeleteCountry), ctx, code)
+}
+
+// DeleteEntry mocks base method.
+func (m *MockStore) DeleteEntry(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEntry indicates an expected call of DeleteEntry.
+func (mr *MockStoreMockRecorder) DeleteEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockStore)(nil).DeleteEntry), ctx, id)
+}
+
+// DeleteMerchant mocks base method.
+func (m *MockStore) DeleteMerchant(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMerchant", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMerchant indicates an expected call of DeleteMerchant.
 func (mr *MockStoreMockRecorder) DeleteMerchant(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMerchant", reflect.TypeOf((*MockStore)(nil).DeleteMerchant), ctx, id)
 }
 
+// DeleteMerchantAdmin mocks base method.
+func (m *MockStore) DeleteMerchantAdmin(ctx context.Context, arg db.DeleteMerchantAdminParams) (db.MerchantAdmin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMerchantAdmin", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantAdmin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMerchantAdmin indicates an expected call of DeleteMerchantAdmin.
+func (mr *MockStoreMockRecorder) DeleteMerchantAdmin(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMerchantAdmin", reflect.TypeOf((*MockStore)(nil).DeleteMerchantAdmin), ctx, arg)
+}
+
 // DeleteOrder mocks base method.
 func (m *MockStore) DeleteOrder(ctx context.Context, id int32) error {
 	m.ctrl.T.Helper()
@@ -274,6 +842,20 @@ func (mr *MockStoreMockRecorder) DeleteProduct(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockStore)(nil).DeleteProduct), ctx, id)
 }
 
+// DeleteRecoveryCodes mocks base method.
+func (m *MockStore) DeleteRecoveryCodes(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecoveryCodes", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecoveryCodes indicates an expected call of DeleteRecoveryCodes.
+func (mr *MockStoreMockRecorder) DeleteRecoveryCodes(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecoveryCodes", reflect.TypeOf((*MockStore)(nil).DeleteRecoveryCodes), ctx, username)
+}
+
 // DeleteTransfer mocks base method.
 func (m *MockStore) DeleteTransfer(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -288,6 +870,92 @@ func (mr *MockStoreMockRecorder) DeleteTransfer(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransfer", reflect.TypeOf((*MockStore)(nil).DeleteTransfer), ctx, id)
 }
 
+// DeleteUserTOTP mocks base method.
+func (m *MockStore) DeleteUserTOTP(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTOTP", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserTOTP indicates an expected call of DeleteUserTOTP.
+func (mr *MockStoreMockRecorder) DeleteUserTOTP(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTOTP", reflect.TypeOf((*MockStore)(nil).DeleteUserTOTP), ctx, username)
+}
+
+// DeleteWebhookEndpoint mocks base method.
+func (m *MockStore) DeleteWebhookEndpoint(ctx context.Context, arg db.DeleteWebhookEndpointParams) (db.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookEndpoint", ctx, arg)
+	ret0, _ := ret[0].(db.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhookEndpoint indicates an expected call of DeleteWebhookEndpoint.
+func (mr *MockStoreMockRecorder) DeleteWebhookEndpoint(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookEndpoint", reflect.TypeOf((*MockStore)(nil).DeleteWebhookEndpoint), ctx, arg)
+}
+
+// DisableTOTPTx mocks base method.
+func (m *MockStore) DisableTOTPTx(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTPTx", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTPTx indicates an expected call of DisableTOTPTx.
+func (mr *MockStoreMockRecorder) DisableTOTPTx(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTPTx", reflect.TypeOf((*MockStore)(nil).DisableTOTPTx), ctx, username)
+}
+
+// EnqueueJob mocks base method.
+func (m *MockStore) EnqueueJob(ctx context.Context, arg db.EnqueueJobParams) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueJob", ctx, arg)
+	ret0, _ := ret[0].(db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueJob indicates an expected call of EnqueueJob.
+func (mr *MockStoreMockRecorder) EnqueueJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueJob", reflect.TypeOf((*MockStore)(nil).EnqueueJob), ctx, arg)
+}
+
+// ExpirePasswordResets mocks base method.
+func (m *MockStore) ExpirePasswordResets(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePasswordResets", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpirePasswordResets indicates an expected call of ExpirePasswordResets.
+func (mr *MockStoreMockRecorder) ExpirePasswordResets(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePasswordResets", reflect.TypeOf((*MockStore)(nil).ExpirePasswordResets), ctx, username)
+}
+
+// FailJob mocks base method.
+func (m *MockStore) FailJob(ctx context.Context, arg db.FailJobParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailJob", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailJob indicates an expected call of FailJob.
+func (mr *MockStoreMockRecorder) FailJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailJob", reflect.TypeOf((*MockStore)(nil).FailJob), ctx, arg)
+}
+
 // GetAccount mocks base method.
 func (m *MockStore) GetAccount(ctx context.Context, id int64) (db.Account, error) {
 	m.ctrl.T.Helper()
@@ -297,235 +965,1129 @@ func (m *MockStore) GetAccount(ctx context.Context, id int64) (db.Account, error
 	return ret0, ret1
 }
 
-// GetAccount indicates an expected call of GetAccount.
-func (mr *MockStoreMockRecorder) GetAccount(ctx, id any) *gomock.Call {
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+}
+
+// GetAccountHolder mocks base method.
+func (m *MockStore) GetAccountHolder(ctx context.Context, arg db.GetAccountHolderParams) (db.AccountHolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountHolder", ctx, arg)
+	ret0, _ := ret[0].(db.AccountHolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountHolder indicates an expected call of GetAccountHolder.
+func (mr *MockStoreMockRecorder) GetAccountHolder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountHolder", reflect.TypeOf((*MockStore)(nil).GetAccountHolder), ctx, arg)
+}
+
+// GetBeneficiary mocks base method.
+func (m *MockStore) GetBeneficiary(ctx context.Context, arg db.GetBeneficiaryParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiary", ctx, arg)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiary indicates an expected call of GetBeneficiary.
+func (mr *MockStoreMockRecorder) GetBeneficiary(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiary", reflect.TypeOf((*MockStore)(nil).GetBeneficiary), ctx, arg)
+}
+
+// GetBeneficiaryByAccount mocks base method.
+func (m *MockStore) GetBeneficiaryByAccount(ctx context.Context, arg db.GetBeneficiaryByAccountParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiaryByAccount", ctx, arg)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiaryByAccount indicates an expected call of GetBeneficiaryByAccount.
+func (mr *MockStoreMockRecorder) GetBeneficiaryByAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiaryByAccount", reflect.TypeOf((*MockStore)(nil).GetBeneficiaryByAccount), ctx, arg)
+}
+
+// GetCountry mocks base method.
+func (m *MockStore) GetCountry(ctx context.Context, code int32) (db.Country, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountry", ctx, code)
+	ret0, _ := ret[0].(db.Country)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountry indicates an expected call of GetCountry.
+func (mr *MockStoreMockRecorder) GetCountry(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountry", reflect.TypeOf((*MockStore)(nil).GetCountry), ctx, code)
+}
+
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+}
+
+// GetGLAccountForUpdate mocks base method.
+func (m *MockStore) GetGLAccountForUpdate(ctx context.Context, arg db.GetGLAccountForUpdateParams) (db.GlAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGLAccountForUpdate", ctx, arg)
+	ret0, _ := ret[0].(db.GlAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGLAccountForUpdate indicates an expected call of GetGLAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetGLAccountForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGLAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetGLAccountForUpdate), ctx, arg)
+}
+
+// GetJob mocks base method.
+func (m *MockStore) GetJob(ctx context.Context, id int64) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockStoreMockRecorder) GetJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockStore)(nil).GetJob), ctx, id)
+}
+
+// GetLedgerAdjustment mocks base method.
+func (m *MockStore) GetLedgerAdjustment(ctx context.Context, id int64) (db.LedgerAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedgerAdjustment", ctx, id)
+	ret0, _ := ret[0].(db.LedgerAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLedgerAdjustment indicates an expected call of GetLedgerAdjustment.
+func (mr *MockStoreMockRecorder) GetLedgerAdjustment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerAdjustment", reflect.TypeOf((*MockStore)(nil).GetLedgerAdjustment), ctx, id)
+}
+
+// GetLedgerAdjustmentForUpdate mocks base method.
+func (m *MockStore) GetLedgerAdjustmentForUpdate(ctx context.Context, id int64) (db.LedgerAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedgerAdjustmentForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.LedgerAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLedgerAdjustmentForUpdate indicates an expected call of GetLedgerAdjustmentForUpdate.
+func (mr *MockStoreMockRecorder) GetLedgerAdjustmentForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerAdjustmentForUpdate", reflect.TypeOf((*MockStore)(nil).GetLedgerAdjustmentForUpdate), ctx, id)
+}
+
+// GetMerchant mocks base method.
+func (m *MockStore) GetMerchant(ctx context.Context, id int64) (db.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchant", ctx, id)
+	ret0, _ := ret[0].(db.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchant indicates an expected call of GetMerchant.
+func (mr *MockStoreMockRecorder) GetMerchant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockStore)(nil).GetMerchant), ctx, id)
+}
+
+// GetOrder mocks base method.
+func (m *MockStore) GetOrder(ctx context.Context, id int32) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockStoreMockRecorder) GetOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockStore)(nil).GetOrder), ctx, id)
+}
+
+// GetProduct mocks base method.
+func (m *MockStore) GetProduct(ctx context.Context, id int32) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, id)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockStoreMockRecorder) GetProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), ctx, id)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+}
+
+// GetTransferReview mocks base method.
+func (m *MockStore) GetTransferReview(ctx context.Context, id int64) (db.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferReview", ctx, id)
+	ret0, _ := ret[0].(db.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferReview indicates an expected call of GetTransferReview.
+func (mr *MockStoreMockRecorder) GetTransferReview(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferReview", reflect.TypeOf((*MockStore)(nil).GetTransferReview), ctx, id)
+}
+
+// GetTransferReviewForUpdate mocks base method.
+func (m *MockStore) GetTransferReviewForUpdate(ctx context.Context, id int64) (db.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferReviewForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferReviewForUpdate indicates an expected call of GetTransferReviewForUpdate.
+func (mr *MockStoreMockRecorder) GetTransferReviewForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferReviewForUpdate", reflect.TypeOf((*MockStore)(nil).GetTransferReviewForUpdate), ctx, id)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(ctx context.Context, username string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, username)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, username)
+}
+
+// GetUserPasswordChangedAt mocks base method.
+func (m *MockStore) GetUserPasswordChangedAt(ctx context.Context, username string) (pgtype.Timestamptz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPasswordChangedAt", ctx, username)
+	ret0, _ := ret[0].(pgtype.Timestamptz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPasswordChangedAt indicates an expected call of GetUserPasswordChangedAt.
+func (mr *MockStoreMockRecorder) GetUserPasswordChangedAt(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPasswordChangedAt", reflect.TypeOf((*MockStore)(nil).GetUserPasswordChangedAt), ctx, username)
+}
+
+// GetUserTOTP mocks base method.
+func (m *MockStore) GetUserTOTP(ctx context.Context, username string) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTOTP", ctx, username)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTOTP indicates an expected call of GetUserTOTP.
+func (mr *MockStoreMockRecorder) GetUserTOTP(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTOTP", reflect.TypeOf((*MockStore)(nil).GetUserTOTP), ctx, username)
+}
+
+// GrantRoleTx mocks base method.
+func (m *MockStore) GrantRoleTx(ctx context.Context, arg db.GrantUserRoleParams) (db.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRoleTx", ctx, arg)
+	ret0, _ := ret[0].(db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRoleTx indicates an expected call of GrantRoleTx.
+func (mr *MockStoreMockRecorder) GrantRoleTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRoleTx", reflect.TypeOf((*MockStore)(nil).GrantRoleTx), ctx, arg)
+}
+
+// GrantUserRole mocks base method.
+func (m *MockStore) GrantUserRole(ctx context.Context, arg db.GrantUserRoleParams) (db.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantUserRole", ctx, arg)
+	ret0, _ := ret[0].(db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantUserRole indicates an expected call of GrantUserRole.
+func (mr *MockStoreMockRecorder) GrantUserRole(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantUserRole", reflect.TypeOf((*MockStore)(nil).GrantUserRole), ctx, arg)
+}
+
+// IsMerchantAdmin mocks base method.
+func (m *MockStore) IsMerchantAdmin(ctx context.Context, arg db.IsMerchantAdminParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMerchantAdmin", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMerchantAdmin indicates an expected call of IsMerchantAdmin.
+func (mr *MockStoreMockRecorder) IsMerchantAdmin(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMerchantAdmin", reflect.TypeOf((*MockStore)(nil).IsMerchantAdmin), ctx, arg)
+}
+
+// ListAccountBalanceMismatches mocks base method.
+func (m *MockStore) ListAccountBalanceMismatches(ctx context.Context) ([]db.ListAccountBalanceMismatchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountBalanceMismatches", ctx)
+	ret0, _ := ret[0].([]db.ListAccountBalanceMismatchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountBalanceMismatches indicates an expected call of ListAccountBalanceMismatches.
+func (mr *MockStoreMockRecorder) ListAccountBalanceMismatches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountBalanceMismatches", reflect.TypeOf((*MockStore)(nil).ListAccountBalanceMismatches), ctx)
+}
+
+// ListAccountHolderEvents mocks base method.
+func (m *MockStore) ListAccountHolderEvents(ctx context.Context, accountID int64) ([]db.AccountHolderEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountHolderEvents", ctx, accountID)
+	ret0, _ := ret[0].([]db.AccountHolderEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountHolderEvents indicates an expected call of ListAccountHolderEvents.
+func (mr *MockStoreMockRecorder) ListAccountHolderEvents(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountHolderEvents", reflect.TypeOf((*MockStore)(nil).ListAccountHolderEvents), ctx, accountID)
+}
+
+// ListAccountHolders mocks base method.
+func (m *MockStore) ListAccountHolders(ctx context.Context, accountID int64) ([]db.AccountHolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountHolders", ctx, accountID)
+	ret0, _ := ret[0].([]db.AccountHolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountHolders indicates an expected call of ListAccountHolders.
+func (mr *MockStoreMockRecorder) ListAccountHolders(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountHolders", reflect.TypeOf((*MockStore)(nil).ListAccountHolders), ctx, accountID)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+}
+
+// ListAccountsByHolder mocks base method.
+func (m *MockStore) ListAccountsByHolder(ctx context.Context, arg db.ListAccountsByHolderParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByHolder", ctx, arg)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByHolder indicates an expected call of ListAccountsByHolder.
+func (mr *MockStoreMockRecorder) ListAccountsByHolder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByHolder", reflect.TypeOf((*MockStore)(nil).ListAccountsByHolder), ctx, arg)
+}
+
+// ListAuditEvents mocks base method.
+func (m *MockStore) ListAuditEvents(ctx context.Context, arg db.ListAuditEventsParams) ([]db.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", ctx, arg)
+	ret0, _ := ret[0].([]db.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockStoreMockRecorder) ListAuditEvents(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockStore)(nil).ListAuditEvents), ctx, arg)
+}
+
+// ListBeneficiaries mocks base method.
+func (m *MockStore) ListBeneficiaries(ctx context.Context, arg db.ListBeneficiariesParams) ([]db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBeneficiaries", ctx, arg)
+	ret0, _ := ret[0].([]db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBeneficiaries indicates an expected call of ListBeneficiaries.
+func (mr *MockStoreMockRecorder) ListBeneficiaries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBeneficiaries", reflect.TypeOf((*MockStore)(nil).ListBeneficiaries), ctx, arg)
+}
+
+// ListCountries mocks base method.
+func (m *MockStore) ListCountries(ctx context.Context) ([]db.Country, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCountries", ctx)
+	ret0, _ := ret[0].([]db.Country)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCountries indicates an expected call of ListCountries.
+func (mr *MockStoreMockRecorder) ListCountries(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCountries", reflect.TypeOf((*MockStore)(nil).ListCountries), ctx)
+}
+
+// ListCurrencyImbalances mocks base method.
+func (m *MockStore) ListCurrencyImbalances(ctx context.Context) ([]db.ListCurrencyImbalancesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrencyImbalances", ctx)
+	ret0, _ := ret[0].([]db.ListCurrencyImbalancesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrencyImbalances indicates an expected call of ListCurrencyImbalances.
+func (mr *MockStoreMockRecorder) ListCurrencyImbalances(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencyImbalances", reflect.TypeOf((*MockStore)(nil).ListCurrencyImbalances), ctx)
+}
+
+// ListEntries mocks base method.
+func (m *MockStore) ListEntries(ctx context.Context, arg db.ListEntriesParams) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockStoreMockRecorder) ListEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+}
+
+// ListEntriesByAccount mocks base method.
+func (m *MockStore) ListEntriesByAccount(ctx context.Context, accountID int64) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntriesByAccount", ctx, accountID)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntriesByAccount indicates an expected call of ListEntriesByAccount.
+func (mr *MockStoreMockRecorder) ListEntriesByAccount(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntriesByAccount", reflect.TypeOf((*MockStore)(nil).ListEntriesByAccount), ctx, accountID)
+}
+
+// ListGLAccounts mocks base method.
+func (m *MockStore) ListGLAccounts(ctx context.Context) ([]db.GlAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGLAccounts", ctx)
+	ret0, _ := ret[0].([]db.GlAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGLAccounts indicates an expected call of ListGLAccounts.
+func (mr *MockStoreMockRecorder) ListGLAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGLAccounts", reflect.TypeOf((*MockStore)(nil).ListGLAccounts), ctx)
+}
+
+// ListGLBalanceMismatches mocks base method.
+func (m *MockStore) ListGLBalanceMismatches(ctx context.Context) ([]db.ListGLBalanceMismatchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGLBalanceMismatches", ctx)
+	ret0, _ := ret[0].([]db.ListGLBalanceMismatchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGLBalanceMismatches indicates an expected call of ListGLBalanceMismatches.
+func (mr *MockStoreMockRecorder) ListGLBalanceMismatches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGLBalanceMismatches", reflect.TypeOf((*MockStore)(nil).ListGLBalanceMismatches), ctx)
+}
+
+// ListJobs mocks base method.
+func (m *MockStore) ListJobs(ctx context.Context, arg db.ListJobsParams) ([]db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobs", ctx, arg)
+	ret0, _ := ret[0].([]db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobs indicates an expected call of ListJobs.
+func (mr *MockStoreMockRecorder) ListJobs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockStore)(nil).ListJobs), ctx, arg)
+}
+
+// ListLedgerAdjustments mocks base method.
+func (m *MockStore) ListLedgerAdjustments(ctx context.Context, arg db.ListLedgerAdjustmentsParams) ([]db.LedgerAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLedgerAdjustments", ctx, arg)
+	ret0, _ := ret[0].([]db.LedgerAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLedgerAdjustments indicates an expected call of ListLedgerAdjustments.
+func (mr *MockStoreMockRecorder) ListLedgerAdjustments(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerAdjustments", reflect.TypeOf((*MockStore)(nil).ListLedgerAdjustments), ctx, arg)
+}
+
+// ListMerchantAdmins mocks base method.
+func (m *MockStore) ListMerchantAdmins(ctx context.Context, merchantID int64) ([]db.MerchantAdmin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantAdmins", ctx, merchantID)
+	ret0, _ := ret[0].([]db.MerchantAdmin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantAdmins indicates an expected call of ListMerchantAdmins.
+func (mr *MockStoreMockRecorder) ListMerchantAdmins(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantAdmins", reflect.TypeOf((*MockStore)(nil).ListMerchantAdmins), ctx, merchantID)
+}
+
+// ListMerchantIDsByOrder mocks base method.
+func (m *MockStore) ListMerchantIDsByOrder(ctx context.Context, orderID pgtype.Int4) ([]int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantIDsByOrder", ctx, orderID)
+	ret0, _ := ret[0].([]int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantIDsByOrder indicates an expected call of ListMerchantIDsByOrder.
+func (mr *MockStoreMockRecorder) ListMerchantIDsByOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantIDsByOrder", reflect.TypeOf((*MockStore)(nil).ListMerchantIDsByOrder), ctx, orderID)
+}
+
+// ListMerchants mocks base method.
+func (m *MockStore) ListMerchants(ctx context.Context) ([]db.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchants", ctx)
+	ret0, _ := ret[0].([]db.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchants indicates an expected call of ListMerchants.
+func (mr *MockStoreMockRecorder) ListMerchants(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchants", reflect.TypeOf((*MockStore)(nil).ListMerchants), ctx)
+}
+
+// ListMerchantsByAdminAccount mocks base method.
+func (m *MockStore) ListMerchantsByAdminAccount(ctx context.Context, adminID int32) ([]db.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantsByAdminAccount", ctx, adminID)
+	ret0, _ := ret[0].([]db.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantsByAdminAccount indicates an expected call of ListMerchantsByAdminAccount.
+func (mr *MockStoreMockRecorder) ListMerchantsByAdminAccount(ctx, adminID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantsByAdminAccount", reflect.TypeOf((*MockStore)(nil).ListMerchantsByAdminAccount), ctx, adminID)
+}
+
+// ListOrderItems mocks base method.
+func (m *MockStore) ListOrderItems(ctx context.Context, orderID pgtype.Int4) ([]db.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderItems", ctx, orderID)
+	ret0, _ := ret[0].([]db.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderItems indicates an expected call of ListOrderItems.
+func (mr *MockStoreMockRecorder) ListOrderItems(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderItems", reflect.TypeOf((*MockStore)(nil).ListOrderItems), ctx, orderID)
+}
+
+// ListOrdersByUser mocks base method.
+func (m *MockStore) ListOrdersByUser(ctx context.Context, userID pgtype.Int4) ([]db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrdersByUser", ctx, userID)
+	ret0, _ := ret[0].([]db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrdersByUser indicates an expected call of ListOrdersByUser.
+func (mr *MockStoreMockRecorder) ListOrdersByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersByUser", reflect.TypeOf((*MockStore)(nil).ListOrdersByUser), ctx, userID)
+}
+
+// ListOutboxEvents mocks base method.
+func (m *MockStore) ListOutboxEvents(ctx context.Context, arg db.ListOutboxEventsParams) ([]db.Outbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOutboxEvents", ctx, arg)
+	ret0, _ := ret[0].([]db.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOutboxEvents indicates an expected call of ListOutboxEvents.
+func (mr *MockStoreMockRecorder) ListOutboxEvents(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutboxEvents", reflect.TypeOf((*MockStore)(nil).ListOutboxEvents), ctx, arg)
+}
+
+// ListPendingOutboxEvents mocks base method.
+func (m *MockStore) ListPendingOutboxEvents(ctx context.Context, limit int32) ([]db.Outbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingOutboxEvents", ctx, limit)
+	ret0, _ := ret[0].([]db.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingOutboxEvents indicates an expected call of ListPendingOutboxEvents.
+func (mr *MockStoreMockRecorder) ListPendingOutboxEvents(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingOutboxEvents", reflect.TypeOf((*MockStore)(nil).ListPendingOutboxEvents), ctx, limit)
+}
+
+// ListProductsByMerchant mocks base method.
+func (m *MockStore) ListProductsByMerchant(ctx context.Context, merchantID int32) ([]db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductsByMerchant", ctx, merchantID)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductsByMerchant indicates an expected call of ListProductsByMerchant.
+func (mr *MockStoreMockRecorder) ListProductsByMerchant(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByMerchant", reflect.TypeOf((*MockStore)(nil).ListProductsByMerchant), ctx, merchantID)
+}
+
+// ListRolePermissions mocks base method.
+func (m *MockStore) ListRolePermissions(ctx context.Context) ([]db.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRolePermissions", ctx)
+	ret0, _ := ret[0].([]db.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRolePermissions indicates an expected call of ListRolePermissions.
+func (mr *MockStoreMockRecorder) ListRolePermissions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRolePermissions", reflect.TypeOf((*MockStore)(nil).ListRolePermissions), ctx)
+}
+
+// ListRoles mocks base method.
+func (m *MockStore) ListRoles(ctx context.Context) ([]db.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]db.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockStoreMockRecorder) ListRoles(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockStore)(nil).ListRoles), ctx)
+}
+
+// ListTransferReviews mocks base method.
+func (m *MockStore) ListTransferReviews(ctx context.Context, arg db.ListTransferReviewsParams) ([]db.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferReviews", ctx, arg)
+	ret0, _ := ret[0].([]db.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferReviews indicates an expected call of ListTransferReviews.
+func (mr *MockStoreMockRecorder) ListTransferReviews(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferReviews", reflect.TypeOf((*MockStore)(nil).ListTransferReviews), ctx, arg)
+}
+
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(ctx context.Context) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfers", ctx)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx)
+}
+
+// ListUserPermissions mocks base method.
+func (m *MockStore) ListUserPermissions(ctx context.Context, username string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPermissions", ctx, username)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPermissions indicates an expected call of ListUserPermissions.
+func (mr *MockStoreMockRecorder) ListUserPermissions(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPermissions", reflect.TypeOf((*MockStore)(nil).ListUserPermissions), ctx, username)
+}
+
+// ListUserRoles mocks base method.
+func (m *MockStore) ListUserRoles(ctx context.Context, username string) ([]db.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserRoles", ctx, username)
+	ret0, _ := ret[0].([]db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserRoles indicates an expected call of ListUserRoles.
+func (mr *MockStoreMockRecorder) ListUserRoles(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRoles", reflect.TypeOf((*MockStore)(nil).ListUserRoles), ctx, username)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockStore) ListWebhookDeliveries(ctx context.Context, arg db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ListWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ListWebhookDeliveries), ctx, arg)
+}
+
+// ListWebhookEndpoints mocks base method.
+func (m *MockStore) ListWebhookEndpoints(ctx context.Context, merchantID int64) ([]db.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookEndpoints", ctx, merchantID)
+	ret0, _ := ret[0].([]db.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookEndpoints indicates an expected call of ListWebhookEndpoints.
+func (mr *MockStoreMockRecorder) ListWebhookEndpoints(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookEndpoints", reflect.TypeOf((*MockStore)(nil).ListWebhookEndpoints), ctx, merchantID)
+}
+
+// ListWebhookEndpointsForEvent mocks base method.
+func (m *MockStore) ListWebhookEndpointsForEvent(ctx context.Context, arg db.ListWebhookEndpointsForEventParams) ([]db.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookEndpointsForEvent", ctx, arg)
+	ret0, _ := ret[0].([]db.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookEndpointsForEvent indicates an expected call of ListWebhookEndpointsForEvent.
+func (mr *MockStoreMockRecorder) ListWebhookEndpointsForEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookEndpointsForEvent", reflect.TypeOf((*MockStore)(nil).ListWebhookEndpointsForEvent), ctx, arg)
+}
+
+// MarkOutboxEventPublished mocks base method.
+func (m *MockStore) MarkOutboxEventPublished(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxEventPublished", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOutboxEventPublished indicates an expected call of MarkOutboxEventPublished.
+func (mr *MockStoreMockRecorder) MarkOutboxEventPublished(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxEventPublished", reflect.TypeOf((*MockStore)(nil).MarkOutboxEventPublished), ctx, id)
+}
+
+// MarkUserEmailVerified mocks base method.
+func (m *MockStore) MarkUserEmailVerified(ctx context.Context, arg db.MarkUserEmailVerifiedParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUserEmailVerified", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUserEmailVerified indicates an expected call of MarkUserEmailVerified.
+func (mr *MockStoreMockRecorder) MarkUserEmailVerified(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserEmailVerified", reflect.TypeOf((*MockStore)(nil).MarkUserEmailVerified), ctx, arg)
+}
+
+// MigrationVersion mocks base method.
+func (m *MockStore) MigrationVersion(ctx context.Context) (int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrationVersion", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MigrationVersion indicates an expected call of MigrationVersion.
+func (mr *MockStoreMockRecorder) MigrationVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrationVersion", reflect.TypeOf((*MockStore)(nil).MigrationVersion), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockStore) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockStoreMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockStore)(nil).Ping), ctx)
+}
+
+// RecordAudit mocks base method.
+func (m *MockStore) RecordAudit(ctx context.Context, rec db.AuditRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAudit", ctx, rec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAudit indicates an expected call of RecordAudit.
+func (mr *MockStoreMockRecorder) RecordAudit(ctx, rec any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAudit", reflect.TypeOf((*MockStore)(nil).RecordAudit), ctx, rec)
 }
 
-// GetAccountForUpdate mocks base method.
-func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+// RecordFailedOTP mocks base method.
+func (m *MockStore) RecordFailedOTP(ctx context.Context, arg db.RecordFailedOTPParams) (db.UserTotp, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "RecordFailedOTP", ctx, arg)
+	ret0, _ := ret[0].(db.UserTotp)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
-func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id any) *gomock.Call {
+// RecordFailedOTP indicates an expected call of RecordFailedOTP.
+func (mr *MockStoreMockRecorder) RecordFailedOTP(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedOTP", reflect.TypeOf((*MockStore)(nil).RecordFailedOTP), ctx, arg)
 }
 
-// GetCountry mocks base method.
-func (m *MockStore) GetCountry(ctx context.Context, code int32) (db.Country, error) {
+// RecordOutboxFailure mocks base method.
+func (m *MockStore) RecordOutboxFailure(ctx context.Context, arg db.RecordOutboxFailureParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCountry", ctx, code)
-	ret0, _ := ret[0].(db.Country)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RecordOutboxFailure", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetCountry indicates an expected call of GetCountry.
-func (mr *MockStoreMockRecorder) GetCountry(ctx, code any) *gomock.Call {
+// RecordOutboxFailure indicates an expected call of RecordOutboxFailure.
+func (mr *MockStoreMockRecorder) RecordOutboxFailure(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountry", reflect.TypeOf((*MockStore)(nil).GetCountry), ctx, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOutboxFailure", reflect.TypeOf((*MockStore)(nil).RecordOutboxFailure), ctx, arg)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+// RecordWebhookDeliveryAttempt mocks base method.
+func (m *MockStore) RecordWebhookDeliveryAttempt(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
-	ret0, _ := ret[0].(db.Entry)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RecordWebhookDeliveryAttempt", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+// RecordWebhookDeliveryAttempt indicates an expected call of RecordWebhookDeliveryAttempt.
+func (mr *MockStoreMockRecorder) RecordWebhookDeliveryAttempt(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookDeliveryAttempt", reflect.TypeOf((*MockStore)(nil).RecordWebhookDeliveryAttempt), ctx, arg)
 }
 
-// GetMerchant mocks base method.
-func (m *MockStore) GetMerchant(ctx context.Context, id int64) (db.Merchant, error) {
+// RemoveAccountHolderTx mocks base method.
+func (m *MockStore) RemoveAccountHolderTx(ctx context.Context, arg db.RemoveAccountHolderTxParams) (db.AccountHolder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMerchant", ctx, id)
-	ret0, _ := ret[0].(db.Merchant)
+	ret := m.ctrl.Call(m, "RemoveAccountHolderTx", ctx, arg)
+	ret0, _ := ret[0].(db.AccountHolder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMerchant indicates an expected call of GetMerchant.
-func (mr *MockStoreMockRecorder) GetMerchant(ctx, id any) *gomock.Call {
+// RemoveAccountHolderTx indicates an expected call of RemoveAccountHolderTx.
+func (mr *MockStoreMockRecorder) RemoveAccountHolderTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockStore)(nil).GetMerchant), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAccountHolderTx", reflect.TypeOf((*MockStore)(nil).RemoveAccountHolderTx), ctx, arg)
 }
 
-// GetOrder mocks base method.
-func (m *MockStore) GetOrder(ctx context.Context, id int32) (db.Order, error) {
+// RemoveMerchantAdminTx mocks base method.
+func (m *MockStore) RemoveMerchantAdminTx(ctx context.Context, arg db.DeleteMerchantAdminParams) (db.MerchantAdmin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
-	ret0, _ := ret[0].(db.Order)
+	ret := m.ctrl.Call(m, "RemoveMerchantAdminTx", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantAdmin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrder indicates an expected call of GetOrder.
-func (mr *MockStoreMockRecorder) GetOrder(ctx, id any) *gomock.Call {
+// RemoveMerchantAdminTx indicates an expected call of RemoveMerchantAdminTx.
+func (mr *MockStoreMockRecorder) RemoveMerchantAdminTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockStore)(nil).GetOrder), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMerchantAdminTx", reflect.TypeOf((*MockStore)(nil).RemoveMerchantAdminTx), ctx, arg)
 }
 
-// GetProduct mocks base method.
-func (m *MockStore) GetProduct(ctx context.Context, id int32) (db.Product, error) {
+// RequestAdjustmentTx mocks base method.
+func (m *MockStore) RequestAdjustmentTx(ctx context.Context, arg db.CreateLedgerAdjustmentParams) (db.LedgerAdjustment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProduct", ctx, id)
-	ret0, _ := ret[0].(db.Product)
+	ret := m.ctrl.Call(m, "RequestAdjustmentTx", ctx, arg)
+	ret0, _ := ret[0].(db.LedgerAdjustment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProduct indicates an expected call of GetProduct.
-func (mr *MockStoreMockRecorder) GetProduct(ctx, id any) *gomock.Call {
+// RequestAdjustmentTx indicates an expected call of RequestAdjustmentTx.
+func (mr *MockStoreMockRecorder) RequestAdjustmentTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAdjustmentTx", reflect.TypeOf((*MockStore)(nil).RequestAdjustmentTx), ctx, arg)
 }
 
-// GetTransfer mocks base method.
-func (m *MockStore) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
+// RequeueStaleJobs mocks base method.
+func (m *MockStore) RequeueStaleJobs(ctx context.Context, lockedBefore pgtype.Timestamptz) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "RequeueStaleJobs", ctx, lockedBefore)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransfer indicates an expected call of GetTransfer.
-func (mr *MockStoreMockRecorder) GetTransfer(ctx, id any) *gomock.Call {
+// RequeueStaleJobs indicates an expected call of RequeueStaleJobs.
+func (mr *MockStoreMockRecorder) RequeueStaleJobs(ctx, lockedBefore any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueStaleJobs", reflect.TypeOf((*MockStore)(nil).RequeueStaleJobs), ctx, lockedBefore)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+// ResetFailedOTPs mocks base method.
+func (m *MockStore) ResetFailedOTPs(ctx context.Context, username string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
-	ret0, _ := ret[0].([]db.Account)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ResetFailedOTPs", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg any) *gomock.Call {
+// ResetFailedOTPs indicates an expected call of ResetFailedOTPs.
+func (mr *MockStoreMockRecorder) ResetFailedOTPs(ctx, username any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailedOTPs", reflect.TypeOf((*MockStore)(nil).ResetFailedOTPs), ctx, username)
 }
 
-// ListCountries mocks base method.
-func (m *MockStore) ListCountries(ctx context.Context) ([]db.Country, error) {
+// ResetPasswordTx mocks base method.
+func (m *MockStore) ResetPasswordTx(ctx context.Context, arg db.ResetPasswordTxParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCountries", ctx)
-	ret0, _ := ret[0].([]db.Country)
+	ret := m.ctrl.Call(m, "ResetPasswordTx", ctx, arg)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCountries indicates an expected call of ListCountries.
-func (mr *MockStoreMockRecorder) ListCountries(ctx any) *gomock.Call {
+// ResetPasswordTx indicates an expected call of ResetPasswordTx.
+func (mr *MockStoreMockRecorder) ResetPasswordTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCountries", reflect.TypeOf((*MockStore)(nil).ListCountries), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordTx", reflect.TypeOf((*MockStore)(nil).ResetPasswordTx), ctx, arg)
 }
 
-// ListEntriesByAccount mocks base method.
-func (m *MockStore) ListEntriesByAccount(ctx context.Context, accountID int64) ([]db.Entry, error) {
+// RetryJob mocks base method.
+func (m *MockStore) RetryJob(ctx context.Context, arg db.RetryJobParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEntriesByAccount", ctx, accountID)
-	ret0, _ := ret[0].([]db.Entry)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RetryJob", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListEntriesByAccount indicates an expected call of ListEntriesByAccount.
-func (mr *MockStoreMockRecorder) ListEntriesByAccount(ctx, accountID any) *gomock.Call {
+// RetryJob indicates an expected call of RetryJob.
+func (mr *MockStoreMockRecorder) RetryJob(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntriesByAccount", reflect.TypeOf((*MockStore)(nil).ListEntriesByAccount), ctx, accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryJob", reflect.TypeOf((*MockStore)(nil).RetryJob), ctx, arg)
 }
 
-// ListMerchants mocks base method.
-func (m *MockStore) ListMerchants(ctx context.Context) ([]db.Merchant, error) {
+// RetryWebhookDelivery mocks base method.
+func (m *MockStore) RetryWebhookDelivery(ctx context.Context, arg db.RetryWebhookDeliveryParams) (db.WebhookDelivery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListMerchants", ctx)
-	ret0, _ := ret[0].([]db.Merchant)
+	ret := m.ctrl.Call(m, "RetryWebhookDelivery", ctx, arg)
+	ret0, _ := ret[0].(db.WebhookDelivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListMerchants indicates an expected call of ListMerchants.
-func (mr *MockStoreMockRecorder) ListMerchants(ctx any) *gomock.Call {
+// RetryWebhookDelivery indicates an expected call of RetryWebhookDelivery.
+func (mr *MockStoreMockRecorder) RetryWebhookDelivery(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchants", reflect.TypeOf((*MockStore)(nil).ListMerchants), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryWebhookDelivery", reflect.TypeOf((*MockStore)(nil).RetryWebhookDelivery), ctx, arg)
 }
 
-// ListOrderItems mocks base method.
-func (m *MockStore) ListOrderItems(ctx context.Context, orderID pgtype.Int4) ([]db.OrderItem, error) {
+// ReviewTransferTx mocks base method.
+func (m *MockStore) ReviewTransferTx(ctx context.Context, arg db.ReviewTransferTxParams) (db.ReviewTransferTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrderItems", ctx, orderID)
-	ret0, _ := ret[0].([]db.OrderItem)
+	ret := m.ctrl.Call(m, "ReviewTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.ReviewTransferTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrderItems indicates an expected call of ListOrderItems.
-func (mr *MockStoreMockRecorder) ListOrderItems(ctx, orderID any) *gomock.Call {
+// ReviewTransferTx indicates an expected call of ReviewTransferTx.
+func (mr *MockStoreMockRecorder) ReviewTransferTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderItems", reflect.TypeOf((*MockStore)(nil).ListOrderItems), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewTransferTx", reflect.TypeOf((*MockStore)(nil).ReviewTransferTx), ctx, arg)
 }
 
-// ListOrdersByUser mocks base method.
-func (m *MockStore) ListOrdersByUser(ctx context.Context, userID pgtype.Int4) ([]db.Order, error) {
+// RevokeRoleTx mocks base method.
+func (m *MockStore) RevokeRoleTx(ctx context.Context, arg db.RevokeUserRoleParams) (db.UserRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrdersByUser", ctx, userID)
-	ret0, _ := ret[0].([]db.Order)
+	ret := m.ctrl.Call(m, "RevokeRoleTx", ctx, arg)
+	ret0, _ := ret[0].(db.UserRole)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrdersByUser indicates an expected call of ListOrdersByUser.
-func (mr *MockStoreMockRecorder) ListOrdersByUser(ctx, userID any) *gomock.Call {
+// RevokeRoleTx indicates an expected call of RevokeRoleTx.
+func (mr *MockStoreMockRecorder) RevokeRoleTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersByUser", reflect.TypeOf((*MockStore)(nil).ListOrdersByUser), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRoleTx", reflect.TypeOf((*MockStore)(nil).RevokeRoleTx), ctx, arg)
 }
 
-// ListProductsByMerchant mocks base method.
-func (m *MockStore) ListProductsByMerchant(ctx context.Context, merchantID int32) ([]db.Product, error) {
+// RevokeUserRole mocks base method.
+func (m *MockStore) RevokeUserRole(ctx context.Context, arg db.RevokeUserRoleParams) (db.UserRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProductsByMerchant", ctx, merchantID)
-	ret0, _ := ret[0].([]db.Product)
+	ret := m.ctrl.Call(m, "RevokeUserRole", ctx, arg)
+	ret0, _ := ret[0].(db.UserRole)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProductsByMerchant indicates an expected call of ListProductsByMerchant.
-func (mr *MockStoreMockRecorder) ListProductsByMerchant(ctx, merchantID any) *gomock.Call {
+// RevokeUserRole indicates an expected call of RevokeUserRole.
+func (mr *MockStoreMockRecorder) RevokeUserRole(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByMerchant", reflect.TypeOf((*MockStore)(nil).ListProductsByMerchant), ctx, merchantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRole", reflect.TypeOf((*MockStore)(nil).RevokeUserRole), ctx, arg)
 }
 
-// ListTransfers mocks base method.
-func (m *MockStore) ListTransfers(ctx context.Context) ([]db.Transfer, error) {
+// SearchUsers mocks base method.
+func (m *MockStore) SearchUsers(ctx context.Context, arg db.SearchUsersParams) ([]db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTransfers", ctx)
-	ret0, _ := ret[0].([]db.Transfer)
+	ret := m.ctrl.Call(m, "SearchUsers", ctx, arg)
+	ret0, _ := ret[0].([]db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTransfers indicates an expected call of ListTransfers.
-func (mr *MockStoreMockRecorder) ListTransfers(ctx any) *gomock.Call {
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockStoreMockRecorder) SearchUsers(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockStore)(nil).SearchUsers), ctx, arg)
 }
 
 // TransferTx mocks base method.
@@ -543,6 +2105,22 @@ func (mr *MockStoreMockRecorder) TransferTx(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), ctx, arg)
 }
 
+// TryLockOutbox mocks base method.
+func (m *MockStore) TryLockOutbox(ctx context.Context) (func(), bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLockOutbox", ctx)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TryLockOutbox indicates an expected call of TryLockOutbox.
+func (mr *MockStoreMockRecorder) TryLockOutbox(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLockOutbox", reflect.TypeOf((*MockStore)(nil).TryLockOutbox), ctx)
+}
+
 // UpdateAccount mocks base method.
 func (m *MockStore) UpdateAccount(ctx context.Context, arg db.UpdateAccountParams) error {
 	m.ctrl.T.Helper()
@@ -557,6 +2135,51 @@ func (mr *MockStoreMockRecorder) UpdateAccount(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), ctx, arg)
 }
 
+// UpdateAccountStatus mocks base method.
+func (m *MockStore) UpdateAccountStatus(ctx context.Context, arg db.UpdateAccountStatusParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountStatus", ctx, arg)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountStatus indicates an expected call of UpdateAccountStatus.
+func (mr *MockStoreMockRecorder) UpdateAccountStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatus", reflect.TypeOf((*MockStore)(nil).UpdateAccountStatus), ctx, arg)
+}
+
+// UpdateAccountStatusTx mocks base method.
+func (m *MockStore) UpdateAccountStatusTx(ctx context.Context, arg db.UpdateAccountStatusTxParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountStatusTx", ctx, arg)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountStatusTx indicates an expected call of UpdateAccountStatusTx.
+func (mr *MockStoreMockRecorder) UpdateAccountStatusTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatusTx", reflect.TypeOf((*MockStore)(nil).UpdateAccountStatusTx), ctx, arg)
+}
+
+// UpdateBeneficiaryNickname mocks base method.
+func (m *MockStore) UpdateBeneficiaryNickname(ctx context.Context, arg db.UpdateBeneficiaryNicknameParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBeneficiaryNickname", ctx, arg)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBeneficiaryNickname indicates an expected call of UpdateBeneficiaryNickname.
+func (mr *MockStoreMockRecorder) UpdateBeneficiaryNickname(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBeneficiaryNickname", reflect.TypeOf((*MockStore)(nil).UpdateBeneficiaryNickname), ctx, arg)
+}
+
 // UpdateCountry mocks base method.
 func (m *MockStore) UpdateCountry(ctx context.Context, arg db.UpdateCountryParams) error {
 	m.ctrl.T.Helper()
@@ -613,6 +2236,36 @@ func (mr *MockStoreMockRecorder) UpdateOrderStatus(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockStore)(nil).UpdateOrderStatus), ctx, arg)
 }
 
+// UpdateOrderStatusTx mocks base method.
+func (m *MockStore) UpdateOrderStatusTx(ctx context.Context, arg db.UpdateOrderStatusParams) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatusTx", ctx, arg)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderStatusTx indicates an expected call of UpdateOrderStatusTx.
+func (mr *MockStoreMockRecorder) UpdateOrderStatusTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatusTx", reflect.TypeOf((*MockStore)(nil).UpdateOrderStatusTx), ctx, arg)
+}
+
+// UpdatePasswordTx mocks base method.
+func (m *MockStore) UpdatePasswordTx(ctx context.Context, arg db.UpdateUserPasswordParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordTx", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePasswordTx indicates an expected call of UpdatePasswordTx.
+func (mr *MockStoreMockRecorder) UpdatePasswordTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordTx", reflect.TypeOf((*MockStore)(nil).UpdatePasswordTx), ctx, arg)
+}
+
 // UpdateProduct mocks base method.
 func (m *MockStore) UpdateProduct(ctx context.Context, arg db.UpdateProductParams) error {
 	m.ctrl.T.Helper()
@@ -626,3 +2279,153 @@ func (mr *MockStoreMockRecorder) UpdateProduct(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockStore)(nil).UpdateProduct), ctx, arg)
 }
+
+// UpdateTransferReview mocks base method.
+func (m *MockStore) UpdateTransferReview(ctx context.Context, arg db.UpdateTransferReviewParams) (db.TransferReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferReview", ctx, arg)
+	ret0, _ := ret[0].(db.TransferReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransferReview indicates an expected call of UpdateTransferReview.
+func (mr *MockStoreMockRecorder) UpdateTransferReview(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferReview", reflect.TypeOf((*MockStore)(nil).UpdateTransferReview), ctx, arg)
+}
+
+// UpdateUserKYC mocks base method.
+func (m *MockStore) UpdateUserKYC(ctx context.Context, arg db.UpdateUserKYCParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserKYC", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserKYC indicates an expected call of UpdateUserKYC.
+func (mr *MockStoreMockRecorder) UpdateUserKYC(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserKYC", reflect.TypeOf((*MockStore)(nil).UpdateUserKYC), ctx, arg)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockStore) UpdateUserPassword(ctx context.Context, arg db.UpdateUserPasswordParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockStoreMockRecorder) UpdateUserPassword(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserPassword), ctx, arg)
+}
+
+// UpsertUserTOTP mocks base method.
+func (m *MockStore) UpsertUserTOTP(ctx context.Context, arg db.UpsertUserTOTPParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserTOTP", ctx, arg)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserTOTP indicates an expected call of UpsertUserTOTP.
+func (mr *MockStoreMockRecorder) UpsertUserTOTP(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserTOTP", reflect.TypeOf((*MockStore)(nil).UpsertUserTOTP), ctx, arg)
+}
+
+// UsePasswordReset mocks base method.
+func (m *MockStore) UsePasswordReset(ctx context.Context, tokenHash string) (db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordReset", ctx, tokenHash)
+	ret0, _ := ret[0].(db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePasswordReset indicates an expected call of UsePasswordReset.
+func (mr *MockStoreMockRecorder) UsePasswordReset(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordReset", reflect.TypeOf((*MockStore)(nil).UsePasswordReset), ctx, tokenHash)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockStore) UseRecoveryCode(ctx context.Context, arg db.UseRecoveryCodeParams) (db.RecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, arg)
+	ret0, _ := ret[0].(db.RecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockStoreMockRecorder) UseRecoveryCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockStore)(nil).UseRecoveryCode), ctx, arg)
+}
+
+// UseUserTOTPStep mocks base method.
+func (m *MockStore) UseUserTOTPStep(ctx context.Context, arg db.UseUserTOTPStepParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseUserTOTPStep", ctx, arg)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseUserTOTPStep indicates an expected call of UseUserTOTPStep.
+func (mr *MockStoreMockRecorder) UseUserTOTPStep(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseUserTOTPStep", reflect.TypeOf((*MockStore)(nil).UseUserTOTPStep), ctx, arg)
+}
+
+// UseVerifyEmail mocks base method.
+func (m *MockStore) UseVerifyEmail(ctx context.Context, arg db.UseVerifyEmailParams) (db.VerifyEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseVerifyEmail", ctx, arg)
+	ret0, _ := ret[0].(db.VerifyEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseVerifyEmail indicates an expected call of UseVerifyEmail.
+func (mr *MockStoreMockRecorder) UseVerifyEmail(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseVerifyEmail", reflect.TypeOf((*MockStore)(nil).UseVerifyEmail), ctx, arg)
+}
+
+// VerifyBeneficiary mocks base method.
+func (m *MockStore) VerifyBeneficiary(ctx context.Context, arg db.VerifyBeneficiaryParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyBeneficiary", ctx, arg)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyBeneficiary indicates an expected call of VerifyBeneficiary.
+func (mr *MockStoreMockRecorder) VerifyBeneficiary(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBeneficiary", reflect.TypeOf((*MockStore)(nil).VerifyBeneficiary), ctx, arg)
+}
+
+// VerifyEmailTx mocks base method.
+func (m *MockStore) VerifyEmailTx(ctx context.Context, arg db.VerifyEmailTxParams) (db.VerifyEmailTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmailTx", ctx, arg)
+	ret0, _ := ret[0].(db.VerifyEmailTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmailTx indicates an expected call of VerifyEmailTx.
+func (mr *MockStoreMockRecorder) VerifyEmailTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailTx", reflect.TypeOf((*MockStore)(nil).VerifyEmailTx), ctx, arg)
+}
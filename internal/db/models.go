@@ -6,6 +6,7 @@ package db
 
 import (
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 
 	"github.com/jackc/pgx/v5/pgtype"
@@ -59,8 +60,58 @@ type Account struct {
 	Balance     int64
 	Currency    string
 	CountryCode int32
-	CreatedAt   pgtype.Timestamptz
-	UpdatedAt   pgtype.Timestamptz
+	// active, frozen, debit_blocked or closed
+	Status string
+	// customer or staff while the account is frozen; only staff can lift a staff freeze
+	FrozenBy  pgtype.Text
+	ClosedAt  pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+}
+
+type AccountHolder struct {
+	AccountID int64
+	Username  string
+	// owner, signatory or viewer
+	Role      string
+	CreatedAt pgtype.Timestamptz
+}
+
+type AccountHolderEvent struct {
+	ID        int64
+	AccountID int64
+	Username  string
+	Role      string
+	Action    string
+	// username of the holder who made the change
+	Actor     string
+	CreatedAt pgtype.Timestamptz
+}
+
+// append-only, updates, deletes and truncates are rejected by audit_events_immutable
+type AuditEvent struct {
+	ID int64
+	// username that made the change, or anonymous
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Before       json.RawMessage
+	After        json.RawMessage
+	Ip           string
+	RequestID    string
+	CreatedAt    pgtype.Timestamptz
+}
+
+type Beneficiary struct {
+	ID int64
+	// user who saved the payee
+	Username  string
+	Nickname  string
+	AccountID int64
+	// the user confirmed the payee, transfers to it skip the new payee confirmation
+	Verified  bool
+	CreatedAt pgtype.Timestamptz
 }
 
 type Country struct {
@@ -77,12 +128,70 @@ type Entry struct {
 	CreatedAt pgtype.Timestamptz
 }
 
+// the bank's own ledger accounts, one per code and currency, on the other side of manual adjustments
+type GlAccount struct {
+	ID        int64
+	Code      string
+	Currency  string
+	Balance   int64
+	CreatedAt pgtype.Timestamptz
+}
+
+type GlEntry struct {
+	ID          int64
+	GlAccountID int64
+	Amount      int64
+	CreatedAt   pgtype.Timestamptz
+}
+
+type Job struct {
+	ID      int64
+	Kind    string
+	Payload json.RawMessage
+	// pending, running, succeeded or failed once max_attempts is used up
+	Status string
+	// at most one pending or running job per key
+	UniqueKey   pgtype.Text
+	Attempts    int32
+	MaxAttempts int32
+	RunAt       pgtype.Timestamptz
+	LockedAt    pgtype.Timestamptz
+	LastError   pgtype.Text
+	FinishedAt  pgtype.Timestamptz
+	CreatedAt   pgtype.Timestamptz
+}
+
+type LedgerAdjustment struct {
+	ID        int64
+	AccountID int64
+	GlCode    string
+	// credited to the customer account when positive, debited when negative; the GL account gets the opposite
+	Amount     int64
+	ReasonCode string
+	Note       string
+	// pending_approval, approved or rejected; decided_by must differ from requested_by
+	Status      string
+	RequestedBy string
+	DecidedBy   pgtype.Text
+	DecidedAt   pgtype.Timestamptz
+	EntryID     pgtype.Int8
+	GlEntryID   pgtype.Int8
+	CreatedAt   pgtype.Timestamptz
+}
+
 type Merchant struct {
 	ID           int64
 	MerchantName string
 	CountryCode  int32
 	CreatedAt    pgtype.Timestamptz
-	AdminID      int32
+	// account that receives the merchant's payments, the users administering the merchant are in merchant_admins
+	AdminID int32
+}
+
+type MerchantAdmin struct {
+	MerchantID int64
+	Username   string
+	CreatedAt  pgtype.Timestamptz
 }
 
 type Order struct {
@@ -98,6 +207,34 @@ type OrderItem struct {
 	Quantity  pgtype.Int4
 }
 
+// domain events written in the same transaction as the change, published in id order by the dispatcher
+type Outbox struct {
+	ID            int64
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Payload       json.RawMessage
+	Attempts      int32
+	LastError     pgtype.Text
+	PublishedAt   pgtype.Timestamptz
+	CreatedAt     pgtype.Timestamptz
+}
+
+type PasswordReset struct {
+	ID       int64
+	Username string
+	// SHA-256 of the emailed token, the token itself is never stored
+	TokenHash string
+	UsedAt    pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+	ExpiredAt pgtype.Timestamptz
+}
+
+type Permission struct {
+	Name        string
+	Description string
+}
+
 type Product struct {
 	ID         int32
 	Name       string
@@ -107,6 +244,25 @@ type Product struct {
 	CreatedAt  pgtype.Timestamptz
 }
 
+type RecoveryCode struct {
+	ID       int64
+	Username string
+	// SHA-256 of the normalized code
+	CodeHash  string
+	UsedAt    pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type Role struct {
+	Name        string
+	Description string
+}
+
+type RolePermission struct {
+	Role       string
+	Permission string
+}
+
 type Transfer struct {
 	ID            int64
 	FromAccountID int64
@@ -116,13 +272,98 @@ type Transfer struct {
 	CreatedAt pgtype.Timestamptz
 }
 
+type TransferReview struct {
+	ID            int64
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        int64
+	Reasons       []string
+	// pending_review, approved or rejected
+	Status     string
+	TransferID pgtype.Int8
+	ReviewedBy pgtype.Text
+	ReviewedAt pgtype.Timestamptz
+	CreatedAt  pgtype.Timestamptz
+}
+
 type User struct {
-	ID                int64
-	Username          string
-	HashedPassword    string
-	FullName          string
-	Email             string
+	ID             int64
+	Username       string
+	HashedPassword string
+	FullName       string
+	Email          string
+	DateOfBirth    pgtype.Date
+	Address        pgtype.Text
+	NationalID     pgtype.Text
+	KycStatus      string
+	// limits account count and transfer size, see internal/kyc
+	KycTier           int32
+	KycVerifiedAt     pgtype.Timestamptz
+	IsEmailVerified   bool
 	PasswordChangedAt pgtype.Timestamptz
 	CreatedAt         pgtype.Timestamptz
 	UpdatedAt         pgtype.Timestamptz
 }
+
+type UserRole struct {
+	Username string
+	Role     string
+	// username of the admin who granted the role, or system
+	GrantedBy string
+	CreatedAt pgtype.Timestamptz
+}
+
+type UserTotp struct {
+	Username string
+	// base32 TOTP key, sealed with AES-GCM under TOTP_ENCRYPTION_KEY as codes need it in the clear
+	Secret string
+	// set once the user proved their app has the secret, a second factor is only required after that
+	ConfirmedAt pgtype.Timestamptz
+	// time step of the last accepted code, a code is never accepted twice
+	LastUsedStep int64
+	// invalid codes since the last valid one or lockout
+	FailedAttempts int32
+	// no code is accepted before then, after too many invalid ones
+	LockedUntil pgtype.Timestamptz
+	CreatedAt   pgtype.Timestamptz
+}
+
+type VerifyEmail struct {
+	ID       int64
+	Username string
+	// address the code was sent to, the user may change theirs later
+	Email      string
+	SecretCode string
+	IsUsed     bool
+	CreatedAt  pgtype.Timestamptz
+	ExpiredAt  pgtype.Timestamptz
+}
+
+type WebhookDelivery struct {
+	ID         int64
+	EndpointID int64
+	// outbox event that triggered the delivery
+	EventID   int64
+	EventType string
+	Payload   json.RawMessage
+	// pending, succeeded or dead once retries are exhausted
+	Status         string
+	Attempts       int32
+	NextAttemptAt  pgtype.Timestamptz
+	LastError      pgtype.Text
+	ResponseStatus pgtype.Int4
+	DeliveredAt    pgtype.Timestamptz
+	CreatedAt      pgtype.Timestamptz
+}
+
+type WebhookEndpoint struct {
+	ID         int64
+	MerchantID int64
+	Url        string
+	// HMAC-SHA256 key used to sign deliveries
+	Secret string
+	// event types to deliver, empty for all
+	EventTypes []string
+	Active     bool
+	CreatedAt  pgtype.Timestamptz
+}
@@ -0,0 +1,42 @@
+package db
+
+import "context"
+
+// CreateOrderTx creates an order and publishes it through the outbox
+func (store *SQLStore) CreateOrderTx(ctx context.Context, arg CreateOrderParams) (Order, error) {
+	var order Order
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		order, err = q.CreateOrder(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return q.enqueueEvent(ctx, "order", order.ID, EventOrderCreated, order)
+	})
+
+	return order, err
+}
+
+// UpdateOrderStatusTx changes an order's status and publishes the updated order through the outbox
+func (store *SQLStore) UpdateOrderStatusTx(ctx context.Context, arg UpdateOrderStatusParams) (Order, error) {
+	var order Order
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.UpdateOrderStatus(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		order, err = q.GetOrder(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.enqueueEvent(ctx, "order", order.ID, EventOrderStatusChanged, order)
+	})
+
+	return order, err
+}
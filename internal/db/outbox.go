@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Domain events written to the outbox
+const (
+	EventAccountCreated       = "account.created"
+	EventAccountStatusChanged = "account.status_changed"
+	EventAccountClosed        = "account.closed"
+	EventTransferCreated      = "transfer.created"
+	EventOrderCreated         = "order.created"
+	EventOrderStatusChanged   = "order.status_changed"
+)
+
+// enqueueEvent writes a domain event to the outbox using q.
+// Called inside execTx, the event is only published if the change it describes commits.
+func (q *Queries) enqueueEvent(ctx context.Context, aggregateType string, aggregateID any, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("cannot marshal %s event: %w", eventType, err)
+	}
+
+	_, err = q.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+		AggregateType: aggregateType,
+		AggregateID:   fmt.Sprint(aggregateID),
+		EventType:     eventType,
+		Payload:       data,
+	})
+	return err
+}
+
+// outboxLockID keys the advisory lock that lets a single dispatcher publish outbox events at a time
+const outboxLockID int64 = 0x6f7574626f78
+
+// TryLockOutbox takes the outbox dispatcher lock, a session advisory lock held on a connection of its own,
+// and returns false if another dispatcher holds it. unlock releases the lock and the connection.
+func (store *SQLStore) TryLockOutbox(ctx context.Context) (unlock func(), locked bool, err error) {
+	conn, err := store.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", outboxLockID).Scan(&locked)
+	if err != nil || !locked {
+		conn.Release()
+		return nil, false, err
+	}
+
+	unlock = func() {
+		ctx := context.WithoutCancel(ctx)
+		if _, err := conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", outboxLockID); err != nil {
+			// a connection that may still hold the lock must not go back to the pool
+			conn.Hijack().Close(ctx)
+			return
+		}
+		conn.Release()
+	}
+	return unlock, true, nil
+}
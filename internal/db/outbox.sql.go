@@ -0,0 +1,161 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: outbox.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :one
+INSERT INTO outbox (
+  aggregate_type, aggregate_id, event_type, payload
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING id, aggregate_type, aggregate_id, event_type, payload, attempts, last_error, published_at, created_at
+`
+
+type CreateOutboxEventParams struct {
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Payload       json.RawMessage
+}
+
+// OUTBOX
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (Outbox, error) {
+	row := q.db.QueryRow(ctx, createOutboxEvent,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.EventType,
+		arg.Payload,
+	)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.AggregateType,
+		&i.AggregateID,
+		&i.EventType,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.PublishedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listOutboxEvents = `-- name: ListOutboxEvents :many
+SELECT id, aggregate_type, aggregate_id, event_type, payload, attempts, last_error, published_at, created_at FROM outbox
+WHERE ($1::bool IS NULL OR (published_at IS NOT NULL) = $1)
+ORDER BY id DESC
+LIMIT $3
+OFFSET $2
+`
+
+type ListOutboxEventsParams struct {
+	Published pgtype.Bool
+	Offset    int32
+	Limit     int32
+}
+
+func (q *Queries) ListOutboxEvents(ctx context.Context, arg ListOutboxEventsParams) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, listOutboxEvents, arg.Published, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.AggregateType,
+			&i.AggregateID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.PublishedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingOutboxEvents = `-- name: ListPendingOutboxEvents :many
+SELECT id, aggregate_type, aggregate_id, event_type, payload, attempts, last_error, published_at, created_at FROM outbox
+WHERE published_at IS NULL
+ORDER BY id
+LIMIT $1
+`
+
+func (q *Queries) ListPendingOutboxEvents(ctx context.Context, limit int32) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, listPendingOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.AggregateType,
+			&i.AggregateID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.PublishedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventPublished = `-- name: MarkOutboxEventPublished :exec
+UPDATE outbox
+SET published_at = now()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventPublished(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, markOutboxEventPublished, id)
+	return err
+}
+
+const recordOutboxFailure = `-- name: RecordOutboxFailure :exec
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = $2
+WHERE id = $1
+`
+
+type RecordOutboxFailureParams struct {
+	ID        int64
+	LastError pgtype.Text
+}
+
+func (q *Queries) RecordOutboxFailure(ctx context.Context, arg RecordOutboxFailureParams) error {
+	_, err := q.db.Exec(ctx, recordOutboxFailure, arg.ID, arg.LastError)
+	return err
+}
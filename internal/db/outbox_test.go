@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pendingEventsFor returns the unpublished outbox events of one aggregate
+func pendingEventsFor(t *testing.T, aggregateType string, aggregateID int64) []Outbox {
+	rows, err := testQueries.ListPendingOutboxEvents(context.Background(), 1000)
+	require.NoError(t, err)
+
+	var events []Outbox
+	for _, row := range rows {
+		if row.AggregateType == aggregateType && row.AggregateID == strconv.FormatInt(aggregateID, 10) {
+			events = append(events, row)
+		}
+	}
+	return events
+}
+
+func TestTransferTxWritesOutbox(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	events := pendingEventsFor(t, "transfer", result.Transfer.ID)
+	require.Len(t, events, 1)
+	require.Equal(t, EventTransferCreated, events[0].EventType)
+
+	var payload TransferTxResult
+	require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+	require.Equal(t, result.Transfer.ID, payload.Transfer.ID)
+
+	err = testQueries.MarkOutboxEventPublished(context.Background(), events[0].ID)
+	require.NoError(t, err)
+	require.Empty(t, pendingEventsFor(t, "transfer", result.Transfer.ID))
+}
+
+func TestUpdateAccountStatusTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+
+	updated, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		ID:     account.ID,
+		Status: AccountFrozen,
+		SetBy:  FrozenByCustomer,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountFrozen, updated.Status)
+
+	events := pendingEventsFor(t, "account", account.ID)
+	require.Len(t, events, 1)
+	require.Equal(t, EventAccountStatusChanged, events[0].EventType)
+
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		ID:     account.ID,
+		Status: AccountClosed,
+		SetBy:  FrozenByCustomer,
+	})
+	require.NoError(t, err)
+
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		ID:     account.ID,
+		Status: AccountActive,
+		SetBy:  FrozenByCustomer,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+}
+
+func TestTryLockOutbox(t *testing.T) {
+	store := NewStore(testDB)
+
+	unlock, locked, err := store.TryLockOutbox(context.Background())
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	// a second dispatcher skips its turn
+	_, locked, err = store.TryLockOutbox(context.Background())
+	require.NoError(t, err)
+	require.False(t, locked)
+
+	unlock()
+
+	unlock, locked, err = store.TryLockOutbox(context.Background())
+	require.NoError(t, err)
+	require.True(t, locked)
+	unlock()
+}
@@ -0,0 +1,73 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: password_resets.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createPasswordReset = `-- name: CreatePasswordReset :one
+INSERT INTO password_resets (
+  username, token_hash
+) VALUES (
+  $1, $2
+)
+RETURNING id, username, token_hash, used_at, created_at, expired_at
+`
+
+type CreatePasswordResetParams struct {
+	Username  string
+	TokenHash string
+}
+
+// PASSWORD RESETS
+func (q *Queries) CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, createPasswordReset, arg.Username, arg.TokenHash)
+	var i PasswordReset
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.TokenHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
+
+const expirePasswordResets = `-- name: ExpirePasswordResets :exec
+UPDATE password_resets
+SET used_at = now()
+WHERE username = $1 AND used_at IS NULL
+`
+
+func (q *Queries) ExpirePasswordResets(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, expirePasswordResets, username)
+	return err
+}
+
+const usePasswordReset = `-- name: UsePasswordReset :one
+UPDATE password_resets
+SET used_at = now()
+WHERE token_hash = $1
+  AND used_at IS NULL
+  AND expired_at > now()
+RETURNING id, username, token_hash, used_at, created_at, expired_at
+`
+
+func (q *Queries) UsePasswordReset(ctx context.Context, tokenHash string) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, usePasswordReset, tokenHash)
+	var i PasswordReset
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.TokenHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"simple_bank/util"
+)
+
+func createRandomPasswordReset(t *testing.T, user User) PasswordReset {
+	arg := CreatePasswordResetParams{
+		Username:  user.Username,
+		TokenHash: util.RandomString(64),
+	}
+
+	reset, err := testQueries.CreatePasswordReset(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Username, reset.Username)
+	require.Equal(t, arg.TokenHash, reset.TokenHash)
+	require.False(t, reset.UsedAt.Valid)
+	require.True(t, reset.ExpiredAt.Time.After(reset.CreatedAt.Time))
+
+	return reset
+}
+
+func TestResetPasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	reset := createRandomPasswordReset(t, user)
+	other := createRandomPasswordReset(t, user)
+
+	_, err := store.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+		TokenHash:         "unknown",
+		HashedPassword:    util.RandomString(32),
+		PasswordChangedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	require.ErrorIs(t, err, ErrInvalidPasswordReset)
+
+	arg := ResetPasswordTxParams{
+		TokenHash:         reset.TokenHash,
+		HashedPassword:    util.RandomString(32),
+		PasswordChangedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+	updated, err := store.ResetPasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.HashedPassword, updated.HashedPassword)
+	require.True(t, updated.PasswordChangedAt.Time.After(user.PasswordChangedAt.Time))
+
+	// the token is used up and every other outstanding token is revoked with it
+	_, err = store.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidPasswordReset)
+
+	arg.TokenHash = other.TokenHash
+	_, err = store.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidPasswordReset)
+}
+
+func TestUpdatePasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	reset := createRandomPasswordReset(t, user)
+
+	arg := UpdateUserPasswordParams{
+		Username:          user.Username,
+		HashedPassword:    util.RandomString(32),
+		PasswordChangedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+	updated, err := store.UpdatePasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.HashedPassword, updated.HashedPassword)
+
+	changedAt, err := testQueries.GetUserPasswordChangedAt(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, arg.PasswordChangedAt.Time, changedAt.Time, time.Millisecond)
+
+	_, err = store.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+		TokenHash:         reset.TokenHash,
+		HashedPassword:    util.RandomString(32),
+		PasswordChangedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	require.ErrorIs(t, err, ErrInvalidPasswordReset)
+}
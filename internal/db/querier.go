@@ -12,31 +12,86 @@ import (
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddGLAccountBalance(ctx context.Context, arg AddGLAccountBalanceParams) (GlAccount, error)
+	// a claimed delivery isn't due again until lease_until, so concurrent deliverers never send it twice;
+	// recording the attempt sets its real next attempt
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error)
+	ClaimJobs(ctx context.Context, arg ClaimJobsParams) ([]Job, error)
+	CompleteJob(ctx context.Context, id int64) error
+	ConfirmUserTOTP(ctx context.Context, arg ConfirmUserTOTPParams) (UserTotp, error)
+	CountAccountOwners(ctx context.Context, accountID int64) (int64, error)
+	CountAccountsByOwner(ctx context.Context, owner string) (int64, error)
+	CountMerchantAdminsByUser(ctx context.Context, username string) (int64, error)
+	CountTransfersBetweenAccounts(ctx context.Context, arg CountTransfersBetweenAccountsParams) (int64, error)
+	CountTransfersFromAccountSince(ctx context.Context, arg CountTransfersFromAccountSinceParams) (int64, error)
+	CountTransfersToCountry(ctx context.Context, arg CountTransfersToCountryParams) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	// ACCOUNT HOLDERS
+	CreateAccountHolder(ctx context.Context, arg CreateAccountHolderParams) (AccountHolder, error)
+	CreateAccountHolderEvent(ctx context.Context, arg CreateAccountHolderEventParams) (AccountHolderEvent, error)
+	// AUDIT EVENTS
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
+	// BENEFICIARIES
+	CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error)
 	CreateCountry(ctx context.Context, arg CreateCountryParams) (Country, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateGLEntry(ctx context.Context, arg CreateGLEntryParams) (GlEntry, error)
+	CreateLedgerAdjustment(ctx context.Context, arg CreateLedgerAdjustmentParams) (LedgerAdjustment, error)
 	CreateMerchant(ctx context.Context, arg CreateMerchantParams) (Merchant, error)
+	// MERCHANT ADMINISTRATORS
+	CreateMerchantAdmin(ctx context.Context, arg CreateMerchantAdminParams) (MerchantAdmin, error)
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) error
+	// OUTBOX
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (Outbox, error)
+	// PASSWORD RESETS
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) (RecoveryCode, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	// TRANSFER REVIEWS
+	CreateTransferReview(ctx context.Context, arg CreateTransferReviewParams) (TransferReview, error)
 	// USERS
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	// VERIFY EMAILS
+	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
+	// WEBHOOK DELIVERIES
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error
+	// WEBHOOK ENDPOINTS
+	CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error)
+	DecideLedgerAdjustment(ctx context.Context, arg DecideLedgerAdjustmentParams) (LedgerAdjustment, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteAccountHolder(ctx context.Context, arg DeleteAccountHolderParams) (AccountHolder, error)
+	DeleteBeneficiary(ctx context.Context, arg DeleteBeneficiaryParams) (Beneficiary, error)
 	DeleteCountry(ctx context.Context, code int32) error
 	DeleteEntry(ctx context.Context, id int64) error
 	DeleteMerchant(ctx context.Context, id int64) error
+	DeleteMerchantAdmin(ctx context.Context, arg DeleteMerchantAdminParams) (MerchantAdmin, error)
 	DeleteOrder(ctx context.Context, id int32) error
 	DeleteOrderItem(ctx context.Context, arg DeleteOrderItemParams) error
 	DeleteProduct(ctx context.Context, id int32) error
+	DeleteRecoveryCodes(ctx context.Context, username string) error
 	DeleteTransfer(ctx context.Context, id int64) error
+	DeleteUserTOTP(ctx context.Context, username string) error
+	DeleteWebhookEndpoint(ctx context.Context, arg DeleteWebhookEndpointParams) (WebhookEndpoint, error)
+	// JOBS
+	EnqueueJob(ctx context.Context, arg EnqueueJobParams) (Job, error)
+	ExpirePasswordResets(ctx context.Context, username string) error
+	FailJob(ctx context.Context, arg FailJobParams) error
 	// ACCOUNTS
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountHolder(ctx context.Context, arg GetAccountHolderParams) (AccountHolder, error)
+	GetBeneficiary(ctx context.Context, arg GetBeneficiaryParams) (Beneficiary, error)
+	GetBeneficiaryByAccount(ctx context.Context, arg GetBeneficiaryByAccountParams) (Beneficiary, error)
 	// COUNTRIES
 	GetCountry(ctx context.Context, code int32) (Country, error)
 	// ENTRIES
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetGLAccountForUpdate(ctx context.Context, arg GetGLAccountForUpdateParams) (GlAccount, error)
+	GetJob(ctx context.Context, id int64) (Job, error)
+	GetLedgerAdjustment(ctx context.Context, id int64) (LedgerAdjustment, error)
+	GetLedgerAdjustmentForUpdate(ctx context.Context, id int64) (LedgerAdjustment, error)
 	// MERCHANTS
 	GetMerchant(ctx context.Context, id int64) (Merchant, error)
 	// ORDERS
@@ -45,22 +100,80 @@ type Querier interface {
 	GetProduct(ctx context.Context, id int32) (Product, error)
 	// TRANSFERS
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferReview(ctx context.Context, id int64) (TransferReview, error)
+	GetTransferReviewForUpdate(ctx context.Context, id int64) (TransferReview, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserPasswordChangedAt(ctx context.Context, username string) (pgtype.Timestamptz, error)
+	GetUserTOTP(ctx context.Context, username string) (UserTotp, error)
+	GrantUserRole(ctx context.Context, arg GrantUserRoleParams) (UserRole, error)
+	IsMerchantAdmin(ctx context.Context, arg IsMerchantAdminParams) (bool, error)
+	// RECONCILIATION
+	ListAccountBalanceMismatches(ctx context.Context) ([]ListAccountBalanceMismatchesRow, error)
+	ListAccountHolderEvents(ctx context.Context, accountID int64) ([]AccountHolderEvent, error)
+	ListAccountHolders(ctx context.Context, accountID int64) ([]AccountHolder, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsByHolder(ctx context.Context, arg ListAccountsByHolderParams) ([]Account, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListBeneficiaries(ctx context.Context, arg ListBeneficiariesParams) ([]Beneficiary, error)
 	ListCountries(ctx context.Context) ([]Country, error)
+	ListCurrencyImbalances(ctx context.Context) ([]ListCurrencyImbalancesRow, error)
+	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
 	ListEntriesByAccount(ctx context.Context, accountID int64) ([]Entry, error)
+	// LEDGER ADJUSTMENTS
+	ListGLAccounts(ctx context.Context) ([]GlAccount, error)
+	ListGLBalanceMismatches(ctx context.Context) ([]ListGLBalanceMismatchesRow, error)
+	ListJobs(ctx context.Context, arg ListJobsParams) ([]Job, error)
+	ListLedgerAdjustments(ctx context.Context, arg ListLedgerAdjustmentsParams) ([]LedgerAdjustment, error)
+	ListMerchantAdmins(ctx context.Context, merchantID int64) ([]MerchantAdmin, error)
+	ListMerchantIDsByOrder(ctx context.Context, orderID pgtype.Int4) ([]int32, error)
 	ListMerchants(ctx context.Context) ([]Merchant, error)
+	ListMerchantsByAdminAccount(ctx context.Context, adminID int32) ([]Merchant, error)
 	// Order Items (no primary key → composite operations)
 	ListOrderItems(ctx context.Context, orderID pgtype.Int4) ([]OrderItem, error)
 	ListOrdersByUser(ctx context.Context, userID pgtype.Int4) ([]Order, error)
+	ListOutboxEvents(ctx context.Context, arg ListOutboxEventsParams) ([]Outbox, error)
+	ListPendingOutboxEvents(ctx context.Context, limit int32) ([]Outbox, error)
 	ListProductsByMerchant(ctx context.Context, merchantID int32) ([]Product, error)
+	ListRolePermissions(ctx context.Context) ([]RolePermission, error)
+	ListRoles(ctx context.Context) ([]Role, error)
+	ListTransferReviews(ctx context.Context, arg ListTransferReviewsParams) ([]TransferReview, error)
 	ListTransfers(ctx context.Context) ([]Transfer, error)
+	// ROLES AND PERMISSIONS
+	ListUserPermissions(ctx context.Context, username string) ([]string, error)
+	ListUserRoles(ctx context.Context, username string) ([]UserRole, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookEndpoints(ctx context.Context, merchantID int64) ([]WebhookEndpoint, error)
+	ListWebhookEndpointsForEvent(ctx context.Context, arg ListWebhookEndpointsForEventParams) ([]WebhookEndpoint, error)
+	MarkOutboxEventPublished(ctx context.Context, id int64) error
+	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (User, error)
+	// the last allowed failure locks the user out and starts the count over
+	RecordFailedOTP(ctx context.Context, arg RecordFailedOTPParams) (UserTotp, error)
+	RecordOutboxFailure(ctx context.Context, arg RecordOutboxFailureParams) error
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) error
+	RequeueStaleJobs(ctx context.Context, lockedBefore pgtype.Timestamptz) (int64, error)
+	ResetFailedOTPs(ctx context.Context, username string) error
+	RetryJob(ctx context.Context, arg RetryJobParams) error
+	RetryWebhookDelivery(ctx context.Context, arg RetryWebhookDeliveryParams) (WebhookDelivery, error)
+	RevokeUserRole(ctx context.Context, arg RevokeUserRoleParams) (UserRole, error)
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) error
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateBeneficiaryNickname(ctx context.Context, arg UpdateBeneficiaryNicknameParams) (Beneficiary, error)
 	UpdateCountry(ctx context.Context, arg UpdateCountryParams) error
 	UpdateMerchant(ctx context.Context, arg UpdateMerchantParams) error
 	UpdateOrderItem(ctx context.Context, arg UpdateOrderItemParams) error
 	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) error
+	UpdateTransferReview(ctx context.Context, arg UpdateTransferReviewParams) (TransferReview, error)
+	UpdateUserKYC(ctx context.Context, arg UpdateUserKYCParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
+	// TWO-FACTOR AUTHENTICATION
+	UpsertUserTOTP(ctx context.Context, arg UpsertUserTOTPParams) (UserTotp, error)
+	UsePasswordReset(ctx context.Context, tokenHash string) (PasswordReset, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (RecoveryCode, error)
+	UseUserTOTPStep(ctx context.Context, arg UseUserTOTPStepParams) (UserTotp, error)
+	UseVerifyEmail(ctx context.Context, arg UseVerifyEmailParams) (VerifyEmail, error)
+	VerifyBeneficiary(ctx context.Context, arg VerifyBeneficiaryParams) (Beneficiary, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// Roles seeded by the RBAC migration
+const (
+	RoleCustomer      = "customer"
+	RoleMerchantAdmin = "merchant_admin"
+	RoleBankStaff     = "bank_staff"
+	RoleBankAdmin     = "bank_admin"
+)
+
+// Permissions checked by the API, granted to roles in role_permissions
+const (
+	PermBanking               = "banking.use"
+	PermMerchantsManage       = "merchants.manage"
+	PermTransferReviewsDecide = "transfer_reviews.decide"
+	PermAuditEventsRead       = "audit_events.read"
+	PermUsersManage           = "users.manage"
+	PermRolesManage           = "roles.manage"
+	PermBackOffice            = "back_office.access"
+	PermAccountsFreeze        = "accounts.freeze"
+	PermLedgerAdjust          = "ledger.adjust"
+	PermLedgerApprove         = "ledger.approve"
+)
+
+// GrantedBySystem is recorded in user_roles for roles that weren't granted by an admin
+const GrantedBySystem = "system"
+
+// Actions recorded in audit_events for role and merchant administrator changes
+const (
+	AuditMerchantAdminAdd    = "merchant_admin.add"
+	AuditMerchantAdminRemove = "merchant_admin.remove"
+	AuditUserRoleGrant       = "user_role.grant"
+	AuditUserRoleRevoke      = "user_role.revoke"
+)
+
+// ErrLastMerchantAdmin is returned when removing an administrator would leave a merchant without one
+var ErrLastMerchantAdmin = errors.New("merchant must keep at least one administrator")
+
+// AddMerchantAdminTx makes a user an administrator of a merchant and grants them the merchant_admin role
+func (store *SQLStore) AddMerchantAdminTx(ctx context.Context, arg CreateMerchantAdminParams) (MerchantAdmin, error) {
+	var admin MerchantAdmin
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		admin, err = q.CreateMerchantAdmin(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.GrantUserRole(ctx, GrantUserRoleParams{
+			Username:  arg.Username,
+			Role:      RoleMerchantAdmin,
+			GrantedBy: GrantedBySystem,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Action:       AuditMerchantAdminAdd,
+			ResourceType: "merchant",
+			ResourceID:   arg.MerchantID,
+			After:        admin,
+		})
+	})
+
+	return admin, err
+}
+
+// RemoveMerchantAdminTx removes a merchant administrator. The merchant_admin role is revoked
+// once the user administers no merchant at all. It fails with ErrLastMerchantAdmin for the last administrator.
+func (store *SQLStore) RemoveMerchantAdminTx(ctx context.Context, arg DeleteMerchantAdminParams) (MerchantAdmin, error) {
+	var admin MerchantAdmin
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		admin, err = q.DeleteMerchantAdmin(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		remaining, err := q.ListMerchantAdmins(ctx, arg.MerchantID)
+		if err != nil {
+			return err
+		}
+		if len(remaining) == 0 {
+			return ErrLastMerchantAdmin
+		}
+
+		count, err := q.CountMerchantAdminsByUser(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			_, err = q.RevokeUserRole(ctx, RevokeUserRoleParams{
+				Username: arg.Username,
+				Role:     RoleMerchantAdmin,
+			})
+			if err != nil && !errors.Is(err, ErrRecordNotFound) {
+				return err
+			}
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Action:       AuditMerchantAdminRemove,
+			ResourceType: "merchant",
+			ResourceID:   arg.MerchantID,
+			Before:       admin,
+		})
+	})
+
+	return admin, err
+}
+
+// GrantRoleTx grants a user a role on behalf of an admin. Granting a role the user already has changes nothing.
+func (store *SQLStore) GrantRoleTx(ctx context.Context, arg GrantUserRoleParams) (UserRole, error) {
+	var userRole UserRole
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		userRole, err = q.GrantUserRole(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Action:       AuditUserRoleGrant,
+			ResourceType: "user",
+			ResourceID:   arg.Username,
+			After:        userRole,
+		})
+	})
+
+	return userRole, err
+}
+
+// RevokeRoleTx takes a role away from a user. It fails with ErrRecordNotFound if the user doesn't have it.
+func (store *SQLStore) RevokeRoleTx(ctx context.Context, arg RevokeUserRoleParams) (UserRole, error) {
+	var userRole UserRole
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		userRole, err = q.RevokeUserRole(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Action:       AuditUserRoleRevoke,
+			ResourceType: "user",
+			ResourceID:   arg.Username,
+			Before:       userRole,
+		})
+	})
+
+	return userRole, err
+}
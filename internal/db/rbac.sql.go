@@ -0,0 +1,268 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: rbac.sql
+
+package db
+
+import (
+	"context"
+)
+
+const countMerchantAdminsByUser = `-- name: CountMerchantAdminsByUser :one
+SELECT count(*) FROM merchant_admins
+WHERE username = $1
+`
+
+func (q *Queries) CountMerchantAdminsByUser(ctx context.Context, username string) (int64, error) {
+	row := q.db.QueryRow(ctx, countMerchantAdminsByUser, username)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createMerchantAdmin = `-- name: CreateMerchantAdmin :one
+INSERT INTO merchant_admins (
+  merchant_id, username
+) VALUES (
+  $1, $2
+)
+RETURNING merchant_id, username, created_at
+`
+
+type CreateMerchantAdminParams struct {
+	MerchantID int64
+	Username   string
+}
+
+// MERCHANT ADMINISTRATORS
+func (q *Queries) CreateMerchantAdmin(ctx context.Context, arg CreateMerchantAdminParams) (MerchantAdmin, error) {
+	row := q.db.QueryRow(ctx, createMerchantAdmin, arg.MerchantID, arg.Username)
+	var i MerchantAdmin
+	err := row.Scan(&i.MerchantID, &i.Username, &i.CreatedAt)
+	return i, err
+}
+
+const deleteMerchantAdmin = `-- name: DeleteMerchantAdmin :one
+DELETE FROM merchant_admins
+WHERE merchant_id = $1 AND username = $2
+RETURNING merchant_id, username, created_at
+`
+
+type DeleteMerchantAdminParams struct {
+	MerchantID int64
+	Username   string
+}
+
+func (q *Queries) DeleteMerchantAdmin(ctx context.Context, arg DeleteMerchantAdminParams) (MerchantAdmin, error) {
+	row := q.db.QueryRow(ctx, deleteMerchantAdmin, arg.MerchantID, arg.Username)
+	var i MerchantAdmin
+	err := row.Scan(&i.MerchantID, &i.Username, &i.CreatedAt)
+	return i, err
+}
+
+const grantUserRole = `-- name: GrantUserRole :one
+INSERT INTO user_roles (
+  username, role, granted_by
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (username, role) DO UPDATE
+SET granted_by = user_roles.granted_by
+RETURNING username, role, granted_by, created_at
+`
+
+type GrantUserRoleParams struct {
+	Username  string
+	Role      string
+	GrantedBy string
+}
+
+func (q *Queries) GrantUserRole(ctx context.Context, arg GrantUserRoleParams) (UserRole, error) {
+	row := q.db.QueryRow(ctx, grantUserRole, arg.Username, arg.Role, arg.GrantedBy)
+	var i UserRole
+	err := row.Scan(
+		&i.Username,
+		&i.Role,
+		&i.GrantedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const isMerchantAdmin = `-- name: IsMerchantAdmin :one
+SELECT EXISTS (
+  SELECT 1 FROM merchant_admins
+  WHERE merchant_id = $1 AND username = $2
+)
+`
+
+type IsMerchantAdminParams struct {
+	MerchantID int64
+	Username   string
+}
+
+func (q *Queries) IsMerchantAdmin(ctx context.Context, arg IsMerchantAdminParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isMerchantAdmin, arg.MerchantID, arg.Username)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listMerchantAdmins = `-- name: ListMerchantAdmins :many
+SELECT merchant_id, username, created_at FROM merchant_admins
+WHERE merchant_id = $1
+ORDER BY username
+`
+
+func (q *Queries) ListMerchantAdmins(ctx context.Context, merchantID int64) ([]MerchantAdmin, error) {
+	rows, err := q.db.Query(ctx, listMerchantAdmins, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []MerchantAdmin{}
+	for rows.Next() {
+		var i MerchantAdmin
+		if err := rows.Scan(&i.MerchantID, &i.Username, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRolePermissions = `-- name: ListRolePermissions :many
+SELECT role, permission FROM role_permissions
+ORDER BY role, permission
+`
+
+func (q *Queries) ListRolePermissions(ctx context.Context) ([]RolePermission, error) {
+	rows, err := q.db.Query(ctx, listRolePermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RolePermission{}
+	for rows.Next() {
+		var i RolePermission
+		if err := rows.Scan(&i.Role, &i.Permission); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoles = `-- name: ListRoles :many
+SELECT name, description FROM roles
+ORDER BY name
+`
+
+func (q *Queries) ListRoles(ctx context.Context) ([]Role, error) {
+	rows, err := q.db.Query(ctx, listRoles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Role{}
+	for rows.Next() {
+		var i Role
+		if err := rows.Scan(&i.Name, &i.Description); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserPermissions = `-- name: ListUserPermissions :many
+SELECT DISTINCT role_permissions.permission FROM user_roles
+JOIN role_permissions ON role_permissions.role = user_roles.role
+WHERE user_roles.username = $1
+ORDER BY role_permissions.permission
+`
+
+// ROLES AND PERMISSIONS
+func (q *Queries) ListUserPermissions(ctx context.Context, username string) ([]string, error) {
+	rows, err := q.db.Query(ctx, listUserPermissions, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		items = append(items, permission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserRoles = `-- name: ListUserRoles :many
+SELECT username, role, granted_by, created_at FROM user_roles
+WHERE username = $1
+ORDER BY role
+`
+
+func (q *Queries) ListUserRoles(ctx context.Context, username string) ([]UserRole, error) {
+	rows, err := q.db.Query(ctx, listUserRoles, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserRole{}
+	for rows.Next() {
+		var i UserRole
+		if err := rows.Scan(
+			&i.Username,
+			&i.Role,
+			&i.GrantedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeUserRole = `-- name: RevokeUserRole :one
+DELETE FROM user_roles
+WHERE username = $1 AND role = $2
+RETURNING username, role, granted_by, created_at
+`
+
+type RevokeUserRoleParams struct {
+	Username string
+	Role     string
+}
+
+func (q *Queries) RevokeUserRole(ctx context.Context, arg RevokeUserRoleParams) (UserRole, error) {
+	row := q.db.QueryRow(ctx, revokeUserRole, arg.Username, arg.Role)
+	var i UserRole
+	err := row.Scan(
+		&i.Username,
+		&i.Role,
+		&i.GrantedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
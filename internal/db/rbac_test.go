@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUserPermissions(t *testing.T) {
+	user := createRandomUser(t)
+
+	permissions, err := testQueries.ListUserPermissions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, permissions)
+
+	store := NewStore(testDB)
+	userRole, err := store.GrantRoleTx(context.Background(), GrantUserRoleParams{
+		Username:  user.Username,
+		Role:      RoleBankStaff,
+		GrantedBy: "admin",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "admin", userRole.GrantedBy)
+
+	permissions, err = testQueries.ListUserPermissions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Contains(t, permissions, PermTransferReviewsDecide)
+	require.Contains(t, permissions, PermBackOffice)
+	require.NotContains(t, permissions, PermRolesManage)
+	require.NotContains(t, permissions, PermLedgerAdjust)
+
+	_, err = store.RevokeRoleTx(context.Background(), RevokeUserRoleParams{Username: user.Username, Role: RoleBankStaff})
+	require.NoError(t, err)
+
+	_, err = store.RevokeRoleTx(context.Background(), RevokeUserRoleParams{Username: user.Username, Role: RoleBankStaff})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestMerchantAdminTx(t *testing.T) {
+	store := NewStore(testDB)
+	merchant := createRandomMerchant(t, createRandomAccount(t), createRandomCountry(t))
+	first := createRandomUser(t)
+	second := createRandomUser(t)
+
+	for _, user := range []User{first, second} {
+		admin, err := store.AddMerchantAdminTx(context.Background(), CreateMerchantAdminParams{
+			MerchantID: merchant.ID,
+			Username:   user.Username,
+		})
+		require.NoError(t, err)
+		require.Equal(t, merchant.ID, admin.MerchantID)
+
+		isAdmin, err := testQueries.IsMerchantAdmin(context.Background(), IsMerchantAdminParams{
+			MerchantID: merchant.ID,
+			Username:   user.Username,
+		})
+		require.NoError(t, err)
+		require.True(t, isAdmin)
+
+		permissions, err := testQueries.ListUserPermissions(context.Background(), user.Username)
+		require.NoError(t, err)
+		require.Contains(t, permissions, PermMerchantsManage)
+	}
+
+	_, err := store.RemoveMerchantAdminTx(context.Background(), DeleteMerchantAdminParams{
+		MerchantID: merchant.ID,
+		Username:   first.Username,
+	})
+	require.NoError(t, err)
+
+	// first administers nothing else, so the role goes with the last merchant
+	permissions, err := testQueries.ListUserPermissions(context.Background(), first.Username)
+	require.NoError(t, err)
+	require.NotContains(t, permissions, PermMerchantsManage)
+
+	_, err = store.RemoveMerchantAdminTx(context.Background(), DeleteMerchantAdminParams{
+		MerchantID: merchant.ID,
+		Username:   second.Username,
+	})
+	require.ErrorIs(t, err, ErrLastMerchantAdmin)
+
+	admins, err := testQueries.ListMerchantAdmins(context.Background(), merchant.ID)
+	require.NoError(t, err)
+	require.Len(t, admins, 1)
+	require.Equal(t, second.Username, admins[0].Username)
+}
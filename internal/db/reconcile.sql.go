@@ -0,0 +1,138 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: reconcile.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listAccountBalanceMismatches = `-- name: ListAccountBalanceMismatches :many
+SELECT a.id, a.owner, a.currency, a.balance, COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id
+`
+
+type ListAccountBalanceMismatchesRow struct {
+	ID           int64
+	Owner        string
+	Currency     string
+	Balance      int64
+	EntriesTotal int64
+}
+
+// RECONCILIATION
+func (q *Queries) ListAccountBalanceMismatches(ctx context.Context) ([]ListAccountBalanceMismatchesRow, error) {
+	rows, err := q.db.Query(ctx, listAccountBalanceMismatches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountBalanceMismatchesRow{}
+	for rows.Next() {
+		var i ListAccountBalanceMismatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Currency,
+			&i.Balance,
+			&i.EntriesTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCurrencyImbalances = `-- name: ListCurrencyImbalances :many
+SELECT currency, SUM(amount)::bigint AS net
+FROM (
+  SELECT a.currency, e.amount
+  FROM entries e
+  JOIN accounts a ON a.id = e.account_id
+  UNION ALL
+  SELECT g.currency, e.amount
+  FROM gl_entries e
+  JOIN gl_accounts g ON g.id = e.gl_account_id
+) ledger
+GROUP BY currency
+HAVING SUM(amount) <> 0
+ORDER BY currency
+`
+
+type ListCurrencyImbalancesRow struct {
+	Currency string
+	Net      int64
+}
+
+func (q *Queries) ListCurrencyImbalances(ctx context.Context) ([]ListCurrencyImbalancesRow, error) {
+	rows, err := q.db.Query(ctx, listCurrencyImbalances)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCurrencyImbalancesRow{}
+	for rows.Next() {
+		var i ListCurrencyImbalancesRow
+		if err := rows.Scan(&i.Currency, &i.Net); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGLBalanceMismatches = `-- name: ListGLBalanceMismatches :many
+SELECT g.id, g.code, g.currency, g.balance, COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM gl_accounts g
+LEFT JOIN gl_entries e ON e.gl_account_id = g.id
+GROUP BY g.id
+HAVING g.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY g.id
+`
+
+type ListGLBalanceMismatchesRow struct {
+	ID           int64
+	Code         string
+	Currency     string
+	Balance      int64
+	EntriesTotal int64
+}
+
+func (q *Queries) ListGLBalanceMismatches(ctx context.Context) ([]ListGLBalanceMismatchesRow, error) {
+	rows, err := q.db.Query(ctx, listGLBalanceMismatches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGLBalanceMismatchesRow{}
+	for rows.Next() {
+		var i ListGLBalanceMismatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Currency,
+			&i.Balance,
+			&i.EntriesTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
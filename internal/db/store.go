@@ -3,14 +3,43 @@ package db
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"simple_bank/internal/metrics"
+	"simple_bank/internal/tracing"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/codes"
 )
 
 // Store provides all functions to execute db queries and transactions
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	ReviewTransferTx(ctx context.Context, arg ReviewTransferTxParams) (ReviewTransferTxResult, error)
+	CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error)
+	AddAccountHolderTx(ctx context.Context, arg AddAccountHolderTxParams) (AccountHolder, error)
+	RemoveAccountHolderTx(ctx context.Context, arg RemoveAccountHolderTxParams) (AccountHolder, error)
+	UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (Account, error)
+	CreateOrderTx(ctx context.Context, arg CreateOrderParams) (Order, error)
+	UpdateOrderStatusTx(ctx context.Context, arg UpdateOrderStatusParams) (Order, error)
+	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (User, error)
+	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	UpdatePasswordTx(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (User, error)
+	ConfirmTOTPTx(ctx context.Context, arg ConfirmTOTPTxParams) (UserTotp, error)
+	DisableTOTPTx(ctx context.Context, username string) error
+	AddMerchantAdminTx(ctx context.Context, arg CreateMerchantAdminParams) (MerchantAdmin, error)
+	RemoveMerchantAdminTx(ctx context.Context, arg DeleteMerchantAdminParams) (MerchantAdmin, error)
+	GrantRoleTx(ctx context.Context, arg GrantUserRoleParams) (UserRole, error)
+	RevokeRoleTx(ctx context.Context, arg RevokeUserRoleParams) (UserRole, error)
+	RequestAdjustmentTx(ctx context.Context, arg CreateLedgerAdjustmentParams) (LedgerAdjustment, error)
+	DecideAdjustmentTx(ctx context.Context, arg DecideAdjustmentTxParams) (DecideAdjustmentTxResult, error)
+	RecordAudit(ctx context.Context, rec AuditRecord) error
+	TryLockOutbox(ctx context.Context) (unlock func(), locked bool, err error)
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version int64, dirty bool, err error)
 }
 
 // Store provides all functions to execute db queries and transactions
@@ -47,7 +76,17 @@ func NewStore(db *pgxpool.Pool) Store {
 * it handles an entire transaction and maintains it state from begin to when the transaction ends
 * handles the boilerplate of a database transaction.
  */
-func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) (err error) {
+	// the span is named after the store method running the transaction, e.g. db.TransferTx
+	ctx, span := tracing.Tracer().Start(ctx, "db."+callerName())
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	tx, err := store.db.Begin(ctx)
 
 	if err != nil {
@@ -59,6 +98,7 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	err = fn(q)
 
 	if err != nil {
+		metrics.ObserveTx(false)
 		if rbErr := tx.Rollback(ctx); rbErr != nil {
 			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
 		}
@@ -66,54 +106,110 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 		return err
 	}
 
-	return tx.Commit(ctx)
+	// a failed commit is rolled back by Postgres
+	err = tx.Commit(ctx)
+	metrics.ObserveTx(err == nil)
+	return err
+}
+
+// callerName is the name of the method that called the function calling it, e.g. TransferTx
+func callerName() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return "tx"
+	}
+
+	name := runtime.FuncForPC(pc).Name()
+	return name[strings.LastIndex(name, ".")+1:]
 }
 
 // TransferTx performs a money transfer from one account to the other
-// It creates a transfer record, add account entries, and update accounts' balance within a single database transaction
+// It creates a transfer record, add account entries, and update accounts' balance within a single database transaction.
+// It fails with ErrAccountCannotSend or ErrAccountCannotReceive when an account's status forbids the transfer
+// The transfer is audited and published through the outbox in the same transaction.
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
-
-		//1. create transfer
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
+		result, err = transfer(ctx, q, arg)
 		if err != nil {
 			return err
 		}
 
-		// 2. Create "From" Entry (Money leaving)
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
+		err = q.enqueueEvent(ctx, "transfer", result.Transfer.ID, EventTransferCreated, result)
 		if err != nil {
 			return err
 		}
 
-		// 3. Create "To" Entry (Money arriving)
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
+		return q.RecordAudit(ctx, AuditRecord{
+			Action:       AuditTransferCreate,
+			ResourceType: "transfer",
+			ResourceID:   result.Transfer.ID,
+			After:        result,
 		})
-		if err != nil {
-			return err
-		}
+	})
 
-		//  4. update balances
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-		}
+	return result, err
+}
+
+// transfer checks both accounts may move money and posts the transfer using q,
+// so it can be shared by every transaction that ends up moving money
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	// 0. lock both accounts and make sure their statuses allow the transfer
+	fromAccount, toAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if err = checkTransferAllowed(fromAccount, toAccount); err != nil {
+		return result, err
+	}
+
+	return postTransfer(ctx, q, arg)
+}
+
+// postTransfer writes the transfer, its entries and the balance updates without any status checks
+func postTransfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+	var err error
+
+	//1. create transfer
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// 2. Create "From" Entry (Money leaving)
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.FromAccountID,
+		Amount:    -arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
 
-		return nil
+	// 3. Create "To" Entry (Money arriving)
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.ToAccountID,
+		Amount:    arg.Amount,
 	})
+	if err != nil {
+		return result, err
+	}
+
+	//  4. update balances
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+	}
 
 	return result, err
 }
@@ -31,7 +31,7 @@ func TestTransferTx(t *testing.T) {
 				Amount:        amount,
 			})
 
-			errs <- err  
+			errs <- err
 			results <- result
 		}()
 	}
@@ -42,7 +42,7 @@ func TestTransferTx(t *testing.T) {
 		require.NoError(t, err)
 
 		result := <-results
-		require.NotEmpty(t, result)	
+		require.NotEmpty(t, result)
 
 		// check transfer
 		transfer := result.Transfer
@@ -141,7 +141,7 @@ func TestTransferTxDeadlock(t *testing.T) {
 				Amount:        amount,
 			})
 
-			errs <- err  
+			errs <- err
 		}()
 	}
 
@@ -161,4 +161,51 @@ func TestTransferTxDeadlock(t *testing.T) {
 	fmt.Println(">> after:", updatedAccount1.Balance, updatedAccount2.Balance)
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
-}
\ No newline at end of file
+}
+
+func TestTransferTxAccountStatus(t *testing.T) {
+	store := NewStore(testDB)
+
+	testCases := []struct {
+		name       string
+		fromStatus string
+		toStatus   string
+		wantErr    error
+	}{
+		{name: "FrozenSender", fromStatus: AccountFrozen, toStatus: AccountActive, wantErr: ErrAccountCannotSend},
+		{name: "DebitBlockedSender", fromStatus: AccountDebitBlocked, toStatus: AccountActive, wantErr: ErrAccountCannotSend},
+		{name: "FrozenReceiver", fromStatus: AccountActive, toStatus: AccountFrozen, wantErr: ErrAccountCannotReceive},
+		{name: "ClosedReceiver", fromStatus: AccountActive, toStatus: AccountClosed, wantErr: ErrAccountCannotReceive},
+		{name: "DebitBlockedReceiver", fromStatus: AccountActive, toStatus: AccountDebitBlocked},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			account1 := createRandomAccount(t)
+			account2 := createRandomAccount(t)
+
+			_, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{ID: account1.ID, Status: tc.fromStatus})
+			require.NoError(t, err)
+			_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{ID: account2.ID, Status: tc.toStatus})
+			require.NoError(t, err)
+
+			_, err = store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        10,
+			})
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tc.wantErr)
+
+			// nothing was posted
+			updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+			require.NoError(t, err)
+			require.Equal(t, account1.Balance, updatedAccount1.Balance)
+		})
+	}
+}
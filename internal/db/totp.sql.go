@@ -0,0 +1,237 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: totp.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const confirmUserTOTP = `-- name: ConfirmUserTOTP :one
+UPDATE user_totps
+SET confirmed_at = now(),
+    last_used_step = $1
+WHERE username = $2 AND confirmed_at IS NULL
+RETURNING username, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+`
+
+type ConfirmUserTOTPParams struct {
+	Step     int64
+	Username string
+}
+
+func (q *Queries) ConfirmUserTOTP(ctx context.Context, arg ConfirmUserTOTPParams) (UserTotp, error) {
+	row := q.db.QueryRow(ctx, confirmUserTOTP, arg.Step, arg.Username)
+	var i UserTotp
+	err := row.Scan(
+		&i.Username,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.FailedAttempts,
+		&i.LockedUntil,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createRecoveryCode = `-- name: CreateRecoveryCode :one
+INSERT INTO recovery_codes (
+  username, code_hash
+) VALUES (
+  $1, $2
+)
+RETURNING id, username, code_hash, used_at, created_at
+`
+
+type CreateRecoveryCodeParams struct {
+	Username string
+	CodeHash string
+}
+
+func (q *Queries) CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) (RecoveryCode, error) {
+	row := q.db.QueryRow(ctx, createRecoveryCode, arg.Username, arg.CodeHash)
+	var i RecoveryCode
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.CodeHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteRecoveryCodes = `-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes
+WHERE username = $1
+`
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, deleteRecoveryCodes, username)
+	return err
+}
+
+const deleteUserTOTP = `-- name: DeleteUserTOTP :exec
+DELETE FROM user_totps
+WHERE username = $1
+`
+
+func (q *Queries) DeleteUserTOTP(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, deleteUserTOTP, username)
+	return err
+}
+
+const getUserTOTP = `-- name: GetUserTOTP :one
+SELECT username, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at FROM user_totps
+WHERE username = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserTOTP(ctx context.Context, username string) (UserTotp, error) {
+	row := q.db.QueryRow(ctx, getUserTOTP, username)
+	var i UserTotp
+	err := row.Scan(
+		&i.Username,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.FailedAttempts,
+		&i.LockedUntil,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const recordFailedOTP = `-- name: RecordFailedOTP :one
+UPDATE user_totps
+SET failed_attempts = CASE WHEN failed_attempts + 1 >= $1::int THEN 0 ELSE failed_attempts + 1 END,
+    locked_until = CASE WHEN failed_attempts + 1 >= $1::int THEN $2::timestamptz ELSE locked_until END
+WHERE username = $3
+RETURNING username, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+`
+
+type RecordFailedOTPParams struct {
+	MaxAttempts int32
+	LockedUntil pgtype.Timestamptz
+	Username    string
+}
+
+// the last allowed failure locks the user out and starts the count over
+func (q *Queries) RecordFailedOTP(ctx context.Context, arg RecordFailedOTPParams) (UserTotp, error) {
+	row := q.db.QueryRow(ctx, recordFailedOTP, arg.MaxAttempts, arg.LockedUntil, arg.Username)
+	var i UserTotp
+	err := row.Scan(
+		&i.Username,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.FailedAttempts,
+		&i.LockedUntil,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const resetFailedOTPs = `-- name: ResetFailedOTPs :exec
+UPDATE user_totps
+SET failed_attempts = 0
+WHERE username = $1
+`
+
+func (q *Queries) ResetFailedOTPs(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, resetFailedOTPs, username)
+	return err
+}
+
+const upsertUserTOTP = `-- name: UpsertUserTOTP :one
+INSERT INTO user_totps (
+  username, secret
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (username) DO UPDATE
+SET secret = EXCLUDED.secret,
+    last_used_step = 0,
+    created_at = now()
+WHERE user_totps.confirmed_at IS NULL
+RETURNING username, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+`
+
+type UpsertUserTOTPParams struct {
+	Username string
+	Secret   string
+}
+
+// TWO-FACTOR AUTHENTICATION
+func (q *Queries) UpsertUserTOTP(ctx context.Context, arg UpsertUserTOTPParams) (UserTotp, error) {
+	row := q.db.QueryRow(ctx, upsertUserTOTP, arg.Username, arg.Secret)
+	var i UserTotp
+	err := row.Scan(
+		&i.Username,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.FailedAttempts,
+		&i.LockedUntil,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const useRecoveryCode = `-- name: UseRecoveryCode :one
+UPDATE recovery_codes
+SET used_at = now()
+WHERE username = $1 AND code_hash = $2 AND used_at IS NULL
+RETURNING id, username, code_hash, used_at, created_at
+`
+
+type UseRecoveryCodeParams struct {
+	Username string
+	CodeHash string
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (RecoveryCode, error) {
+	row := q.db.QueryRow(ctx, useRecoveryCode, arg.Username, arg.CodeHash)
+	var i RecoveryCode
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.CodeHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const useUserTOTPStep = `-- name: UseUserTOTPStep :one
+UPDATE user_totps
+SET last_used_step = $1
+WHERE username = $2
+  AND confirmed_at IS NOT NULL
+  AND last_used_step < $1
+RETURNING username, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+`
+
+type UseUserTOTPStepParams struct {
+	Step     int64
+	Username string
+}
+
+func (q *Queries) UseUserTOTPStep(ctx context.Context, arg UseUserTOTPStepParams) (UserTotp, error) {
+	row := q.db.QueryRow(ctx, useUserTOTPStep, arg.Step, arg.Username)
+	var i UserTotp
+	err := row.Scan(
+		&i.Username,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.FailedAttempts,
+		&i.LockedUntil,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"simple_bank/util"
+)
+
+func TestConfirmTOTPTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	_, err := store.ConfirmTOTPTx(context.Background(), ConfirmTOTPTxParams{Username: user.Username, Step: 1})
+	require.ErrorIs(t, err, ErrTOTPNotPending)
+
+	pending, err := testQueries.UpsertUserTOTP(context.Background(), UpsertUserTOTPParams{
+		Username: user.Username,
+		Secret:   util.RandomString(32),
+	})
+	require.NoError(t, err)
+	require.False(t, pending.ConfirmedAt.Valid)
+
+	hashes := []string{util.RandomString(64), util.RandomString(64)}
+	confirmed, err := store.ConfirmTOTPTx(context.Background(), ConfirmTOTPTxParams{
+		Username:           user.Username,
+		Step:               100,
+		RecoveryCodeHashes: hashes,
+	})
+	require.NoError(t, err)
+	require.True(t, confirmed.ConfirmedAt.Valid)
+	require.Equal(t, int64(100), confirmed.LastUsedStep)
+
+	// a confirmed secret can't be replaced by enrolling again
+	_, err = testQueries.UpsertUserTOTP(context.Background(), UpsertUserTOTPParams{
+		Username: user.Username,
+		Secret:   util.RandomString(32),
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	// steps only move forward, so a code is never accepted twice
+	_, err = testQueries.UseUserTOTPStep(context.Background(), UseUserTOTPStepParams{Username: user.Username, Step: 100})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	_, err = testQueries.UseUserTOTPStep(context.Background(), UseUserTOTPStepParams{Username: user.Username, Step: 101})
+	require.NoError(t, err)
+
+	// recovery codes are single use
+	_, err = testQueries.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{Username: user.Username, CodeHash: hashes[0]})
+	require.NoError(t, err)
+	_, err = testQueries.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{Username: user.Username, CodeHash: hashes[0]})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestDisableTOTPTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	_, err := testQueries.UpsertUserTOTP(context.Background(), UpsertUserTOTPParams{
+		Username: user.Username,
+		Secret:   util.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	hash := util.RandomString(64)
+	_, err = store.ConfirmTOTPTx(context.Background(), ConfirmTOTPTxParams{
+		Username:           user.Username,
+		Step:               1,
+		RecoveryCodeHashes: []string{hash},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.DisableTOTPTx(context.Background(), user.Username))
+
+	_, err = testQueries.GetUserTOTP(context.Background(), user.Username)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	_, err = testQueries.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{Username: user.Username, CodeHash: hash})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestRecordFailedOTP(t *testing.T) {
+	user := createRandomUser(t)
+
+	_, err := testQueries.UpsertUserTOTP(context.Background(), UpsertUserTOTPParams{
+		Username: user.Username,
+		Secret:   util.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	arg := RecordFailedOTPParams{
+		Username:    user.Username,
+		MaxAttempts: 3,
+		LockedUntil: pgtype.Timestamptz{Time: time.Now().Add(OTPLockout), Valid: true},
+	}
+
+	userTOTP, err := testQueries.RecordFailedOTP(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), userTOTP.FailedAttempts)
+	require.False(t, userTOTP.LockedUntil.Valid)
+
+	// a success starts the count over
+	require.NoError(t, testQueries.ResetFailedOTPs(context.Background(), user.Username))
+
+	for i := int32(1); i < arg.MaxAttempts; i++ {
+		userTOTP, err = testQueries.RecordFailedOTP(context.Background(), arg)
+		require.NoError(t, err)
+		require.Equal(t, i, userTOTP.FailedAttempts)
+	}
+
+	// the last allowed failure locks the user out
+	userTOTP, err = testQueries.RecordFailedOTP(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, userTOTP.FailedAttempts)
+	require.WithinDuration(t, arg.LockedUntil.Time, userTOTP.LockedUntil.Time, time.Second)
+}
@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"simple_bank/internal/totp"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrTOTPNotPending is returned when confirming two-factor authentication for a user with no unconfirmed enrollment
+var ErrTOTPNotPending = errors.New("no two-factor enrollment is waiting for confirmation")
+
+// ConfirmTOTPTxParams contains the input parameters of the confirm TOTP transaction
+type ConfirmTOTPTxParams struct {
+	Username string
+	// Step is the time step of the code that proved the enrollment, so it can't be replayed at login
+	Step int64
+	// RecoveryCodeHashes replace any recovery codes the user had
+	RecoveryCodeHashes []string
+}
+
+// ConfirmTOTPTx turns on two-factor authentication for a user and stores their recovery codes.
+// It fails with ErrTOTPNotPending unless the user has an unconfirmed enrollment.
+func (store *SQLStore) ConfirmTOTPTx(ctx context.Context, arg ConfirmTOTPTxParams) (UserTotp, error) {
+	var userTOTP UserTotp
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		userTOTP, err = q.ConfirmUserTOTP(ctx, ConfirmUserTOTPParams{
+			Username: arg.Username,
+			Step:     arg.Step,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrTOTPNotPending
+			}
+			return err
+		}
+
+		err = q.DeleteRecoveryCodes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		for _, codeHash := range arg.RecoveryCodeHashes {
+			_, err = q.CreateRecoveryCode(ctx, CreateRecoveryCodeParams{
+				Username: arg.Username,
+				CodeHash: codeHash,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Action:       AuditUserTOTPEnable,
+			ResourceType: "user",
+			ResourceID:   arg.Username,
+		})
+	})
+
+	return userTOTP, err
+}
+
+// DisableTOTPTx turns off two-factor authentication for a user and drops their recovery codes
+func (store *SQLStore) DisableTOTPTx(ctx context.Context, username string) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteUserTOTP(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteRecoveryCodes(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Action:       AuditUserTOTPDisable,
+			ResourceType: "user",
+			ResourceID:   username,
+		})
+	})
+}
+
+// ErrInvalidOTP is returned when a second factor is neither a current TOTP code nor an unused recovery code
+var ErrInvalidOTP = errors.New("two-factor code is invalid or was already used")
+
+// ErrOTPLocked is returned for any second factor while a user is locked out after too many invalid ones
+var ErrOTPLocked = errors.New("too many invalid two-factor codes, try again later")
+
+// After MaxOTPAttempts invalid second factors in a row, none is accepted for OTPLockout,
+// so the million TOTP codes can't be guessed through the API
+const (
+	MaxOTPAttempts = 5
+	OTPLockout     = 15 * time.Minute
+)
+
+// ConfirmedTOTP returns a user's two-factor enrollment with its secret decrypted by cipher,
+// and false if they have none or haven't confirmed it yet
+func ConfirmedTOTP(ctx context.Context, store Store, cipher *totp.Cipher, username string) (UserTotp, bool, error) {
+	userTOTP, err := store.GetUserTOTP(ctx, username)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return UserTotp{}, false, nil
+		}
+		return UserTotp{}, false, err
+	}
+
+	userTOTP.Secret, err = cipher.Open(userTOTP.Secret, userTOTP.Username)
+	if err != nil {
+		return UserTotp{}, false, err
+	}
+
+	return userTOTP, userTOTP.ConfirmedAt.Valid, nil
+}
+
+// HasTOTP reports whether username has confirmed two-factor authentication, without opening the secret
+func HasTOTP(ctx context.Context, store Store, username string) (bool, error) {
+	userTOTP, err := store.GetUserTOTP(ctx, username)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return userTOTP.ConfirmedAt.Valid, nil
+}
+
+// CheckSecondFactor accepts code as a current TOTP code or else as an unused recovery code, using it up either way.
+// It returns ErrInvalidOTP when code is neither, counting the failure, and ErrOTPLocked while the user is locked out.
+func CheckSecondFactor(ctx context.Context, store Store, userTOTP UserTotp, code string) error {
+	now := time.Now()
+	if userTOTP.LockedUntil.Valid && now.Before(userTOTP.LockedUntil.Time) {
+		return ErrOTPLocked
+	}
+
+	var err error
+	if step, ok := totp.Validate(userTOTP.Secret, code, now); ok {
+		// a code is only good once, even within its time step
+		_, err = store.UseUserTOTPStep(ctx, UseUserTOTPStepParams{
+			Username: userTOTP.Username,
+			Step:     step,
+		})
+	} else {
+		_, err = store.UseRecoveryCode(ctx, UseRecoveryCodeParams{
+			Username: userTOTP.Username,
+			CodeHash: totp.HashRecoveryCode(code),
+		})
+	}
+
+	if errors.Is(err, ErrRecordNotFound) {
+		_, err = store.RecordFailedOTP(ctx, RecordFailedOTPParams{
+			Username:    userTOTP.Username,
+			MaxAttempts: MaxOTPAttempts,
+			LockedUntil: pgtype.Timestamptz{Time: now.Add(OTPLockout), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		return ErrInvalidOTP
+	}
+	if err != nil {
+		return err
+	}
+
+	if userTOTP.FailedAttempts > 0 {
+		return store.ResetFailedOTPs(ctx, userTOTP.Username)
+	}
+	return nil
+}
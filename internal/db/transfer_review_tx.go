@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Statuses a transfer review moves through. Only pending reviews can be decided.
+const (
+	TransferReviewPending  = "pending_review"
+	TransferReviewApproved = "approved"
+	TransferReviewRejected = "rejected"
+)
+
+// ErrReviewNotPending is returned when deciding a review that was already approved or rejected
+var ErrReviewNotPending = errors.New("transfer review is not pending")
+
+// ReviewTransferTxParams contains the input parameters of the review transaction
+type ReviewTransferTxParams struct {
+	ReviewID   int64  `json:"review_id"`
+	Approve    bool   `json:"approve"`
+	ReviewedBy string `json:"reviewed_by"`
+}
+
+// ReviewTransferTxResult is the result of the review transaction.
+// Transfer is only populated when the review was approved.
+type ReviewTransferTxResult struct {
+	Review   TransferReview    `json:"review"`
+	Transfer *TransferTxResult `json:"transfer,omitempty"`
+}
+
+// ReviewTransferTx approves or rejects a transfer held for review.
+// Approval posts the held transfer exactly like TransferTx, in the same database transaction
+// that marks the review as decided, so a review can never be approved without its money moving.
+func (store *SQLStore) ReviewTransferTx(ctx context.Context, arg ReviewTransferTxParams) (ReviewTransferTxResult, error) {
+	var result ReviewTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		review, err := q.GetTransferReviewForUpdate(ctx, arg.ReviewID)
+		if err != nil {
+			return err
+		}
+
+		if review.Status != TransferReviewPending {
+			return ErrReviewNotPending
+		}
+
+		update := UpdateTransferReviewParams{
+			ID:         review.ID,
+			Status:     TransferReviewRejected,
+			ReviewedBy: pgtype.Text{String: arg.ReviewedBy, Valid: true},
+		}
+
+		if arg.Approve {
+			posted, err := transfer(ctx, q, TransferTxParams{
+				FromAccountID: review.FromAccountID,
+				ToAccountID:   review.ToAccountID,
+				Amount:        review.Amount,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = q.enqueueEvent(ctx, "transfer", posted.Transfer.ID, EventTransferCreated, posted)
+			if err != nil {
+				return err
+			}
+
+			result.Transfer = &posted
+			update.Status = TransferReviewApproved
+			update.TransferID = pgtype.Int8{Int64: posted.Transfer.ID, Valid: true}
+		}
+
+		result.Review, err = q.UpdateTransferReview(ctx, update)
+		if err != nil {
+			return err
+		}
+
+		return q.RecordAudit(ctx, AuditRecord{
+			Actor:        arg.ReviewedBy,
+			Action:       AuditTransferReviewDecide,
+			ResourceType: "transfer_review",
+			ResourceID:   review.ID,
+			Before:       review,
+			After:        result,
+		})
+	})
+
+	return result, err
+}
@@ -0,0 +1,184 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: transfer_reviews.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTransferReview = `-- name: CreateTransferReview :one
+INSERT INTO transfer_reviews (
+  from_account_id, to_account_id, amount, reasons
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING id, from_account_id, to_account_id, amount, reasons, status, transfer_id, reviewed_by, reviewed_at, created_at
+`
+
+type CreateTransferReviewParams struct {
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        int64
+	Reasons       []string
+}
+
+// TRANSFER REVIEWS
+func (q *Queries) CreateTransferReview(ctx context.Context, arg CreateTransferReviewParams) (TransferReview, error) {
+	row := q.db.QueryRow(ctx, createTransferReview,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Reasons,
+	)
+	var i TransferReview
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Reasons,
+		&i.Status,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferReview = `-- name: GetTransferReview :one
+SELECT id, from_account_id, to_account_id, amount, reasons, status, transfer_id, reviewed_by, reviewed_at, created_at FROM transfer_reviews
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetTransferReview(ctx context.Context, id int64) (TransferReview, error) {
+	row := q.db.QueryRow(ctx, getTransferReview, id)
+	var i TransferReview
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Reasons,
+		&i.Status,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferReviewForUpdate = `-- name: GetTransferReviewForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, reasons, status, transfer_id, reviewed_by, reviewed_at, created_at FROM transfer_reviews
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferReviewForUpdate(ctx context.Context, id int64) (TransferReview, error) {
+	row := q.db.QueryRow(ctx, getTransferReviewForUpdate, id)
+	var i TransferReview
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Reasons,
+		&i.Status,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listTransferReviews = `-- name: ListTransferReviews :many
+SELECT id, from_account_id, to_account_id, amount, reasons, status, transfer_id, reviewed_by, reviewed_at, created_at FROM transfer_reviews
+WHERE status = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3
+`
+
+type ListTransferReviewsParams struct {
+	Status string
+	Limit  int32
+	Offset int32
+}
+
+func (q *Queries) ListTransferReviews(ctx context.Context, arg ListTransferReviewsParams) ([]TransferReview, error) {
+	rows, err := q.db.Query(ctx, listTransferReviews, arg.Status, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferReview{}
+	for rows.Next() {
+		var i TransferReview
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Reasons,
+			&i.Status,
+			&i.TransferID,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTransferReview = `-- name: UpdateTransferReview :one
+UPDATE transfer_reviews
+SET status = $2,
+    transfer_id = $3,
+    reviewed_by = $4,
+    reviewed_at = now()
+WHERE id = $1
+RETURNING id, from_account_id, to_account_id, amount, reasons, status, transfer_id, reviewed_by, reviewed_at, created_at
+`
+
+type UpdateTransferReviewParams struct {
+	ID         int64
+	Status     string
+	TransferID pgtype.Int8
+	ReviewedBy pgtype.Text
+}
+
+func (q *Queries) UpdateTransferReview(ctx context.Context, arg UpdateTransferReviewParams) (TransferReview, error) {
+	row := q.db.QueryRow(ctx, updateTransferReview,
+		arg.ID,
+		arg.Status,
+		arg.TransferID,
+		arg.ReviewedBy,
+	)
+	var i TransferReview
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Reasons,
+		&i.Status,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
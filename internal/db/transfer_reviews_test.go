@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"simple_bank/util"
+)
+
+func createRandomTransferReview(t *testing.T, fromAccount, toAccount Account) TransferReview {
+	arg := CreateTransferReviewParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        util.RandomMoney(),
+		Reasons:       []string{"new_payee_large_amount"},
+	}
+
+	review, err := testQueries.CreateTransferReview(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, review)
+
+	require.Equal(t, arg.FromAccountID, review.FromAccountID)
+	require.Equal(t, arg.ToAccountID, review.ToAccountID)
+	require.Equal(t, arg.Amount, review.Amount)
+	require.Equal(t, arg.Reasons, review.Reasons)
+	require.Equal(t, TransferReviewPending, review.Status)
+	require.False(t, review.TransferID.Valid)
+	require.False(t, review.ReviewedAt.Valid)
+
+	require.NotZero(t, review.ID)
+	require.NotZero(t, review.CreatedAt)
+
+	return review
+}
+
+func TestCreateTransferReview(t *testing.T) {
+	createRandomTransferReview(t, createRandomAccount(t), createRandomAccount(t))
+}
+
+func TestGetTransferReview(t *testing.T) {
+	review1 := createRandomTransferReview(t, createRandomAccount(t), createRandomAccount(t))
+
+	review2, err := testQueries.GetTransferReview(context.Background(), review1.ID)
+	require.NoError(t, err)
+	require.Equal(t, review1, review2)
+}
+
+func TestListTransferReviews(t *testing.T) {
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	for i := 0; i < 3; i++ {
+		createRandomTransferReview(t, fromAccount, toAccount)
+	}
+
+	reviews, err := testQueries.ListTransferReviews(context.Background(), ListTransferReviewsParams{
+		Status: TransferReviewPending,
+		Limit:  3,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, reviews, 3)
+
+	for _, review := range reviews {
+		require.Equal(t, TransferReviewPending, review.Status)
+	}
+}
+
+func TestReviewTransferTxApprove(t *testing.T) {
+	store := NewStore(testDB)
+
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	review := createRandomTransferReview(t, fromAccount, toAccount)
+
+	result, err := store.ReviewTransferTx(context.Background(), ReviewTransferTxParams{
+		ReviewID:   review.ID,
+		Approve:    true,
+		ReviewedBy: "admin",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, TransferReviewApproved, result.Review.Status)
+	require.Equal(t, "admin", result.Review.ReviewedBy.String)
+	require.True(t, result.Review.ReviewedAt.Valid)
+
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Review.TransferID.Int64)
+	require.Equal(t, review.Amount, result.Transfer.Transfer.Amount)
+	require.Equal(t, fromAccount.Balance-review.Amount, result.Transfer.FromAccount.Balance)
+	require.Equal(t, toAccount.Balance+review.Amount, result.Transfer.ToAccount.Balance)
+}
+
+func TestReviewTransferTxReject(t *testing.T) {
+	store := NewStore(testDB)
+
+	fromAccount := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	review := createRandomTransferReview(t, fromAccount, toAccount)
+
+	result, err := store.ReviewTransferTx(context.Background(), ReviewTransferTxParams{
+		ReviewID:   review.ID,
+		ReviewedBy: "admin",
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferReviewRejected, result.Review.Status)
+	require.False(t, result.Review.TransferID.Valid)
+	require.Nil(t, result.Transfer)
+
+	// nothing was posted, so balances are untouched
+	updatedAccount, err := testQueries.GetAccount(context.Background(), fromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.Balance, updatedAccount.Balance)
+
+	// a decided review cannot be decided again
+	_, err = store.ReviewTransferTx(context.Background(), ReviewTransferTxParams{
+		ReviewID:   review.ID,
+		Approve:    true,
+		ReviewedBy: "admin",
+	})
+	require.ErrorIs(t, err, ErrReviewNotPending)
+}
@@ -7,8 +7,63 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countTransfersBetweenAccounts = `-- name: CountTransfersBetweenAccounts :one
+SELECT count(*) FROM transfers
+WHERE from_account_id = $1 AND to_account_id = $2
+`
+
+type CountTransfersBetweenAccountsParams struct {
+	FromAccountID int64
+	ToAccountID   int64
+}
+
+func (q *Queries) CountTransfersBetweenAccounts(ctx context.Context, arg CountTransfersBetweenAccountsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTransfersBetweenAccounts, arg.FromAccountID, arg.ToAccountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTransfersFromAccountSince = `-- name: CountTransfersFromAccountSince :one
+SELECT count(*) FROM transfers
+WHERE from_account_id = $1
+  AND created_at >= $2
+`
+
+type CountTransfersFromAccountSinceParams struct {
+	FromAccountID int64
+	Since         pgtype.Timestamptz
+}
+
+func (q *Queries) CountTransfersFromAccountSince(ctx context.Context, arg CountTransfersFromAccountSinceParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTransfersFromAccountSince, arg.FromAccountID, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTransfersToCountry = `-- name: CountTransfersToCountry :one
+SELECT count(*) FROM transfers t
+JOIN accounts a ON a.id = t.to_account_id
+WHERE t.from_account_id = $1 AND a.country_code = $2
+`
+
+type CountTransfersToCountryParams struct {
+	FromAccountID int64
+	CountryCode   int32
+}
+
+func (q *Queries) CountTransfersToCountry(ctx context.Context, arg CountTransfersToCountryParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTransfersToCountry, arg.FromAccountID, arg.CountryCode)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
   from_account_id, to_account_id, amount
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrInvalidVerifyEmail is returned when a verification code doesn't match, was already used or has expired
+	ErrInvalidVerifyEmail = errors.New("email verification code is invalid, used or expired")
+	// ErrInvalidPasswordReset is returned when a reset token is unknown, was already used or has expired
+	ErrInvalidPasswordReset = errors.New("password reset token is invalid, used or expired")
+)
+
+// CreateUserTxParams contains the input parameters of the create user transaction
+type CreateUserTxParams struct {
+	CreateUserParams
+	// AfterCreate runs inside the transaction once the user exists, e.g. to queue the verification email.
+	// An error rolls the user back.
+	AfterCreate func(q *Queries, user User) error
+}
+
+// CreateUserTx creates a user with the customer role and runs AfterCreate in the same transaction
+func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		user, err = q.CreateUser(ctx, arg.CreateUserParams)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.GrantUserRole(ctx, GrantUserRoleParams{
+			Username:  user.Username,
+			Role:      RoleCustomer,
+			GrantedBy: GrantedBySystem,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterCreate == nil {
+			return nil
+		}
+
+		return arg.AfterCreate(q, user)
+	})
+
+	return user, err
+}
+
+// VerifyEmailTxParams contains the input parameters of the verify email transaction
+type VerifyEmailTxParams struct {
+	ID         int64
+	SecretCode string
+}
+
+// VerifyEmailTxResult is the result of the verify email transaction
+type VerifyEmailTxResult struct {
+	User        User
+	VerifyEmail VerifyEmail
+}
+
+// VerifyEmailTx uses up a verification code and marks its user's email as verified.
+// It fails with ErrInvalidVerifyEmail for unknown, used or expired codes,
+// and for codes sent to an address the user no longer has.
+func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error) {
+	var result VerifyEmailTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.VerifyEmail, err = q.UseVerifyEmail(ctx, UseVerifyEmailParams{
+			ID:         arg.ID,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrInvalidVerifyEmail
+			}
+			return err
+		}
+
+		result.User, err = q.MarkUserEmailVerified(ctx, MarkUserEmailVerifiedParams{
+			Username: result.VerifyEmail.Username,
+			Email:    result.VerifyEmail.Email,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrInvalidVerifyEmail
+			}
+			return err
+		}
+
+		// the user row carries the password hash and the code row its secret, so only these fields are kept
+		return q.RecordAudit(ctx, AuditRecord{
+			Actor:        result.User.Username,
+			Action:       AuditUserVerifyEmail,
+			ResourceType: "user",
+			ResourceID:   result.User.Username,
+			After: map[string]any{
+				"id":       result.VerifyEmail.ID,
+				"username": result.VerifyEmail.Username,
+				"email":    result.VerifyEmail.Email,
+				"is_used":  result.VerifyEmail.IsUsed,
+			},
+		})
+	})
+
+	return result, err
+}
+
+// UpdatePasswordTx changes a user's password. Outstanding reset tokens are revoked,
+// and password_changed_at moves forward so access tokens issued earlier are rejected.
+func (store *SQLStore) UpdatePasswordTx(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		user, err = updatePassword(ctx, q, arg, AuditUserPasswordChange)
+		return err
+	})
+
+	return user, err
+}
+
+// ResetPasswordTxParams contains the input parameters of the reset password transaction
+type ResetPasswordTxParams struct {
+	TokenHash         string
+	HashedPassword    string
+	PasswordChangedAt pgtype.Timestamptz
+}
+
+// ResetPasswordTx uses up a reset token and sets the password of the user it was issued to.
+// It fails with ErrInvalidPasswordReset for unknown, used or expired tokens.
+func (store *SQLStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		reset, err := q.UsePasswordReset(ctx, arg.TokenHash)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrInvalidPasswordReset
+			}
+			return err
+		}
+
+		user, err = updatePassword(ctx, q, UpdateUserPasswordParams{
+			Username:          reset.Username,
+			HashedPassword:    arg.HashedPassword,
+			PasswordChangedAt: arg.PasswordChangedAt,
+		}, AuditUserPasswordReset)
+		return err
+	})
+
+	return user, err
+}
+
+func updatePassword(ctx context.Context, q *Queries, arg UpdateUserPasswordParams, action string) (User, error) {
+	user, err := q.UpdateUserPassword(ctx, arg)
+	if err != nil {
+		return User{}, err
+	}
+
+	err = q.ExpirePasswordResets(ctx, user.Username)
+	if err != nil {
+		return User{}, err
+	}
+
+	// password hashes stay out of the audit log
+	err = q.RecordAudit(ctx, AuditRecord{
+		Actor:        user.Username,
+		Action:       action,
+		ResourceType: "user",
+		ResourceID:   user.Username,
+	})
+	return user, err
+}
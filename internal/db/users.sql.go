@@ -21,7 +21,7 @@ INSERT INTO users (
 ) VALUES (
   $1, $2, $3, $4, $5
 )
-RETURNING id, username, hashed_password, full_name, email, password_changed_at, created_at, updated_at
+RETURNING id, username, hashed_password, full_name, email, date_of_birth, address, national_id, kyc_status, kyc_tier, kyc_verified_at, is_email_verified, password_changed_at, created_at, updated_at
 `
 
 type CreateUserParams struct {
@@ -48,6 +48,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.HashedPassword,
 		&i.FullName,
 		&i.Email,
+		&i.DateOfBirth,
+		&i.Address,
+		&i.NationalID,
+		&i.KycStatus,
+		&i.KycTier,
+		&i.KycVerifiedAt,
+		&i.IsEmailVerified,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -56,7 +63,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, username, hashed_password, full_name, email, password_changed_at, created_at, updated_at FROM users
+SELECT id, username, hashed_password, full_name, email, date_of_birth, address, national_id, kyc_status, kyc_tier, kyc_verified_at, is_email_verified, password_changed_at, created_at, updated_at FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -69,6 +76,204 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.HashedPassword,
 		&i.FullName,
 		&i.Email,
+		&i.DateOfBirth,
+		&i.Address,
+		&i.NationalID,
+		&i.KycStatus,
+		&i.KycTier,
+		&i.KycVerifiedAt,
+		&i.IsEmailVerified,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserPasswordChangedAt = `-- name: GetUserPasswordChangedAt :one
+SELECT password_changed_at FROM users
+WHERE username = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserPasswordChangedAt(ctx context.Context, username string) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getUserPasswordChangedAt, username)
+	var password_changed_at pgtype.Timestamptz
+	err := row.Scan(&password_changed_at)
+	return password_changed_at, err
+}
+
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :one
+UPDATE users
+SET is_email_verified = true,
+    updated_at = now()
+WHERE username = $1 AND email = $2
+RETURNING id, username, hashed_password, full_name, email, date_of_birth, address, national_id, kyc_status, kyc_tier, kyc_verified_at, is_email_verified, password_changed_at, created_at, updated_at
+`
+
+type MarkUserEmailVerifiedParams struct {
+	Username string
+	Email    string
+}
+
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (User, error) {
+	row := q.db.QueryRow(ctx, markUserEmailVerified, arg.Username, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.DateOfBirth,
+		&i.Address,
+		&i.NationalID,
+		&i.KycStatus,
+		&i.KycTier,
+		&i.KycVerifiedAt,
+		&i.IsEmailVerified,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, username, hashed_password, full_name, email, date_of_birth, address, national_id, kyc_status, kyc_tier, kyc_verified_at, is_email_verified, password_changed_at, created_at, updated_at FROM users
+WHERE $1::varchar IS NULL
+   OR username ILIKE '%' || $1 || '%'
+   OR email ILIKE '%' || $1 || '%'
+   OR full_name ILIKE '%' || $1 || '%'
+ORDER BY username
+LIMIT $3
+OFFSET $2
+`
+
+type SearchUsersParams struct {
+	Query  pgtype.Text
+	Offset int32
+	Limit  int32
+}
+
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, searchUsers, arg.Query, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.HashedPassword,
+			&i.FullName,
+			&i.Email,
+			&i.DateOfBirth,
+			&i.Address,
+			&i.NationalID,
+			&i.KycStatus,
+			&i.KycTier,
+			&i.KycVerifiedAt,
+			&i.IsEmailVerified,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateUserKYC = `-- name: UpdateUserKYC :one
+UPDATE users
+SET date_of_birth = $1,
+    address = $2,
+    national_id = $3,
+    kyc_status = $4::varchar,
+    kyc_tier = $5,
+    kyc_verified_at = CASE WHEN $4::varchar = 'verified' THEN now() ELSE NULL END,
+    updated_at = now()
+WHERE username = $6
+RETURNING id, username, hashed_password, full_name, email, date_of_birth, address, national_id, kyc_status, kyc_tier, kyc_verified_at, is_email_verified, password_changed_at, created_at, updated_at
+`
+
+type UpdateUserKYCParams struct {
+	DateOfBirth pgtype.Date
+	Address     pgtype.Text
+	NationalID  pgtype.Text
+	KycStatus   string
+	KycTier     int32
+	Username    string
+}
+
+func (q *Queries) UpdateUserKYC(ctx context.Context, arg UpdateUserKYCParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserKYC,
+		arg.DateOfBirth,
+		arg.Address,
+		arg.NationalID,
+		arg.KycStatus,
+		arg.KycTier,
+		arg.Username,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.DateOfBirth,
+		&i.Address,
+		&i.NationalID,
+		&i.KycStatus,
+		&i.KycTier,
+		&i.KycVerifiedAt,
+		&i.IsEmailVerified,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE users
+SET hashed_password = $1,
+    password_changed_at = $2,
+    updated_at = now()
+WHERE username = $3
+RETURNING id, username, hashed_password, full_name, email, date_of_birth, address, national_id, kyc_status, kyc_tier, kyc_verified_at, is_email_verified, password_changed_at, created_at, updated_at
+`
+
+type UpdateUserPasswordParams struct {
+	HashedPassword    string
+	PasswordChangedAt pgtype.Timestamptz
+	Username          string
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserPassword, arg.HashedPassword, arg.PasswordChangedAt, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.DateOfBirth,
+		&i.Address,
+		&i.NationalID,
+		&i.KycStatus,
+		&i.KycTier,
+		&i.KycVerifiedAt,
+		&i.IsEmailVerified,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"simple_bank/util"
+)
+
+func createRandomUser(t *testing.T) User {
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	arg := CreateUserParams{
+		Username:          util.RandomOwner(),
+		HashedPassword:    hashedPassword,
+		FullName:          util.RandomOwner(),
+		Email:             util.RandomEmail(),
+		PasswordChangedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+
+	user, err := testQueries.CreateUser(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, user)
+
+	require.Equal(t, arg.Username, user.Username)
+	require.Equal(t, arg.HashedPassword, user.HashedPassword)
+	require.Equal(t, arg.FullName, user.FullName)
+	require.Equal(t, arg.Email, user.Email)
+	require.Equal(t, "unverified", user.KycStatus)
+	require.Zero(t, user.KycTier)
+
+	require.NotZero(t, user.ID)
+	require.NotZero(t, user.CreatedAt)
+
+	return user
+}
+
+func TestCreateUser(t *testing.T) {
+	createRandomUser(t)
+}
+
+func TestGetUser(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	user2, err := testQueries.GetUser(context.Background(), user1.Username)
+	require.NoError(t, err)
+	require.Equal(t, user1.ID, user2.ID)
+	require.Equal(t, user1.Email, user2.Email)
+	require.WithinDuration(t, user1.CreatedAt.Time, user2.CreatedAt.Time, time.Second)
+}
+
+func TestUpdateUserKYC(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	arg := UpdateUserKYCParams{
+		Username:    user1.Username,
+		DateOfBirth: pgtype.Date{Time: time.Date(1990, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Address:     pgtype.Text{String: "1 Main Street", Valid: true},
+		NationalID:  pgtype.Text{String: "12345678", Valid: true},
+		KycStatus:   "verified",
+		KycTier:     1,
+	}
+
+	user2, err := testQueries.UpdateUserKYC(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.KycStatus, user2.KycStatus)
+	require.Equal(t, arg.KycTier, user2.KycTier)
+	require.Equal(t, arg.NationalID, user2.NationalID)
+	require.True(t, user2.KycVerifiedAt.Valid)
+}
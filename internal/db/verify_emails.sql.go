@@ -0,0 +1,71 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: verify_emails.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createVerifyEmail = `-- name: CreateVerifyEmail :one
+INSERT INTO verify_emails (
+  username, email, secret_code
+) VALUES (
+  $1, $2, $3
+)
+RETURNING id, username, email, secret_code, is_used, created_at, expired_at
+`
+
+type CreateVerifyEmailParams struct {
+	Username   string
+	Email      string
+	SecretCode string
+}
+
+// VERIFY EMAILS
+func (q *Queries) CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error) {
+	row := q.db.QueryRow(ctx, createVerifyEmail, arg.Username, arg.Email, arg.SecretCode)
+	var i VerifyEmail
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
+
+const useVerifyEmail = `-- name: UseVerifyEmail :one
+UPDATE verify_emails
+SET is_used = true
+WHERE id = $1
+  AND secret_code = $2
+  AND is_used = false
+  AND expired_at > now()
+RETURNING id, username, email, secret_code, is_used, created_at, expired_at
+`
+
+type UseVerifyEmailParams struct {
+	ID         int64
+	SecretCode string
+}
+
+func (q *Queries) UseVerifyEmail(ctx context.Context, arg UseVerifyEmailParams) (VerifyEmail, error) {
+	row := q.db.QueryRow(ctx, useVerifyEmail, arg.ID, arg.SecretCode)
+	var i VerifyEmail
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
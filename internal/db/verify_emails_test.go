@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"simple_bank/util"
+)
+
+func createRandomVerifyEmail(t *testing.T, user User) VerifyEmail {
+	arg := CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: util.RandomString(32),
+	}
+
+	verifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Username, verifyEmail.Username)
+	require.Equal(t, arg.Email, verifyEmail.Email)
+	require.Equal(t, arg.SecretCode, verifyEmail.SecretCode)
+	require.False(t, verifyEmail.IsUsed)
+	require.True(t, verifyEmail.ExpiredAt.Time.After(verifyEmail.CreatedAt.Time))
+
+	return verifyEmail
+}
+
+func TestVerifyEmailTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	require.False(t, user.IsEmailVerified)
+
+	verifyEmail := createRandomVerifyEmail(t, user)
+
+	_, err := store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		ID:         verifyEmail.ID,
+		SecretCode: "wrong",
+	})
+	require.ErrorIs(t, err, ErrInvalidVerifyEmail)
+
+	result, err := store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		ID:         verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+	require.True(t, result.VerifyEmail.IsUsed)
+	require.True(t, result.User.IsEmailVerified)
+
+	// the audit row names the code without keeping its secret
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Actor: pgtype.Text{String: user.Username, Valid: true},
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	require.Equal(t, AuditUserVerifyEmail, events[0].Action)
+	require.Contains(t, string(events[0].After), `"is_used":true`)
+	require.NotContains(t, string(events[0].After), verifyEmail.SecretCode)
+
+	// codes are single use
+	_, err = store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		ID:         verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.ErrorIs(t, err, ErrInvalidVerifyEmail)
+}
+
+func TestVerifyEmailTxChangedAddress(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	verifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      util.RandomEmail(),
+		SecretCode: util.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	_, err = store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		ID:         verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.ErrorIs(t, err, ErrInvalidVerifyEmail)
+
+	found, err := testQueries.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.False(t, found.IsEmailVerified)
+}
+
+func TestCreateUserTxRollsBack(t *testing.T) {
+	store := NewStore(testDB)
+	username := util.RandomOwner()
+
+	_, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:          username,
+			HashedPassword:    util.RandomString(32),
+			FullName:          util.RandomOwner(),
+			Email:             util.RandomEmail(),
+			PasswordChangedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		},
+		AfterCreate: func(q *Queries, user User) error {
+			return context.Canceled
+		},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = testQueries.GetUser(context.Background(), username)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
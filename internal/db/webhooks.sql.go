@@ -0,0 +1,364 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: webhooks.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimDueWebhookDeliveries = `-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = $1
+FROM webhook_endpoints
+WHERE webhook_endpoints.id = webhook_deliveries.endpoint_id
+  AND webhook_deliveries.id IN (
+    SELECT id FROM webhook_deliveries AS due
+    WHERE due.status = 'pending'
+      AND due.next_attempt_at <= now()
+    ORDER BY due.id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING webhook_deliveries.id, webhook_deliveries.endpoint_id, webhook_deliveries.event_id, webhook_deliveries.event_type, webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at, webhook_deliveries.last_error, webhook_deliveries.response_status, webhook_deliveries.delivered_at, webhook_deliveries.created_at, webhook_endpoints.url, webhook_endpoints.secret
+`
+
+type ClaimDueWebhookDeliveriesParams struct {
+	LeaseUntil pgtype.Timestamptz
+	Limit      int32
+}
+
+type ClaimDueWebhookDeliveriesRow struct {
+	WebhookDelivery WebhookDelivery
+	Url             string
+	Secret          string
+}
+
+// a claimed delivery isn't due again until lease_until, so concurrent deliverers never send it twice;
+// recording the attempt sets its real next attempt
+func (q *Queries) ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, claimDueWebhookDeliveries, arg.LeaseUntil, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClaimDueWebhookDeliveriesRow{}
+	for rows.Next() {
+		var i ClaimDueWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.WebhookDelivery.ID,
+			&i.WebhookDelivery.EndpointID,
+			&i.WebhookDelivery.EventID,
+			&i.WebhookDelivery.EventType,
+			&i.WebhookDelivery.Payload,
+			&i.WebhookDelivery.Status,
+			&i.WebhookDelivery.Attempts,
+			&i.WebhookDelivery.NextAttemptAt,
+			&i.WebhookDelivery.LastError,
+			&i.WebhookDelivery.ResponseStatus,
+			&i.WebhookDelivery.DeliveredAt,
+			&i.WebhookDelivery.CreatedAt,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+  endpoint_id, event_id, event_type, payload
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (endpoint_id, event_id) DO NOTHING
+`
+
+type CreateWebhookDeliveryParams struct {
+	EndpointID int64
+	EventID    int64
+	EventType  string
+	Payload    json.RawMessage
+}
+
+// WEBHOOK DELIVERIES
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error {
+	_, err := q.db.Exec(ctx, createWebhookDelivery,
+		arg.EndpointID,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+	)
+	return err
+}
+
+const createWebhookEndpoint = `-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (
+  merchant_id, url, secret, event_types
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING id, merchant_id, url, secret, event_types, active, created_at
+`
+
+type CreateWebhookEndpointParams struct {
+	MerchantID int64
+	Url        string
+	Secret     string
+	EventTypes []string
+}
+
+// WEBHOOK ENDPOINTS
+func (q *Queries) CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error) {
+	row := q.db.QueryRow(ctx, createWebhookEndpoint,
+		arg.MerchantID,
+		arg.Url,
+		arg.Secret,
+		arg.EventTypes,
+	)
+	var i WebhookEndpoint
+	err := row.Scan(
+		&i.ID,
+		&i.MerchantID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteWebhookEndpoint = `-- name: DeleteWebhookEndpoint :one
+DELETE FROM webhook_endpoints
+WHERE id = $1 AND merchant_id = $2
+RETURNING id, merchant_id, url, secret, event_types, active, created_at
+`
+
+type DeleteWebhookEndpointParams struct {
+	ID         int64
+	MerchantID int64
+}
+
+func (q *Queries) DeleteWebhookEndpoint(ctx context.Context, arg DeleteWebhookEndpointParams) (WebhookEndpoint, error) {
+	row := q.db.QueryRow(ctx, deleteWebhookEndpoint, arg.ID, arg.MerchantID)
+	var i WebhookEndpoint
+	err := row.Scan(
+		&i.ID,
+		&i.MerchantID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT webhook_deliveries.id, webhook_deliveries.endpoint_id, webhook_deliveries.event_id, webhook_deliveries.event_type, webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at, webhook_deliveries.last_error, webhook_deliveries.response_status, webhook_deliveries.delivered_at, webhook_deliveries.created_at FROM webhook_deliveries
+JOIN webhook_endpoints ON webhook_endpoints.id = webhook_deliveries.endpoint_id
+WHERE webhook_endpoints.merchant_id = $1
+  AND ($2::varchar IS NULL OR webhook_deliveries.status = $2)
+ORDER BY webhook_deliveries.id DESC
+LIMIT $4
+OFFSET $3
+`
+
+type ListWebhookDeliveriesParams struct {
+	MerchantID int64
+	Status     pgtype.Text
+	Offset     int32
+	Limit      int32
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveries,
+		arg.MerchantID,
+		arg.Status,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookDelivery{}
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.EndpointID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.ResponseStatus,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookEndpoints = `-- name: ListWebhookEndpoints :many
+SELECT id, merchant_id, url, secret, event_types, active, created_at FROM webhook_endpoints
+WHERE merchant_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListWebhookEndpoints(ctx context.Context, merchantID int64) ([]WebhookEndpoint, error) {
+	rows, err := q.db.Query(ctx, listWebhookEndpoints, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookEndpoint{}
+	for rows.Next() {
+		var i WebhookEndpoint
+		if err := rows.Scan(
+			&i.ID,
+			&i.MerchantID,
+			&i.Url,
+			&i.Secret,
+			&i.EventTypes,
+			&i.Active,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookEndpointsForEvent = `-- name: ListWebhookEndpointsForEvent :many
+SELECT id, merchant_id, url, secret, event_types, active, created_at FROM webhook_endpoints
+WHERE merchant_id = ANY($1::bigint[])
+  AND active
+  AND (cardinality(event_types) = 0 OR $2::text = ANY(event_types))
+ORDER BY id
+`
+
+type ListWebhookEndpointsForEventParams struct {
+	MerchantIds []int64
+	EventType   string
+}
+
+func (q *Queries) ListWebhookEndpointsForEvent(ctx context.Context, arg ListWebhookEndpointsForEventParams) ([]WebhookEndpoint, error) {
+	rows, err := q.db.Query(ctx, listWebhookEndpointsForEvent, arg.MerchantIds, arg.EventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookEndpoint{}
+	for rows.Next() {
+		var i WebhookEndpoint
+		if err := rows.Scan(
+			&i.ID,
+			&i.MerchantID,
+			&i.Url,
+			&i.Secret,
+			&i.EventTypes,
+			&i.Active,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordWebhookDeliveryAttempt = `-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET status = $1,
+    attempts = attempts + 1,
+    next_attempt_at = $2,
+    last_error = $3,
+    response_status = $4,
+    delivered_at = CASE WHEN $1::varchar = 'succeeded' THEN now() ELSE NULL END
+WHERE id = $5
+`
+
+type RecordWebhookDeliveryAttemptParams struct {
+	Status         string
+	NextAttemptAt  pgtype.Timestamptz
+	LastError      pgtype.Text
+	ResponseStatus pgtype.Int4
+	ID             int64
+}
+
+func (q *Queries) RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) error {
+	_, err := q.db.Exec(ctx, recordWebhookDeliveryAttempt,
+		arg.Status,
+		arg.NextAttemptAt,
+		arg.LastError,
+		arg.ResponseStatus,
+		arg.ID,
+	)
+	return err
+}
+
+const retryWebhookDelivery = `-- name: RetryWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending',
+    next_attempt_at = now()
+FROM webhook_endpoints
+WHERE webhook_deliveries.id = $1
+  AND webhook_endpoints.id = webhook_deliveries.endpoint_id
+  AND webhook_endpoints.merchant_id = $2
+  AND webhook_deliveries.status = 'dead'
+RETURNING webhook_deliveries.id, webhook_deliveries.endpoint_id, webhook_deliveries.event_id, webhook_deliveries.event_type, webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at, webhook_deliveries.last_error, webhook_deliveries.response_status, webhook_deliveries.delivered_at, webhook_deliveries.created_at
+`
+
+type RetryWebhookDeliveryParams struct {
+	ID         int64
+	MerchantID int64
+}
+
+func (q *Queries) RetryWebhookDelivery(ctx context.Context, arg RetryWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, retryWebhookDelivery, arg.ID, arg.MerchantID)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.EndpointID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.ResponseStatus,
+		&i.DeliveredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"simple_bank/util"
+)
+
+func createRandomWebhookEndpoint(t *testing.T, eventTypes ...string) WebhookEndpoint {
+	merchant := createRandomMerchant(t, createRandomAccount(t), createRandomCountry(t))
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	endpoint, err := testQueries.CreateWebhookEndpoint(context.Background(), CreateWebhookEndpointParams{
+		MerchantID: merchant.ID,
+		Url:        "https://" + util.RandomOwner() + ".example/hooks",
+		Secret:     util.RandomString(32),
+		EventTypes: eventTypes,
+	})
+	require.NoError(t, err)
+	require.True(t, endpoint.Active)
+
+	return endpoint
+}
+
+func TestListWebhookEndpointsForEvent(t *testing.T) {
+	all := createRandomWebhookEndpoint(t)
+	orders := createRandomWebhookEndpoint(t, EventOrderCreated)
+
+	endpoints, err := testQueries.ListWebhookEndpointsForEvent(context.Background(), ListWebhookEndpointsForEventParams{
+		MerchantIds: []int64{all.MerchantID, orders.MerchantID},
+		EventType:   EventTransferCreated,
+	})
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	require.Equal(t, all.ID, endpoints[0].ID)
+}
+
+func TestWebhookDeliveryLifecycle(t *testing.T) {
+	endpoint := createRandomWebhookEndpoint(t)
+	eventID := util.RandomInt(1, 1_000_000_000)
+
+	arg := CreateWebhookDeliveryParams{
+		EndpointID: endpoint.ID,
+		EventID:    eventID,
+		EventType:  EventTransferCreated,
+		Payload:    json.RawMessage(`{"id":1}`),
+	}
+
+	// the outbox may publish an event twice, the second delivery is dropped
+	require.NoError(t, testQueries.CreateWebhookDelivery(context.Background(), arg))
+	require.NoError(t, testQueries.CreateWebhookDelivery(context.Background(), arg))
+
+	deliveries, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		MerchantID: endpoint.MerchantID,
+		Limit:      5,
+		Offset:     0,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	delivery := deliveries[0]
+	require.Equal(t, "pending", delivery.Status)
+
+	err = testQueries.RecordWebhookDeliveryAttempt(context.Background(), RecordWebhookDeliveryAttemptParams{
+		ID:            delivery.ID,
+		Status:        "dead",
+		NextAttemptAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		LastError:     pgtype.Text{String: "unexpected status 500", Valid: true},
+	})
+	require.NoError(t, err)
+
+	retried, err := testQueries.RetryWebhookDelivery(context.Background(), RetryWebhookDeliveryParams{
+		ID:         delivery.ID,
+		MerchantID: endpoint.MerchantID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending", retried.Status)
+	require.Equal(t, int32(1), retried.Attempts)
+
+	// only dead deliveries can be retried
+	_, err = testQueries.RetryWebhookDelivery(context.Background(), RetryWebhookDeliveryParams{
+		ID:         delivery.ID,
+		MerchantID: endpoint.MerchantID,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestClaimDueWebhookDeliveries(t *testing.T) {
+	endpoint := createRandomWebhookEndpoint(t)
+	eventID := util.RandomInt(1, 1_000_000_000)
+
+	require.NoError(t, testQueries.CreateWebhookDelivery(context.Background(), CreateWebhookDeliveryParams{
+		EndpointID: endpoint.ID,
+		EventID:    eventID,
+		EventType:  EventTransferCreated,
+		Payload:    json.RawMessage(`{"id":1}`),
+	}))
+
+	// claim returns the delivery of eventID if claiming a batch returned it
+	claim := func() *ClaimDueWebhookDeliveriesRow {
+		rows, err := testQueries.ClaimDueWebhookDeliveries(context.Background(), ClaimDueWebhookDeliveriesParams{
+			LeaseUntil: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+			Limit:      1000,
+		})
+		require.NoError(t, err)
+
+		for _, row := range rows {
+			if row.WebhookDelivery.EventID == eventID {
+				return &row
+			}
+		}
+		return nil
+	}
+
+	row := claim()
+	require.NotNil(t, row)
+	require.Equal(t, endpoint.Url, row.Url)
+	require.Equal(t, endpoint.Secret, row.Secret)
+
+	// leased to the first deliverer, so no other one sends it meanwhile
+	require.Nil(t, claim())
+}
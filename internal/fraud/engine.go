@@ -0,0 +1,69 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"simple_bank/internal/db"
+)
+
+// Store is the part of db.Store the rules need to look at an account's transfer history
+type Store interface {
+	CountTransfersBetweenAccounts(ctx context.Context, arg db.CountTransfersBetweenAccountsParams) (int64, error)
+	CountTransfersFromAccountSince(ctx context.Context, arg db.CountTransfersFromAccountSinceParams) (int64, error)
+	CountTransfersToCountry(ctx context.Context, arg db.CountTransfersToCountryParams) (int64, error)
+}
+
+// Transfer is a transfer that has not been posted yet, together with both accounts it touches
+type Transfer struct {
+	FromAccount db.Account
+	ToAccount   db.Account
+	Amount      int64
+}
+
+// Rule inspects a transfer before it is posted and reports whether it looks suspicious
+type Rule interface {
+	// Name identifies the rule in the reasons recorded on a review
+	Name() string
+	Check(ctx context.Context, store Store, transfer Transfer) (bool, error)
+}
+
+// Decision is the outcome of screening a transfer.
+// Reasons holds the names of every rule that flagged it.
+type Decision struct {
+	Flagged bool
+	Reasons []string
+}
+
+// Engine runs every configured rule against a transfer
+type Engine struct {
+	store Store
+	rules []Rule
+}
+
+// NewEngine creates a fraud engine that evaluates rules against the store's transfer history
+func NewEngine(store Store, rules ...Rule) *Engine {
+	return &Engine{
+		store: store,
+		rules: rules,
+	}
+}
+
+// Screen evaluates all rules, rather than stopping at the first hit,
+// so reviewers see every reason a transfer was held.
+func (engine *Engine) Screen(ctx context.Context, transfer Transfer) (Decision, error) {
+	var decision Decision
+
+	for _, rule := range engine.rules {
+		flagged, err := rule.Check(ctx, engine.store, transfer)
+		if err != nil {
+			return Decision{}, fmt.Errorf("rule %s: %w", rule.Name(), err)
+		}
+
+		if flagged {
+			decision.Flagged = true
+			decision.Reasons = append(decision.Reasons, rule.Name())
+		}
+	}
+
+	return decision, nil
+}
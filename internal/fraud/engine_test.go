@@ -0,0 +1,104 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"simple_bank/internal/db"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore answers the history queries with fixed counts
+type fakeStore struct {
+	betweenAccounts int64
+	recent          int64
+	toCountry       int64
+	err             error
+}
+
+func (store fakeStore) CountTransfersBetweenAccounts(ctx context.Context, arg db.CountTransfersBetweenAccountsParams) (int64, error) {
+	return store.betweenAccounts, store.err
+}
+
+func (store fakeStore) CountTransfersFromAccountSince(ctx context.Context, arg db.CountTransfersFromAccountSinceParams) (int64, error) {
+	return store.recent, store.err
+}
+
+func (store fakeStore) CountTransfersToCountry(ctx context.Context, arg db.CountTransfersToCountryParams) (int64, error) {
+	return store.toCountry, store.err
+}
+
+func TestScreen(t *testing.T) {
+	domestic := Transfer{
+		FromAccount: db.Account{ID: 1, CountryCode: 1},
+		ToAccount:   db.Account{ID: 2, CountryCode: 1},
+		Amount:      10,
+	}
+
+	crossBorder := domestic
+	crossBorder.ToAccount.CountryCode = 2
+
+	large := domestic
+	large.Amount = DefaultLargeAmount
+
+	testCases := []struct {
+		name        string
+		store       fakeStore
+		transfer    Transfer
+		wantReasons []string
+	}{
+		{
+			name:     "Clean",
+			store:    fakeStore{betweenAccounts: 1, toCountry: 1},
+			transfer: domestic,
+		},
+		{
+			name:        "NewPayeeLargeAmount",
+			store:       fakeStore{},
+			transfer:    large,
+			wantReasons: []string{"new_payee_large_amount"},
+		},
+		{
+			name:     "KnownPayeeLargeAmount",
+			store:    fakeStore{betweenAccounts: 2},
+			transfer: large,
+		},
+		{
+			name:        "RapidTransfers",
+			store:       fakeStore{betweenAccounts: 1, recent: DefaultRapidTransfers},
+			transfer:    domestic,
+			wantReasons: []string{"rapid_transfers"},
+		},
+		{
+			name:        "UnusualCountryPair",
+			store:       fakeStore{betweenAccounts: 1},
+			transfer:    crossBorder,
+			wantReasons: []string{"unusual_country_pair"},
+		},
+		{
+			name:     "KnownCountryPair",
+			store:    fakeStore{betweenAccounts: 1, toCountry: 4},
+			transfer: crossBorder,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			engine := NewEngine(tc.store, DefaultRules()...)
+
+			decision, err := engine.Screen(context.Background(), tc.transfer)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.wantReasons) > 0, decision.Flagged)
+			require.Equal(t, tc.wantReasons, decision.Reasons)
+		})
+	}
+}
+
+func TestScreenStoreError(t *testing.T) {
+	engine := NewEngine(fakeStore{err: errors.New("connection refused")}, DefaultRules()...)
+
+	_, err := engine.Screen(context.Background(), Transfer{Amount: DefaultLargeAmount})
+	require.Error(t, err)
+}
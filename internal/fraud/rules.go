@@ -0,0 +1,97 @@
+package fraud
+
+import (
+	"context"
+	"simple_bank/internal/db"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// default thresholds used by DefaultRules
+const (
+	DefaultLargeAmount    = 5000
+	DefaultRapidWindow    = time.Minute
+	DefaultRapidTransfers = 3
+)
+
+// DefaultRules returns the rule set the API screens transfers with
+func DefaultRules() []Rule {
+	return []Rule{
+		NewPayeeLargeAmount{Threshold: DefaultLargeAmount},
+		RapidTransfers{Window: DefaultRapidWindow, Max: DefaultRapidTransfers},
+		UnusualCountryPair{},
+	}
+}
+
+// NewPayeeLargeAmount flags transfers of at least Threshold to an account
+// the sender has never paid before.
+type NewPayeeLargeAmount struct {
+	Threshold int64
+}
+
+func (rule NewPayeeLargeAmount) Name() string {
+	return "new_payee_large_amount"
+}
+
+func (rule NewPayeeLargeAmount) Check(ctx context.Context, store Store, transfer Transfer) (bool, error) {
+	if transfer.Amount < rule.Threshold {
+		return false, nil
+	}
+
+	count, err := store.CountTransfersBetweenAccounts(ctx, db.CountTransfersBetweenAccountsParams{
+		FromAccountID: transfer.FromAccount.ID,
+		ToAccountID:   transfer.ToAccount.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count == 0, nil
+}
+
+// RapidTransfers flags a sender that already made Max or more transfers within Window
+type RapidTransfers struct {
+	Window time.Duration
+	Max    int64
+}
+
+func (rule RapidTransfers) Name() string {
+	return "rapid_transfers"
+}
+
+func (rule RapidTransfers) Check(ctx context.Context, store Store, transfer Transfer) (bool, error) {
+	count, err := store.CountTransfersFromAccountSince(ctx, db.CountTransfersFromAccountSinceParams{
+		FromAccountID: transfer.FromAccount.ID,
+		Since:         pgtype.Timestamptz{Time: time.Now().Add(-rule.Window), Valid: true},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count >= rule.Max, nil
+}
+
+// UnusualCountryPair flags cross-border transfers to a country
+// the sender has never sent money to before.
+type UnusualCountryPair struct{}
+
+func (rule UnusualCountryPair) Name() string {
+	return "unusual_country_pair"
+}
+
+func (rule UnusualCountryPair) Check(ctx context.Context, store Store, transfer Transfer) (bool, error) {
+	if transfer.FromAccount.CountryCode == transfer.ToAccount.CountryCode {
+		return false, nil
+	}
+
+	count, err := store.CountTransfersToCountry(ctx, db.CountTransfersToCountryParams{
+		FromAccountID: transfer.FromAccount.ID,
+		CountryCode:   transfer.ToAccount.CountryCode,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count == 0, nil
+}
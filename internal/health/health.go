@@ -0,0 +1,137 @@
+// Package health checks whether the bank can serve traffic: whether its dependencies answer
+// and whether its background workers are still making progress.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Statuses of a check and of a whole report
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+)
+
+// DefaultTimeout bounds each check run by Run
+const DefaultTimeout = 2 * time.Second
+
+// Check is a named dependency check. A failing critical check makes the service unavailable,
+// any other failing check only degrades it.
+type Check struct {
+	Name     string
+	Critical bool
+	Run      func(ctx context.Context) error
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Name     string
+	Status   string
+	Critical bool
+	Err      error
+	Duration time.Duration
+}
+
+// Report is the outcome of a set of checks
+type Report struct {
+	Status  string
+	Results []Result
+}
+
+// Run runs checks in parallel, each bounded by DefaultTimeout, and reports them in the order given
+func Run(ctx context.Context, checks []Check) Report {
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Run(checkCtx)
+			result := Result{
+				Name:     check.Name,
+				Status:   StatusOK,
+				Critical: check.Critical,
+				Err:      err,
+				Duration: time.Since(start),
+			}
+			if err != nil {
+				result.Status = StatusDegraded
+				if check.Critical {
+					result.Status = StatusUnavailable
+				}
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Results: results}
+	for _, result := range results {
+		switch {
+		case result.Status == StatusUnavailable:
+			report.Status = StatusUnavailable
+		case result.Status == StatusDegraded && report.Status == StatusOK:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// ErrNotRunning is reported by a heartbeat that has not beaten within its staleness window
+var ErrNotRunning = errors.New("worker is not running")
+
+// Heartbeat records the progress of a background loop, such as the outbox dispatcher.
+// The loop beats once per iteration; the heartbeat fails its check when the loop stops
+// beating or when its last iteration failed. A nil heartbeat ignores beats.
+type Heartbeat struct {
+	staleAfter time.Duration
+	now        func() time.Time
+
+	mu   sync.Mutex
+	last time.Time
+	err  error
+}
+
+// NewHeartbeat creates a heartbeat that goes stale staleAfter its last beat.
+// The loop is given staleAfter from now to beat for the first time.
+func NewHeartbeat(staleAfter time.Duration) *Heartbeat {
+	return &Heartbeat{
+		staleAfter: staleAfter,
+		now:        time.Now,
+		last:       time.Now(),
+	}
+}
+
+// Beat records an iteration of the loop and its error, nil when it succeeded
+func (heartbeat *Heartbeat) Beat(err error) {
+	if heartbeat == nil {
+		return
+	}
+
+	heartbeat.mu.Lock()
+	defer heartbeat.mu.Unlock()
+	heartbeat.last = heartbeat.now()
+	heartbeat.err = err
+}
+
+// Check returns the error of the last iteration, or ErrNotRunning once the loop has gone stale
+func (heartbeat *Heartbeat) Check(ctx context.Context) error {
+	heartbeat.mu.Lock()
+	defer heartbeat.mu.Unlock()
+
+	if since := heartbeat.now().Sub(heartbeat.last); since > heartbeat.staleAfter {
+		return fmt.Errorf("%w: last beat %s ago", ErrNotRunning, since.Round(time.Second))
+	}
+	return heartbeat.err
+}
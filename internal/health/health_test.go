@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return errors.New("down") }
+
+	report := Run(context.Background(), []Check{
+		{Name: "database", Critical: true, Run: ok},
+		{Name: "outbox", Run: ok},
+	})
+	require.Equal(t, StatusOK, report.Status)
+	require.Equal(t, "database", report.Results[0].Name)
+	require.Equal(t, "outbox", report.Results[1].Name)
+
+	report = Run(context.Background(), []Check{
+		{Name: "database", Critical: true, Run: ok},
+		{Name: "outbox", Run: fail},
+	})
+	require.Equal(t, StatusDegraded, report.Status)
+	require.Equal(t, StatusDegraded, report.Results[1].Status)
+	require.EqualError(t, report.Results[1].Err, "down")
+
+	report = Run(context.Background(), []Check{
+		{Name: "database", Critical: true, Run: fail},
+		{Name: "outbox", Run: fail},
+	})
+	require.Equal(t, StatusUnavailable, report.Status)
+	require.Equal(t, StatusUnavailable, report.Results[0].Status)
+}
+
+func TestRunTimeout(t *testing.T) {
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	report := Run(ctx, []Check{{Name: "database", Critical: true, Run: hang}})
+	require.Equal(t, StatusUnavailable, report.Status)
+	require.ErrorIs(t, report.Results[0].Err, context.DeadlineExceeded)
+}
+
+func TestHeartbeat(t *testing.T) {
+	now := time.Now()
+	heartbeat := NewHeartbeat(time.Minute)
+	heartbeat.now = func() time.Time { return now }
+	heartbeat.last = now
+
+	require.NoError(t, heartbeat.Check(context.Background()))
+
+	heartbeat.Beat(errors.New("broker unreachable"))
+	require.EqualError(t, heartbeat.Check(context.Background()), "broker unreachable")
+
+	heartbeat.Beat(nil)
+	require.NoError(t, heartbeat.Check(context.Background()))
+
+	now = now.Add(2 * time.Minute)
+	require.ErrorIs(t, heartbeat.Check(context.Background()), ErrNotRunning)
+
+	// workers run without a heartbeat outside the server
+	var missing *Heartbeat
+	missing.Beat(nil)
+}
@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"simple_bank/internal/db"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DefaultMaxAttempts is how often a job runs before it is marked failed, unless MaxAttempts says otherwise
+const DefaultMaxAttempts = 5
+
+// ErrDuplicate is returned when a job with the same unique key is already pending or running
+var ErrDuplicate = errors.New("a job with this unique key is already queued")
+
+// Enqueuer inserts jobs. Both db.Store and the Queries of a transaction satisfy it,
+// so a job can be queued atomically with the change that needs it.
+type Enqueuer interface {
+	EnqueueJob(ctx context.Context, arg db.EnqueueJobParams) (db.Job, error)
+}
+
+// Option customises a job when it is enqueued
+type Option func(*db.EnqueueJobParams)
+
+// RunAt schedules the job to run no earlier than t
+func RunAt(t time.Time) Option {
+	return func(arg *db.EnqueueJobParams) {
+		arg.RunAt = pgtype.Timestamptz{Time: t, Valid: true}
+	}
+}
+
+// UniqueKey allows at most one pending or running job with key
+func UniqueKey(key string) Option {
+	return func(arg *db.EnqueueJobParams) {
+		arg.UniqueKey = pgtype.Text{String: key, Valid: true}
+	}
+}
+
+// MaxAttempts sets how often the job runs before it is marked failed
+func MaxAttempts(n int32) Option {
+	return func(arg *db.EnqueueJobParams) {
+		arg.MaxAttempts = n
+	}
+}
+
+// Kind names a type of job whose payload is a T
+type Kind[T any] string
+
+// Enqueue queues a job of this kind. It fails with ErrDuplicate when a UniqueKey is already taken.
+func (kind Kind[T]) Enqueue(ctx context.Context, q Enqueuer, payload T, opts ...Option) (db.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return db.Job{}, fmt.Errorf("cannot marshal %s payload: %w", kind, err)
+	}
+
+	arg := db.EnqueueJobParams{
+		Kind:        string(kind),
+		Payload:     data,
+		MaxAttempts: DefaultMaxAttempts,
+		RunAt:       pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+	for _, opt := range opts {
+		opt(&arg)
+	}
+
+	job, err := q.EnqueueJob(ctx, arg)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		// ON CONFLICT DO NOTHING returns no row
+		return db.Job{}, ErrDuplicate
+	}
+
+	return job, err
+}
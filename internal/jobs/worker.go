@@ -0,0 +1,211 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"simple_bank/internal/db"
+	"simple_bank/internal/health"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Worker defaults
+const (
+	DefaultConcurrency  = 4
+	DefaultPollInterval = time.Second
+	DefaultStaleAfter   = 15 * time.Minute
+	baseBackoff         = 10 * time.Second
+	maxBackoff          = time.Hour
+)
+
+// Store is the part of db.Store a worker needs to claim and settle jobs
+type Store interface {
+	ClaimJobs(ctx context.Context, arg db.ClaimJobsParams) ([]db.Job, error)
+	CompleteJob(ctx context.Context, id int64) error
+	RetryJob(ctx context.Context, arg db.RetryJobParams) error
+	FailJob(ctx context.Context, arg db.FailJobParams) error
+	RequeueStaleJobs(ctx context.Context, lockedBefore pgtype.Timestamptz) (int64, error)
+}
+
+type handlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+// Worker claims due jobs with FOR UPDATE SKIP LOCKED and runs their handlers,
+// so any number of workers can share the same database
+type Worker struct {
+	store        Store
+	handlers     map[string]handlerFunc
+	concurrency  int
+	pollInterval time.Duration
+	staleAfter   time.Duration
+	now          func() time.Time
+
+	// Heartbeat, when set, is beaten after every batch so readiness checks can tell the worker is alive
+	Heartbeat *health.Heartbeat
+}
+
+// NewWorker creates a worker running up to concurrency jobs at a time
+func NewWorker(store Store, concurrency int, pollInterval time.Duration) *Worker {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	return &Worker{
+		store:        store,
+		handlers:     map[string]handlerFunc{},
+		concurrency:  concurrency,
+		pollInterval: pollInterval,
+		staleAfter:   DefaultStaleAfter,
+		now:          time.Now,
+	}
+}
+
+// Handle registers fn as the handler of kind.
+// A returned error retries the job with exponential backoff until its attempts are used up.
+func Handle[T any](worker *Worker, kind Kind[T], fn func(ctx context.Context, payload T) error) {
+	worker.handlers[string(kind)] = func(ctx context.Context, data json.RawMessage) error {
+		var payload T
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return fmt.Errorf("cannot unmarshal %s payload: %w", kind, err)
+		}
+
+		return fn(ctx, payload)
+	}
+}
+
+// Run works jobs until ctx is cancelled, then waits for the running ones to finish
+func (worker *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(worker.pollInterval)
+	defer ticker.Stop()
+
+	var lastRequeue time.Time
+	for {
+		if worker.now().Sub(lastRequeue) >= time.Minute {
+			worker.requeueStale(ctx)
+			lastRequeue = worker.now()
+		}
+
+		n, err := worker.WorkOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "jobs: cannot work on jobs", "error", err)
+		}
+		worker.Heartbeat.Beat(err)
+
+		// a full batch means more jobs are probably waiting
+		if n == worker.concurrency {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// WorkOnce claims up to concurrency due jobs, runs them in parallel and returns how many ran
+func (worker *Worker) WorkOnce(ctx context.Context) (int, error) {
+	if len(worker.handlers) == 0 {
+		return 0, nil
+	}
+
+	kinds := make([]string, 0, len(worker.handlers))
+	for kind := range worker.handlers {
+		kinds = append(kinds, kind)
+	}
+
+	claimed, err := worker.store.ClaimJobs(ctx, db.ClaimJobsParams{
+		Kinds: kinds,
+		Limit: int32(worker.concurrency),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, job := range claimed {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker.work(ctx, job)
+		}()
+	}
+	wg.Wait()
+
+	return len(claimed), nil
+}
+
+// work runs one claimed job and records the outcome.
+// Outcomes are recorded even once ctx is cancelled, so a job never stays claimed by a stopped worker.
+func (worker *Worker) work(ctx context.Context, job db.Job) {
+	err := worker.run(ctx, job)
+	settleCtx := context.WithoutCancel(ctx)
+
+	switch {
+	case err == nil:
+		err = worker.store.CompleteJob(settleCtx, job.ID)
+	case job.Attempts >= job.MaxAttempts:
+		err = worker.store.FailJob(settleCtx, db.FailJobParams{
+			ID:        job.ID,
+			LastError: pgtype.Text{String: err.Error(), Valid: true},
+		})
+	default:
+		err = worker.store.RetryJob(settleCtx, db.RetryJobParams{
+			ID:        job.ID,
+			RunAt:     pgtype.Timestamptz{Time: worker.now().Add(Backoff(job.Attempts)), Valid: true},
+			LastError: pgtype.Text{String: err.Error(), Valid: true},
+		})
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "jobs: cannot settle job", "job_id", job.ID, "error", err)
+	}
+}
+
+func (worker *Worker) run(ctx context.Context, job db.Job) (err error) {
+	handler, ok := worker.handlers[job.Kind]
+	if !ok {
+		return fmt.Errorf("no handler for job kind %s", job.Kind)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job %d panicked: %v", job.ID, r)
+		}
+	}()
+
+	return handler(ctx, job.Payload)
+}
+
+// requeueStale puts jobs back whose worker died while running them
+func (worker *Worker) requeueStale(ctx context.Context) {
+	lockedBefore := pgtype.Timestamptz{Time: worker.now().Add(-worker.staleAfter), Valid: true}
+	n, err := worker.store.RequeueStaleJobs(ctx, lockedBefore)
+	if err != nil {
+		slog.ErrorContext(ctx, "jobs: cannot requeue stale jobs", "error", err)
+		return
+	}
+	if n > 0 {
+		slog.WarnContext(ctx, "jobs: requeued stale jobs", "count", n)
+	}
+}
+
+// Backoff returns how long to wait before retrying a job that has failed attempts times
+func Backoff(attempts int32) time.Duration {
+	backoff := baseBackoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return backoff
+}
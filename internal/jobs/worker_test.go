@@ -0,0 +1,191 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"simple_bank/internal/db"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore keeps jobs in memory and mimics claiming, retrying and failing them
+type fakeStore struct {
+	mu   sync.Mutex
+	jobs []db.Job
+}
+
+func (store *fakeStore) EnqueueJob(ctx context.Context, arg db.EnqueueJobParams) (db.Job, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, job := range store.jobs {
+		if arg.UniqueKey.Valid && job.UniqueKey == arg.UniqueKey && (job.Status == "pending" || job.Status == "running") {
+			return db.Job{}, db.ErrRecordNotFound
+		}
+	}
+
+	job := db.Job{
+		ID:          int64(len(store.jobs) + 1),
+		Kind:        arg.Kind,
+		Payload:     arg.Payload,
+		Status:      "pending",
+		UniqueKey:   arg.UniqueKey,
+		MaxAttempts: arg.MaxAttempts,
+		RunAt:       arg.RunAt,
+	}
+	store.jobs = append(store.jobs, job)
+	return job, nil
+}
+
+func (store *fakeStore) ClaimJobs(ctx context.Context, arg db.ClaimJobsParams) ([]db.Job, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var claimed []db.Job
+	for i := range store.jobs {
+		job := &store.jobs[i]
+		if job.Status == "pending" && !job.RunAt.Time.After(time.Now()) && slices.Contains(arg.Kinds, job.Kind) && int32(len(claimed)) < arg.Limit {
+			job.Status = "running"
+			job.Attempts++
+			claimed = append(claimed, *job)
+		}
+	}
+	return claimed, nil
+}
+
+func (store *fakeStore) set(id int64, fn func(job *db.Job)) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	fn(&store.jobs[id-1])
+}
+
+func (store *fakeStore) CompleteJob(ctx context.Context, id int64) error {
+	store.set(id, func(job *db.Job) { job.Status = "succeeded" })
+	return nil
+}
+
+func (store *fakeStore) RetryJob(ctx context.Context, arg db.RetryJobParams) error {
+	store.set(arg.ID, func(job *db.Job) {
+		job.Status = "pending"
+		job.RunAt = arg.RunAt
+		job.LastError = arg.LastError
+	})
+	return nil
+}
+
+func (store *fakeStore) FailJob(ctx context.Context, arg db.FailJobParams) error {
+	store.set(arg.ID, func(job *db.Job) {
+		job.Status = "failed"
+		job.LastError = arg.LastError
+	})
+	return nil
+}
+
+func (store *fakeStore) RequeueStaleJobs(ctx context.Context, lockedBefore pgtype.Timestamptz) (int64, error) {
+	return 0, nil
+}
+
+type greeting struct {
+	Name string `json:"name"`
+}
+
+const greet Kind[greeting] = "greet"
+
+func TestWorkOnceRunsTypedHandler(t *testing.T) {
+	store := &fakeStore{}
+	worker := NewWorker(store, 2, 0)
+
+	var mu sync.Mutex
+	var names []string
+	Handle(worker, greet, func(ctx context.Context, payload greeting) error {
+		mu.Lock()
+		defer mu.Unlock()
+		names = append(names, payload.Name)
+		return nil
+	})
+
+	for _, name := range []string{"ada", "grace", "linus"} {
+		_, err := greet.Enqueue(context.Background(), store, greeting{Name: name})
+		require.NoError(t, err)
+	}
+
+	n, err := worker.WorkOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	n, err = worker.WorkOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	require.ElementsMatch(t, []string{"ada", "grace", "linus"}, names)
+	for _, job := range store.jobs {
+		require.Equal(t, "succeeded", job.Status)
+	}
+}
+
+func TestWorkOnceRetriesThenFails(t *testing.T) {
+	store := &fakeStore{}
+	worker := NewWorker(store, 1, 0)
+	Handle(worker, greet, func(ctx context.Context, payload greeting) error {
+		return errors.New("smtp down")
+	})
+
+	_, err := greet.Enqueue(context.Background(), store, greeting{Name: "ada"}, MaxAttempts(2))
+	require.NoError(t, err)
+
+	_, err = worker.WorkOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "pending", store.jobs[0].Status)
+	require.True(t, store.jobs[0].RunAt.Time.After(time.Now()))
+
+	// make the retry due straight away
+	store.jobs[0].RunAt.Time = time.Now()
+	_, err = worker.WorkOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "failed", store.jobs[0].Status)
+	require.Equal(t, "smtp down", store.jobs[0].LastError.String)
+}
+
+func TestWorkOnceRecoversPanics(t *testing.T) {
+	store := &fakeStore{}
+	worker := NewWorker(store, 1, 0)
+	Handle(worker, greet, func(ctx context.Context, payload greeting) error {
+		panic("boom")
+	})
+
+	_, err := greet.Enqueue(context.Background(), store, greeting{}, MaxAttempts(1))
+	require.NoError(t, err)
+
+	_, err = worker.WorkOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "failed", store.jobs[0].Status)
+	require.Contains(t, store.jobs[0].LastError.String, "boom")
+}
+
+func TestEnqueueScheduledAndUnique(t *testing.T) {
+	store := &fakeStore{}
+	worker := NewWorker(store, 1, 0)
+	Handle(worker, greet, func(ctx context.Context, payload greeting) error { return nil })
+
+	_, err := greet.Enqueue(context.Background(), store, greeting{}, UniqueKey("daily"), RunAt(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	_, err = greet.Enqueue(context.Background(), store, greeting{}, UniqueKey("daily"))
+	require.ErrorIs(t, err, ErrDuplicate)
+
+	// the job isn't due yet
+	n, err := worker.WorkOnce(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, baseBackoff, Backoff(1))
+	require.Equal(t, 4*baseBackoff, Backoff(3))
+	require.Equal(t, maxBackoff, Backoff(30))
+}
@@ -0,0 +1,57 @@
+package kyc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStubProvider(t *testing.T) {
+	provider := NewStubProvider()
+
+	adult := Profile{
+		Username:    "alice",
+		FullName:    "Alice",
+		DateOfBirth: time.Now().AddDate(-30, 0, 0),
+		Address:     "1 Main Street",
+		NationalID:  "12345678",
+	}
+
+	result, err := provider.Verify(context.Background(), adult)
+	require.NoError(t, err)
+	require.Equal(t, StatusVerified, result.Status)
+	require.Equal(t, int32(1), result.Tier)
+
+	minor := adult
+	minor.DateOfBirth = time.Now().AddDate(-10, 0, 0)
+
+	result, err = provider.Verify(context.Background(), minor)
+	require.NoError(t, err)
+	require.Equal(t, StatusRejected, result.Status)
+	require.Zero(t, result.Tier)
+
+	incomplete := adult
+	incomplete.NationalID = ""
+
+	result, err = provider.Verify(context.Background(), incomplete)
+	require.NoError(t, err)
+	require.Equal(t, StatusRejected, result.Status)
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(ProviderStub)
+	require.NoError(t, err)
+	require.IsType(t, &StubProvider{}, provider)
+
+	_, err = NewProvider("acme")
+	require.Error(t, err)
+}
+
+func TestTierFor(t *testing.T) {
+	require.Equal(t, int32(0), TierFor(0).Level)
+	require.Equal(t, int32(2), TierFor(2).Level)
+	require.Equal(t, int32(0), TierFor(99).Level)
+	require.Less(t, TierFor(0).MaxTransferAmount, TierFor(1).MaxTransferAmount)
+}
@@ -0,0 +1,40 @@
+package kyc
+
+// Verification statuses stored in users.kyc_status
+const (
+	StatusUnverified = "unverified"
+	StatusVerified   = "verified"
+	StatusRejected   = "rejected"
+)
+
+// MaxUnverifiedEmailTransfer is the largest transfer a user may send before verifying their email,
+// whatever their tier
+const MaxUnverifiedEmailTransfer = 1000
+
+// Tier is the set of limits that applies to a user at a given verification level
+type Tier struct {
+	Level int32
+	// MaxAccounts is the number of open accounts a user may own
+	MaxAccounts int64
+	// MaxTransferAmount is the largest single transfer a user may send
+	MaxTransferAmount int64
+}
+
+// tiers are ordered by level. Unverified users get tier 0, so they can hold one account
+// and move only small sums until a provider verifies them.
+var tiers = []Tier{
+	{Level: 0, MaxAccounts: 1, MaxTransferAmount: 100},
+	{Level: 1, MaxAccounts: 3, MaxTransferAmount: 10000},
+	{Level: 2, MaxAccounts: 10, MaxTransferAmount: 1000000},
+}
+
+// TierFor returns the limits for a level, falling back to the lowest tier for unknown levels
+func TierFor(level int32) Tier {
+	for _, tier := range tiers {
+		if tier.Level == level {
+			return tier
+		}
+	}
+
+	return tiers[0]
+}
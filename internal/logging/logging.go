@@ -0,0 +1,120 @@
+// Package logging sets up the structured JSON logs of the servers and workers.
+// Every record logged with a request's context carries the request ID, the
+// signed in user and the trace ID, and secrets are redacted before anything is written.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// redacted replaces the value of every secret attribute
+const redacted = "[REDACTED]"
+
+// secretKeys are the attribute keys, or parts of them, whose values are never logged
+var secretKeys = []string{"password", "secret", "token", "authorization", "otp", "db_source", "api_key"}
+
+// New creates a logger writing JSON lines to w, dropping records below level:
+// debug, info, warn or error, info when empty
+func New(w io.Writer, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if level != "" {
+		if err := lvl.UnmarshalText([]byte(level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+		}
+	}
+
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       lvl,
+		ReplaceAttr: redact,
+	})
+	return slog.New(contextHandler{handler}), nil
+}
+
+// redact hides the values of secret attributes and the passwords of URLs
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return slog.String(attr.Key, redacted)
+		}
+	}
+
+	if attr.Value.Kind() == slog.KindString {
+		return slog.String(attr.Key, RedactURL(attr.Value.String()))
+	}
+	return attr
+}
+
+// RedactURL hides the password of a URL such as a database connection string,
+// and returns anything else as it is
+func RedactURL(s string) string {
+	if !strings.Contains(s, "://") {
+		return s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, ok := u.User.Password(); !ok {
+		return s
+	}
+
+	return u.Redacted()
+}
+
+// Fields are what a request adds to the records logged with its context.
+// Like db.AuditInfo they are shared by pointer, so the auth middleware can
+// fill in the user once it has verified the token.
+type Fields struct {
+	RequestID string
+	Username  string
+}
+
+type fieldsKey struct{}
+
+// WithFields returns a context whose log records carry fields
+func WithFields(ctx context.Context, fields *Fields) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// FieldsFrom returns the fields of the request ctx belongs to, or nil outside one
+func FieldsFrom(ctx context.Context) *Fields {
+	fields, _ := ctx.Value(fieldsKey{}).(*Fields)
+	return fields
+}
+
+// contextHandler adds the request fields and the trace of the context to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (handler contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		record.AddAttrs(slog.String("trace_id", spanCtx.TraceID().String()))
+	}
+	if fields := FieldsFrom(ctx); fields != nil {
+		if fields.RequestID != "" {
+			record.AddAttrs(slog.String("request_id", fields.RequestID))
+		}
+		if fields.Username != "" {
+			record.AddAttrs(slog.String("user", fields.Username))
+		}
+	}
+	return handler.Handler.Handle(ctx, record)
+}
+
+func (handler contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{handler.Handler.WithAttrs(attrs)}
+}
+
+func (handler contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{handler.Handler.WithGroup(name)}
+}
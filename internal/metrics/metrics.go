@@ -0,0 +1,104 @@
+// Package metrics defines the Prometheus metrics of the bank, registered on the default registry
+// that GET /metrics serves: HTTP traffic, transfers, database transactions and the connection pool.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "simple_bank"
+
+// Transfer outcomes, the outcome label of TransfersTotal
+const (
+	TransferCreated = "created"
+	TransferHeld    = "held"
+	TransferFailed  = "failed"
+)
+
+// Transaction results, the result label of DBTransactionsTotal
+const (
+	TxCommit   = "commit"
+	TxRollback = "rollback"
+)
+
+var (
+	// HTTPRequestsTotal counts handled requests by route, method and status
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by route, method and status.",
+	}, []string{"route", "method", "status"})
+
+	// HTTPRequestDuration is the latency of handled requests by route, method and status
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests, by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// TransfersTotal counts transfer requests by outcome, and failed ones by the error code they failed with
+	TransfersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Transfer requests, by outcome (created, held or failed) and failure reason.",
+	}, []string{"outcome", "reason"})
+
+	// TransferVolume sums the amounts of posted transfers, in minor units, by currency
+	TransferVolume = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_volume_minor_units_total",
+		Help:      "Amount moved by posted transfers in minor units, e.g. cents, by currency.",
+	}, []string{"currency"})
+
+	// DBTransactionsTotal counts the store's transactions by whether they committed or rolled back
+	DBTransactionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_transactions_total",
+		Help:      "Store transactions, by result (commit or rollback).",
+	}, []string{"result"})
+)
+
+// unmatchedRoute labels requests to unknown routes, so scanners can't blow up the number of series
+const unmatchedRoute = "unmatched"
+
+// ObserveHTTPRequest records a handled request. route is the route template, e.g. /accounts/:id,
+// or "" when no route matched.
+func ObserveHTTPRequest(route, method string, status int, duration time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+
+	code := strconv.Itoa(status)
+	HTTPRequestsTotal.WithLabelValues(route, method, code).Inc()
+	HTTPRequestDuration.WithLabelValues(route, method, code).Observe(duration.Seconds())
+}
+
+// ObserveTransferCreated records a posted transfer and adds its amount to the volume of its currency
+func ObserveTransferCreated(currency string, amount int64) {
+	TransfersTotal.WithLabelValues(TransferCreated, "").Inc()
+	TransferVolume.WithLabelValues(currency).Add(float64(amount))
+}
+
+// ObserveTransferHeld records a transfer fraud screening held for review
+func ObserveTransferHeld() {
+	TransfersTotal.WithLabelValues(TransferHeld, "").Inc()
+}
+
+// ObserveTransferFailed records a transfer request that was refused or failed, with why
+func ObserveTransferFailed(reason string) {
+	TransfersTotal.WithLabelValues(TransferFailed, reason).Inc()
+}
+
+// ObserveTx records the result of a store transaction
+func ObserveTx(committed bool) {
+	result := TxRollback
+	if committed {
+		result = TxCommit
+	}
+	DBTransactionsTotal.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector exports the pgxpool.Stat of a connection pool, read afresh on every scrape
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+}
+
+// NewPoolCollector creates a collector for pool, to be registered once per pool
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &PoolCollector{
+		pool:                 pool,
+		acquiredConns:        desc("acquired_connections", "Connections currently checked out of the pool."),
+		idleConns:            desc("idle_connections", "Idle connections in the pool."),
+		constructingConns:    desc("constructing_connections", "Connections being opened."),
+		totalConns:           desc("total_connections", "Connections in the pool, acquired, idle or being opened."),
+		maxConns:             desc("max_connections", "Largest size the pool may grow to."),
+		acquireCount:         desc("acquires_total", "Successful acquires of a connection from the pool."),
+		acquireDuration:      desc("acquire_duration_seconds_total", "Time spent waiting for successful acquires."),
+		canceledAcquireCount: desc("canceled_acquires_total", "Acquires cancelled by their context."),
+		emptyAcquireCount:    desc("empty_acquires_total", "Successful acquires that had to wait for a connection."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (collector *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.acquiredConns
+	ch <- collector.idleConns
+	ch <- collector.constructingConns
+	ch <- collector.totalConns
+	ch <- collector.maxConns
+	ch <- collector.acquireCount
+	ch <- collector.acquireDuration
+	ch <- collector.canceledAcquireCount
+	ch <- collector.emptyAcquireCount
+}
+
+// Collect implements prometheus.Collector
+func (collector *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := collector.pool.Stat()
+
+	gauge := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+	}
+	counter := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value)
+	}
+
+	gauge(collector.acquiredConns, float64(stat.AcquiredConns()))
+	gauge(collector.idleConns, float64(stat.IdleConns()))
+	gauge(collector.constructingConns, float64(stat.ConstructingConns()))
+	gauge(collector.totalConns, float64(stat.TotalConns()))
+	gauge(collector.maxConns, float64(stat.MaxConns()))
+	counter(collector.acquireCount, float64(stat.AcquireCount()))
+	counter(collector.acquireDuration, stat.AcquireDuration().Seconds())
+	counter(collector.canceledAcquireCount, float64(stat.CanceledAcquireCount()))
+	counter(collector.emptyAcquireCount, float64(stat.EmptyAcquireCount()))
+}
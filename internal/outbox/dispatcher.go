@@ -0,0 +1,115 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"simple_bank/internal/db"
+	"simple_bank/internal/health"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Store is the part of db.Store the dispatcher needs to lock, read and acknowledge outbox events
+type Store interface {
+	TryLockOutbox(ctx context.Context) (unlock func(), locked bool, err error)
+	ListPendingOutboxEvents(ctx context.Context, limit int32) ([]db.Outbox, error)
+	MarkOutboxEventPublished(ctx context.Context, id int64) error
+	RecordOutboxFailure(ctx context.Context, arg db.RecordOutboxFailureParams) error
+}
+
+// Defaults used by NewDispatcher when no interval or batch size is given
+const (
+	DefaultPollInterval = time.Second
+	DefaultBatchSize    = 100
+)
+
+// Dispatcher polls the outbox and publishes pending events in id order.
+// Every batch is published under a database lock, so when several replicas run a dispatcher
+// only one publishes at a time and events are never delivered out of order.
+type Dispatcher struct {
+	store     Store
+	publisher Publisher
+	interval  time.Duration
+	batchSize int32
+
+	// Heartbeat, when set, is beaten after every dispatch so readiness checks can tell the dispatcher is alive
+	Heartbeat *health.Heartbeat
+}
+
+// NewDispatcher creates a dispatcher that polls store every interval
+func NewDispatcher(store Store, publisher Publisher, interval time.Duration, batchSize int32) *Dispatcher {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &Dispatcher{
+		store:     store,
+		publisher: publisher,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run dispatches pending events until ctx is cancelled
+func (dispatcher *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(dispatcher.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := dispatcher.DispatchOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "outbox: cannot dispatch events", "error", err)
+		}
+		dispatcher.Heartbeat.Beat(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce publishes one batch of pending events and returns how many were published.
+// It stops at the first failure, so a later event is never published before an earlier one;
+// the failed event is retried on the next run. It publishes nothing while another dispatcher holds the lock.
+func (dispatcher *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	unlock, locked, err := dispatcher.store.TryLockOutbox(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+	defer unlock()
+
+	rows, err := dispatcher.store.ListPendingOutboxEvents(ctx, dispatcher.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, row := range rows {
+		err := dispatcher.publisher.Publish(ctx, newEvent(row))
+		if err != nil {
+			failure := db.RecordOutboxFailureParams{
+				ID:        row.ID,
+				LastError: pgtype.Text{String: err.Error(), Valid: true},
+			}
+			if recordErr := dispatcher.store.RecordOutboxFailure(ctx, failure); recordErr != nil {
+				slog.ErrorContext(ctx, "outbox: cannot record failure", "event_id", row.ID, "error", recordErr)
+			}
+			return i, err
+		}
+
+		// if this fails the event is published again on the next run, which at least once allows
+		if err := dispatcher.store.MarkOutboxEventPublished(ctx, row.ID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(rows), nil
+}
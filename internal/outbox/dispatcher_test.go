@@ -0,0 +1,135 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"simple_bank/internal/db"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore keeps outbox rows in memory
+type fakeStore struct {
+	rows     []db.Outbox
+	failures map[int64]string
+	locked   bool
+}
+
+func newFakeStore(n int) *fakeStore {
+	store := &fakeStore{failures: map[int64]string{}}
+	for i := 1; i <= n; i++ {
+		store.rows = append(store.rows, db.Outbox{ID: int64(i), EventType: db.EventTransferCreated, Payload: []byte(`{}`)})
+	}
+	return store
+}
+
+func (store *fakeStore) TryLockOutbox(ctx context.Context) (func(), bool, error) {
+	if store.locked {
+		return nil, false, nil
+	}
+	store.locked = true
+	return func() { store.locked = false }, true, nil
+}
+
+func (store *fakeStore) ListPendingOutboxEvents(ctx context.Context, limit int32) ([]db.Outbox, error) {
+	var pending []db.Outbox
+	for _, row := range store.rows {
+		if !row.PublishedAt.Valid && int32(len(pending)) < limit {
+			pending = append(pending, row)
+		}
+	}
+	return pending, nil
+}
+
+func (store *fakeStore) MarkOutboxEventPublished(ctx context.Context, id int64) error {
+	for i := range store.rows {
+		if store.rows[i].ID == id {
+			store.rows[i].PublishedAt.Valid = true
+		}
+	}
+	return nil
+}
+
+func (store *fakeStore) RecordOutboxFailure(ctx context.Context, arg db.RecordOutboxFailureParams) error {
+	store.failures[arg.ID] = arg.LastError.String
+	return nil
+}
+
+// fakePublisher records published event IDs and fails on the IDs in failOn
+type fakePublisher struct {
+	published []int64
+	failOn    map[int64]bool
+}
+
+func (publisher *fakePublisher) Publish(ctx context.Context, event Event) error {
+	if publisher.failOn[event.ID] {
+		return errors.New("downstream unavailable")
+	}
+	publisher.published = append(publisher.published, event.ID)
+	return nil
+}
+
+func TestDispatchOnceInOrder(t *testing.T) {
+	store := newFakeStore(3)
+	publisher := &fakePublisher{}
+	dispatcher := NewDispatcher(store, publisher, 0, 0)
+
+	n, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, []int64{1, 2, 3}, publisher.published)
+
+	n, err = dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestDispatchOnceStopsAtFailure(t *testing.T) {
+	store := newFakeStore(3)
+	publisher := &fakePublisher{failOn: map[int64]bool{2: true}}
+	dispatcher := NewDispatcher(store, publisher, 0, 0)
+
+	n, err := dispatcher.DispatchOnce(context.Background())
+	require.Error(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []int64{1}, publisher.published)
+	require.Contains(t, store.failures[2], "downstream unavailable")
+
+	// once the publisher recovers the failed event goes out before the ones after it
+	publisher.failOn = nil
+	n, err = dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, []int64{1, 2, 3}, publisher.published)
+}
+
+func TestDispatchOnceBatchSize(t *testing.T) {
+	store := newFakeStore(5)
+	publisher := &fakePublisher{}
+	dispatcher := NewDispatcher(store, publisher, 0, 2)
+
+	n, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, []int64{1, 2}, publisher.published)
+}
+
+func TestDispatchOnceLocked(t *testing.T) {
+	store := newFakeStore(3)
+	publisher := &fakePublisher{}
+	dispatcher := NewDispatcher(store, publisher, 0, 0)
+
+	// another dispatcher is publishing
+	store.locked = true
+	n, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Empty(t, publisher.published)
+
+	store.locked = false
+	n, err = dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.False(t, store.locked)
+}
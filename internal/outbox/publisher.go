@@ -0,0 +1,191 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"simple_bank/internal/db"
+	"sync"
+	"time"
+)
+
+// Event is a domain event as it is handed to a publisher
+type Event struct {
+	ID            int64           `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Type          string          `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func newEvent(row db.Outbox) Event {
+	return Event{
+		ID:            row.ID,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		Type:          row.EventType,
+		Payload:       row.Payload,
+		CreatedAt:     row.CreatedAt.Time,
+	}
+}
+
+// Publisher delivers events downstream.
+// Delivery is at least once, so an event may be published again after a failure and
+// consumers should deduplicate on Event.ID.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// HTTPTimeout bounds every request of the HTTP publisher NewPublisher creates,
+// so a hung endpoint can't stall the dispatcher and hold its lock
+const HTTPTimeout = 10 * time.Second
+
+// Publisher kinds accepted by NewPublisher
+const (
+	PublisherLog  = "log"
+	PublisherFile = "file"
+	PublisherHTTP = "http"
+)
+
+// NewPublisher creates the publisher of the given kind.
+// target is the file path for file publishers and the endpoint URL for HTTP publishers.
+func NewPublisher(kind, target string) (Publisher, error) {
+	switch kind {
+	case PublisherLog, "":
+		return NewLogPublisher(slog.Default()), nil
+	case PublisherFile:
+		return NewFilePublisher(target)
+	case PublisherHTTP:
+		if target == "" {
+			return nil, fmt.Errorf("http publisher needs a target url")
+		}
+		return NewHTTPPublisher(target, &http.Client{Timeout: HTTPTimeout}), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher %q", kind)
+	}
+}
+
+// LogPublisher writes every event to a logger
+type LogPublisher struct {
+	logger *slog.Logger
+}
+
+// NewLogPublisher creates a publisher that logs events
+func NewLogPublisher(logger *slog.Logger) *LogPublisher {
+	return &LogPublisher{logger: logger}
+}
+
+// Publish logs the event
+func (publisher *LogPublisher) Publish(ctx context.Context, event Event) error {
+	publisher.logger.InfoContext(ctx, "outbox event",
+		"event_id", event.ID,
+		"event_type", event.Type,
+		"aggregate_type", event.AggregateType,
+		"aggregate_id", event.AggregateID,
+		"payload", event.Payload,
+	)
+	return nil
+}
+
+// FilePublisher appends events to a file as JSON lines
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFilePublisher opens, or creates, the file events are appended to
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	if path == "" {
+		return nil, fmt.Errorf("file publisher needs a target path")
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open outbox file: %w", err)
+	}
+
+	return &FilePublisher{file: file}, nil
+}
+
+// Publish appends the event and syncs the file, so a published event survives a crash
+func (publisher *FilePublisher) Publish(ctx context.Context, event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+
+	if _, err = publisher.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return publisher.file.Sync()
+}
+
+// Close closes the underlying file
+func (publisher *FilePublisher) Close() error {
+	return publisher.file.Close()
+}
+
+// HTTPPublisher posts every event as JSON to an endpoint
+type HTTPPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPublisher creates a publisher that posts events to url
+func NewHTTPPublisher(url string, client *http.Client) *HTTPPublisher {
+	return &HTTPPublisher{
+		url:    url,
+		client: client,
+	}
+}
+
+// Publish posts the event and treats any non 2xx response as a failed delivery
+func (publisher *HTTPPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, publisher.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Idempotency-Key", fmt.Sprint(event.ID))
+
+	response, err := publisher.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("publishing event %d: unexpected status %s", event.ID, response.Status)
+	}
+
+	return nil
+}
+
+// MultiPublisher hands every event to each of its publishers in turn.
+// A failure stops there and the whole event is retried, so earlier publishers may see it again.
+type MultiPublisher []Publisher
+
+// Publish publishes event to every publisher
+func (publishers MultiPublisher) Publish(ctx context.Context, event Event) error {
+	for _, publisher := range publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
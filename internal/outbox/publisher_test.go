@@ -0,0 +1,82 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	publisher, err := NewFilePublisher(path)
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	for i := int64(1); i <= 2; i++ {
+		err = publisher.Publish(context.Background(), Event{ID: i, Type: "account.created", Payload: json.RawMessage(`{"id":1}`)})
+		require.NoError(t, err)
+	}
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var ids []int64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		ids = append(ids, event.ID)
+	}
+	require.Equal(t, []int64{1, 2}, ids)
+}
+
+func TestHTTPPublisher(t *testing.T) {
+	var received Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "7", r.Header.Get("Idempotency-Key"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	publisher := NewHTTPPublisher(server.URL, server.Client())
+	err := publisher.Publish(context.Background(), Event{ID: 7, Type: "transfer.created", Payload: json.RawMessage(`{}`)})
+	require.NoError(t, err)
+	require.Equal(t, int64(7), received.ID)
+}
+
+func TestHTTPPublisherRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	publisher := NewHTTPPublisher(server.URL, server.Client())
+	err := publisher.Publish(context.Background(), Event{ID: 1, Payload: json.RawMessage(`{}`)})
+	require.Error(t, err)
+}
+
+func TestNewPublisher(t *testing.T) {
+	_, err := NewPublisher(PublisherHTTP, "")
+	require.Error(t, err)
+
+	_, err = NewPublisher("kafka", "")
+	require.Error(t, err)
+
+	publisher, err := NewPublisher(PublisherLog, "")
+	require.NoError(t, err)
+	require.IsType(t, &LogPublisher{}, publisher)
+
+	publisher, err = NewPublisher(PublisherHTTP, "http://localhost:8081/events")
+	require.NoError(t, err)
+	require.Equal(t, HTTPTimeout, publisher.(*HTTPPublisher).client.Timeout)
+}
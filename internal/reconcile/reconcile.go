@@ -0,0 +1,83 @@
+// Package reconcile checks the ledger against itself: every balance must equal the sum of
+// its entries, and as every transfer and adjustment posts two opposite entries, the entries
+// of each currency must net to zero across customer and GL accounts.
+package reconcile
+
+import (
+	"context"
+	"log/slog"
+	"simple_bank/internal/db"
+)
+
+// Store is the part of db.Store reconciliation reads
+type Store interface {
+	ListAccountBalanceMismatches(ctx context.Context) ([]db.ListAccountBalanceMismatchesRow, error)
+	ListGLBalanceMismatches(ctx context.Context) ([]db.ListGLBalanceMismatchesRow, error)
+	ListCurrencyImbalances(ctx context.Context) ([]db.ListCurrencyImbalancesRow, error)
+}
+
+// Report lists everything that doesn't reconcile
+type Report struct {
+	Accounts   []db.ListAccountBalanceMismatchesRow
+	GLAccounts []db.ListGLBalanceMismatchesRow
+	Currencies []db.ListCurrencyImbalancesRow
+}
+
+// OK tells whether the whole ledger reconciles
+func (report Report) OK() bool {
+	return len(report.Accounts) == 0 && len(report.GLAccounts) == 0 && len(report.Currencies) == 0
+}
+
+// Run reconciles the ledger of store.
+// The checks read without locking, so run them when transfers are quiet or expect to rerun a failure.
+func Run(ctx context.Context, store Store) (Report, error) {
+	var report Report
+	var err error
+
+	report.Accounts, err = store.ListAccountBalanceMismatches(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+	report.GLAccounts, err = store.ListGLBalanceMismatches(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+	report.Currencies, err = store.ListCurrencyImbalances(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+
+	return report, nil
+}
+
+// Log logs every mismatch of report as an error, or a single line when the ledger reconciles
+func (report Report) Log(ctx context.Context, logger *slog.Logger) {
+	for _, account := range report.Accounts {
+		logger.ErrorContext(ctx, "reconcile: account balance differs from its entries",
+			"account_id", account.ID,
+			"owner", account.Owner,
+			"currency", account.Currency,
+			"balance", account.Balance,
+			"entries_total", account.EntriesTotal,
+		)
+	}
+	for _, account := range report.GLAccounts {
+		logger.ErrorContext(ctx, "reconcile: GL account balance differs from its entries",
+			"gl_account_id", account.ID,
+			"code", account.Code,
+			"currency", account.Currency,
+			"balance", account.Balance,
+			"entries_total", account.EntriesTotal,
+		)
+	}
+	for _, currency := range report.Currencies {
+		logger.ErrorContext(ctx, "reconcile: entries don't net to zero",
+			"currency", currency.Currency,
+			"net", currency.Net,
+		)
+	}
+
+	if report.OK() {
+		logger.InfoContext(ctx, "reconcile: ledger reconciles")
+	}
+}
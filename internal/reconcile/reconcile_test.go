@@ -0,0 +1,66 @@
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"simple_bank/internal/db"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore returns canned mismatches
+type fakeStore struct {
+	accounts   []db.ListAccountBalanceMismatchesRow
+	glAccounts []db.ListGLBalanceMismatchesRow
+	currencies []db.ListCurrencyImbalancesRow
+	err        error
+}
+
+func (store *fakeStore) ListAccountBalanceMismatches(ctx context.Context) ([]db.ListAccountBalanceMismatchesRow, error) {
+	return store.accounts, store.err
+}
+
+func (store *fakeStore) ListGLBalanceMismatches(ctx context.Context) ([]db.ListGLBalanceMismatchesRow, error) {
+	return store.glAccounts, nil
+}
+
+func (store *fakeStore) ListCurrencyImbalances(ctx context.Context) ([]db.ListCurrencyImbalancesRow, error) {
+	return store.currencies, nil
+}
+
+func TestRunReconciles(t *testing.T) {
+	report, err := Run(context.Background(), &fakeStore{})
+	require.NoError(t, err)
+	require.True(t, report.OK())
+
+	var buf bytes.Buffer
+	report.Log(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)))
+	require.Contains(t, buf.String(), "ledger reconciles")
+}
+
+func TestRunMismatches(t *testing.T) {
+	store := &fakeStore{
+		accounts:   []db.ListAccountBalanceMismatchesRow{{ID: 7, Owner: "alice", Currency: "USD", Balance: 500, EntriesTotal: 450}},
+		currencies: []db.ListCurrencyImbalancesRow{{Currency: "USD", Net: 50}},
+	}
+
+	report, err := Run(context.Background(), store)
+	require.NoError(t, err)
+	require.False(t, report.OK())
+	require.Len(t, report.Accounts, 1)
+	require.Empty(t, report.GLAccounts)
+
+	var buf bytes.Buffer
+	report.Log(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)))
+	require.Contains(t, buf.String(), `"account_id":7`)
+	require.Contains(t, buf.String(), `"net":50`)
+	require.NotContains(t, buf.String(), "ledger reconciles")
+}
+
+func TestRunError(t *testing.T) {
+	_, err := Run(context.Background(), &fakeStore{err: errors.New("connection refused")})
+	require.EqualError(t, err, "connection refused")
+}
@@ -0,0 +1,76 @@
+// Package shutdown stops the parts of the process in order once it is asked to exit,
+// so in-flight requests finish before the workers stop and the workers stop before the database closes.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds the whole shutdown when no drain timeout is configured
+const DefaultTimeout = 30 * time.Second
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Hooks run the cleanups of the process in the reverse order they were added, like defers:
+// whatever is started last, such as the servers, is stopped first.
+// The zero value is ready to use.
+type Hooks struct {
+	mu    sync.Mutex
+	hooks []hook
+}
+
+// Add registers fn to run on shutdown. fn should return once ctx is done, even if it has not finished.
+func (hooks *Hooks) Add(name string, fn func(ctx context.Context) error) {
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.hooks = append(hooks.hooks, hook{name: name, fn: fn})
+}
+
+// Run runs every hook, latest first, sharing the deadline of ctx between them.
+// A failing hook does not stop the ones after it; their errors are joined.
+func (hooks *Hooks) Run(ctx context.Context) error {
+	hooks.mu.Lock()
+	pending := hooks.hooks
+	hooks.hooks = nil
+	hooks.mu.Unlock()
+
+	var errs []error
+	for i := len(pending) - 1; i >= 0; i-- {
+		h := pending[i]
+
+		start := time.Now()
+		err := h.fn(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "shutdown: cannot stop "+h.name, "error", err, "duration", time.Since(start))
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+			continue
+		}
+		slog.InfoContext(ctx, "shutdown: stopped "+h.name, "duration", time.Since(start))
+	}
+	return errors.Join(errs...)
+}
+
+// Wait waits for wg, giving up once ctx is done. It lets a hook wait for goroutines
+// that may never return, such as a worker stuck on a hung connection.
+func Wait(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
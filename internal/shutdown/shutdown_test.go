@@ -0,0 +1,59 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooksRunInReverse(t *testing.T) {
+	var hooks Hooks
+	var order []string
+	for _, name := range []string{"pool", "workers", "server"} {
+		hooks.Add(name, func(context.Context) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+
+	require.NoError(t, hooks.Run(context.Background()))
+	require.Equal(t, []string{"server", "workers", "pool"}, order)
+
+	// hooks run once
+	require.NoError(t, hooks.Run(context.Background()))
+	require.Len(t, order, 3)
+}
+
+func TestHooksKeepGoingAfterError(t *testing.T) {
+	var hooks Hooks
+	closed := false
+	hooks.Add("pool", func(context.Context) error {
+		closed = true
+		return nil
+	})
+	hooks.Add("server", func(context.Context) error {
+		return errors.New("connections still open")
+	})
+
+	err := hooks.Run(context.Background())
+	require.EqualError(t, err, "server: connections still open")
+	require.True(t, closed)
+}
+
+func TestWait(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Go(func() {})
+	require.NoError(t, Wait(context.Background(), &wg))
+
+	release := make(chan struct{})
+	defer close(release)
+	wg.Go(func() { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, Wait(ctx, &wg), context.DeadlineExceeded)
+}
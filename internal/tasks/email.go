@@ -0,0 +1,111 @@
+package tasks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"simple_bank/internal/db"
+	"simple_bank/internal/jobs"
+	"simple_bank/internal/mail"
+)
+
+// SendVerifyEmail mails a user a link that verifies their email address
+const SendVerifyEmail jobs.Kind[VerifyEmailPayload] = "send_verify_email"
+
+// VerifyEmailPayload is the payload of SendVerifyEmail
+type VerifyEmailPayload struct {
+	Username string `json:"username"`
+}
+
+// EnqueueVerifyEmail queues the job that mails username a verification link, for both APIs.
+// A send that is already queued for the user is enough, so duplicates are not an error.
+func EnqueueVerifyEmail(ctx context.Context, q jobs.Enqueuer, username string) error {
+	_, err := SendVerifyEmail.Enqueue(ctx, q, VerifyEmailPayload{Username: username},
+		jobs.UniqueKey("verify_email:"+username))
+	if errors.Is(err, jobs.ErrDuplicate) {
+		return nil
+	}
+
+	return err
+}
+
+// Store is the part of db.Store the email tasks need
+type Store interface {
+	GetUser(ctx context.Context, username string) (db.User, error)
+	CreateVerifyEmail(ctx context.Context, arg db.CreateVerifyEmailParams) (db.VerifyEmail, error)
+	CreatePasswordReset(ctx context.Context, arg db.CreatePasswordResetParams) (db.PasswordReset, error)
+}
+
+// Emailer runs the jobs that send emails to users
+type Emailer struct {
+	store   Store
+	mailer  mail.Mailer
+	baseURL string
+}
+
+// NewEmailer creates an emailer whose links point at the API served from baseURL
+func NewEmailer(store Store, mailer mail.Mailer, baseURL string) *Emailer {
+	return &Emailer{
+		store:   store,
+		mailer:  mailer,
+		baseURL: baseURL,
+	}
+}
+
+// Register adds the emailer's handlers to worker
+func (emailer *Emailer) Register(worker *jobs.Worker) {
+	jobs.Handle(worker, SendVerifyEmail, emailer.sendVerifyEmail)
+	jobs.Handle(worker, SendPasswordReset, emailer.sendPasswordReset)
+}
+
+// sendVerifyEmail stores a new secret code for the user's current address and mails them the link.
+// Users who verified in the meantime get nothing.
+func (emailer *Emailer) sendVerifyEmail(ctx context.Context, payload VerifyEmailPayload) error {
+	user, err := emailer.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		return fmt.Errorf("cannot get user %s: %w", payload.Username, err)
+	}
+
+	if user.IsEmailVerified {
+		return nil
+	}
+
+	code, err := randomToken(16)
+	if err != nil {
+		return err
+	}
+
+	verifyEmail, err := emailer.store.CreateVerifyEmail(ctx, db.CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: code,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create verify email: %w", err)
+	}
+
+	link := fmt.Sprintf("%s/verify_email?%s", emailer.baseURL, url.Values{
+		"id":   {fmt.Sprint(verifyEmail.ID)},
+		"code": {verifyEmail.SecretCode},
+	}.Encode())
+
+	return emailer.mailer.Send(ctx, mail.Message{
+		To:      []string{user.Email},
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hello %s,\n\nPlease verify your email address by opening the link below. It expires in 15 minutes.\n\n%s\n",
+			user.FullName, link),
+	})
+}
+
+// randomToken returns n random bytes, hex encoded, that can't be guessed within the token's lifetime
+func randomToken(n int) (string, error) {
+	key := make([]byte, n)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
@@ -0,0 +1,129 @@
+package tasks
+
+import (
+	"context"
+	"net/url"
+	"simple_bank/internal/db"
+	"simple_bank/internal/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	user           db.User
+	verifyEmails   []db.VerifyEmail
+	passwordResets []db.PasswordReset
+}
+
+func (store *fakeStore) GetUser(ctx context.Context, username string) (db.User, error) {
+	if username != store.user.Username {
+		return db.User{}, db.ErrRecordNotFound
+	}
+	return store.user, nil
+}
+
+func (store *fakeStore) CreateVerifyEmail(ctx context.Context, arg db.CreateVerifyEmailParams) (db.VerifyEmail, error) {
+	verifyEmail := db.VerifyEmail{
+		ID:         int64(len(store.verifyEmails) + 1),
+		Username:   arg.Username,
+		Email:      arg.Email,
+		SecretCode: arg.SecretCode,
+	}
+	store.verifyEmails = append(store.verifyEmails, verifyEmail)
+	return verifyEmail, nil
+}
+
+func (store *fakeStore) CreatePasswordReset(ctx context.Context, arg db.CreatePasswordResetParams) (db.PasswordReset, error) {
+	reset := db.PasswordReset{
+		ID:        int64(len(store.passwordResets) + 1),
+		Username:  arg.Username,
+		TokenHash: arg.TokenHash,
+	}
+	store.passwordResets = append(store.passwordResets, reset)
+	return reset, nil
+}
+
+type recordingMailer struct {
+	sent []mail.Message
+}
+
+func (mailer *recordingMailer) Send(ctx context.Context, msg mail.Message) error {
+	mailer.sent = append(mailer.sent, msg)
+	return nil
+}
+
+func TestSendVerifyEmail(t *testing.T) {
+	store := &fakeStore{user: db.User{Username: "alice", FullName: "Alice", Email: "alice@example.com"}}
+	mailer := &recordingMailer{}
+	emailer := NewEmailer(store, mailer, "https://bank.example.com")
+
+	err := emailer.sendVerifyEmail(context.Background(), VerifyEmailPayload{Username: "alice"})
+	require.NoError(t, err)
+
+	require.Len(t, store.verifyEmails, 1)
+	verifyEmail := store.verifyEmails[0]
+	require.Equal(t, "alice@example.com", verifyEmail.Email)
+	require.Len(t, verifyEmail.SecretCode, 32)
+
+	require.Len(t, mailer.sent, 1)
+	require.Equal(t, []string{"alice@example.com"}, mailer.sent[0].To)
+
+	// the mail carries a link back to GET /verify_email with the stored code
+	var link string
+	for _, line := range strings.Split(mailer.sent[0].Body, "\n") {
+		if strings.HasPrefix(line, "https://") {
+			link = line
+		}
+	}
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	require.Equal(t, "/verify_email", parsed.Path)
+	require.Equal(t, "1", parsed.Query().Get("id"))
+	require.Equal(t, verifyEmail.SecretCode, parsed.Query().Get("code"))
+}
+
+func TestSendVerifyEmailAlreadyVerified(t *testing.T) {
+	store := &fakeStore{user: db.User{Username: "alice", Email: "alice@example.com", IsEmailVerified: true}}
+	mailer := &recordingMailer{}
+
+	err := NewEmailer(store, mailer, "").sendVerifyEmail(context.Background(), VerifyEmailPayload{Username: "alice"})
+	require.NoError(t, err)
+	require.Empty(t, store.verifyEmails)
+	require.Empty(t, mailer.sent)
+}
+
+func TestSendVerifyEmailUnknownUser(t *testing.T) {
+	err := NewEmailer(&fakeStore{}, &recordingMailer{}, "").sendVerifyEmail(context.Background(), VerifyEmailPayload{Username: "bob"})
+	require.ErrorIs(t, err, db.ErrRecordNotFound)
+}
+
+// fakeEnqueuer queues jobs in memory, answering like ON CONFLICT DO NOTHING for a taken unique key
+type fakeEnqueuer struct {
+	jobs []db.EnqueueJobParams
+}
+
+func (q *fakeEnqueuer) EnqueueJob(ctx context.Context, arg db.EnqueueJobParams) (db.Job, error) {
+	for _, job := range q.jobs {
+		if job.UniqueKey == arg.UniqueKey {
+			return db.Job{}, db.ErrRecordNotFound
+		}
+	}
+	q.jobs = append(q.jobs, arg)
+	return db.Job{ID: int64(len(q.jobs)), Kind: arg.Kind}, nil
+}
+
+func TestEnqueueVerifyEmail(t *testing.T) {
+	q := &fakeEnqueuer{}
+
+	require.NoError(t, EnqueueVerifyEmail(context.Background(), q, "alice"))
+	require.Len(t, q.jobs, 1)
+	require.Equal(t, string(SendVerifyEmail), q.jobs[0].Kind)
+	require.JSONEq(t, `{"username":"alice"}`, string(q.jobs[0].Payload))
+	require.Equal(t, "verify_email:alice", q.jobs[0].UniqueKey.String)
+
+	// a send already queued for the user is enough
+	require.NoError(t, EnqueueVerifyEmail(context.Background(), q, "alice"))
+	require.Len(t, q.jobs, 1)
+}
@@ -0,0 +1,55 @@
+package tasks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"simple_bank/internal/db"
+	"simple_bank/internal/jobs"
+	"simple_bank/internal/mail"
+)
+
+// SendPasswordReset mails a user a single use token for POST /users/password/reset
+const SendPasswordReset jobs.Kind[PasswordResetPayload] = "send_password_reset"
+
+// PasswordResetPayload is the payload of SendPasswordReset
+type PasswordResetPayload struct {
+	Username string `json:"username"`
+}
+
+// HashResetToken returns the hash a reset token is stored and looked up by
+func HashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// sendPasswordReset stores the hash of a new reset token and mails the token itself to the user,
+// so a leaked database can't be used to reset passwords
+func (emailer *Emailer) sendPasswordReset(ctx context.Context, payload PasswordResetPayload) error {
+	user, err := emailer.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		return fmt.Errorf("cannot get user %s: %w", payload.Username, err)
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+
+	_, err = emailer.store.CreatePasswordReset(ctx, db.CreatePasswordResetParams{
+		Username:  user.Username,
+		TokenHash: HashResetToken(token),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create password reset: %w", err)
+	}
+
+	return emailer.mailer.Send(ctx, mail.Message{
+		To:      []string{user.Email},
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\n\nSomeone asked to reset your password. If it was you, send this token to %s/users/password/reset "+
+			"with your new password. It expires in 30 minutes and works once.\n\n%s\n\nIf it wasn't you, you can ignore this email.\n",
+			user.FullName, emailer.baseURL, token),
+	})
+}
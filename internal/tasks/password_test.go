@@ -0,0 +1,40 @@
+package tasks
+
+import (
+	"context"
+	"simple_bank/internal/db"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendPasswordReset(t *testing.T) {
+	store := &fakeStore{user: db.User{Username: "alice", FullName: "Alice", Email: "alice@example.com"}}
+	mailer := &recordingMailer{}
+
+	err := NewEmailer(store, mailer, "https://bank.example.com").sendPasswordReset(context.Background(), PasswordResetPayload{Username: "alice"})
+	require.NoError(t, err)
+
+	require.Len(t, store.passwordResets, 1)
+	require.Len(t, mailer.sent, 1)
+	require.Equal(t, []string{"alice@example.com"}, mailer.sent[0].To)
+
+	// only the hash is stored, the mail carries the token that hashes to it
+	tokenHash := store.passwordResets[0].TokenHash
+	require.NotContains(t, mailer.sent[0].Body, tokenHash)
+
+	var found bool
+	for _, line := range strings.Split(mailer.sent[0].Body, "\n") {
+		if line != "" && HashResetToken(line) == tokenHash {
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+func TestHashResetToken(t *testing.T) {
+	require.Equal(t, HashResetToken("token"), HashResetToken("token"))
+	require.NotEqual(t, HashResetToken("token"), HashResetToken("other"))
+	require.Len(t, HashResetToken("token"), 64)
+}
@@ -0,0 +1,12 @@
+package token
+
+import "time"
+
+// Maker is an interface for managing access tokens
+type Maker interface {
+	// CreateToken creates a new token for a specific username and duration
+	CreateToken(username string, duration time.Duration) (string, *Payload, error)
+
+	// VerifyToken checks if the token is valid or not
+	VerifyToken(token string) (*Payload, error)
+}
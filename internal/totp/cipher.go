@@ -0,0 +1,70 @@
+package totp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+const minKeySize = 32
+
+// ErrSealedSecret is returned when a stored secret doesn't open under the key, or for its user
+var ErrSealedSecret = errors.New("TOTP secret cannot be decrypted")
+
+// Cipher keeps TOTP secrets encrypted at rest with AES-256-GCM. The secret is needed in the clear
+// to compute codes, so it can't be hashed like a password. The username is authenticated along with it,
+// so a secret copied to another user's row doesn't open.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher creates a cipher whose AES key is derived from key
+func NewCipher(key string) (*Cipher, error) {
+	if len(key) < minKeySize {
+		return nil, fmt.Errorf("invalid key size: must be at least %d characters", minKeySize)
+	}
+
+	derived := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(derived[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// Seal encrypts the secret of username, returning the nonce and ciphertext base64 encoded
+func (c *Cipher) Seal(secret, username string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(secret), []byte(username))
+	return base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a secret Seal encrypted for username
+func (c *Cipher) Open(sealed, username string) (string, error) {
+	data, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < c.aead.NonceSize() {
+		return "", ErrSealedSecret
+	}
+
+	nonceSize := c.aead.NonceSize()
+	secret, err := c.aead.Open(nil, data[:nonceSize], data[nonceSize:], []byte(username))
+	if err != nil {
+		return "", ErrSealedSecret
+	}
+
+	return string(secret), nil
+}
@@ -0,0 +1,45 @@
+package totp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCipher(t *testing.T) {
+	c, err := NewCipher("0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	sealed, err := c.Seal(secret, "alice")
+	require.NoError(t, err)
+	require.NotContains(t, sealed, secret)
+
+	// every seal has its own nonce
+	again, err := c.Seal(secret, "alice")
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, again)
+
+	opened, err := c.Open(sealed, "alice")
+	require.NoError(t, err)
+	require.Equal(t, secret, opened)
+
+	// the secret is bound to its user and its key
+	_, err = c.Open(sealed, "bob")
+	require.ErrorIs(t, err, ErrSealedSecret)
+
+	other, err := NewCipher("fedcba9876543210fedcba9876543210")
+	require.NoError(t, err)
+	_, err = other.Open(sealed, "alice")
+	require.ErrorIs(t, err, ErrSealedSecret)
+
+	_, err = c.Open(secret, "alice")
+	require.ErrorIs(t, err, ErrSealedSecret)
+}
+
+func TestNewCipherKeySize(t *testing.T) {
+	_, err := NewCipher("too short")
+	require.Error(t, err)
+}
@@ -0,0 +1,139 @@
+// Package totp implements the time-based one-time passwords of RFC 6238 as used by authenticator apps:
+// HMAC-SHA1, 30 second steps and 6 digit codes.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Parameters shared with authenticator apps through the provisioning URI
+const (
+	Period = 30 * time.Second
+	Digits = 6
+	// Skew is how many steps before and after the current one are still accepted, for clock drift
+	Skew = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a random 160 bit secret, base32 encoded as authenticator apps expect it
+func GenerateSecret() (string, error) {
+	key := make([]byte, 20)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(key), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI authenticator apps scan as a QR code
+func ProvisioningURI(secret, issuer, account string) string {
+	query := url.Values{
+		"secret":    {secret},
+		"issuer":    {issuer},
+		"algorithm": {"SHA1"},
+		"digits":    {fmt.Sprint(Digits)},
+		"period":    {fmt.Sprint(int(Period.Seconds()))},
+	}
+
+	return fmt.Sprintf("otpauth://totp/%s:%s?%s", url.PathEscape(issuer), url.PathEscape(account), query.Encode())
+}
+
+// Step returns the time step t falls in
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// Code returns the code for secret at time t
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return codeAt(key, Step(t)), nil
+}
+
+// Validate checks code against secret at time t, allowing Skew steps of drift.
+// It returns the step the code belongs to, so callers can refuse to accept the same step twice.
+func Validate(secret, code string, t time.Time) (int64, bool) {
+	key, err := decodeSecret(secret)
+	if err != nil || len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(t)
+	for step := current - Skew; step <= current+Skew; step++ {
+		if hmac.Equal([]byte(codeAt(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return nil, fmt.Errorf("invalid totp secret: %w", err)
+	}
+
+	return key, nil
+}
+
+// codeAt is the HOTP value of RFC 4226 for counter step
+func codeAt(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < Digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%modulo)
+}
+
+// RecoveryCodeCount is how many recovery codes a user gets when enrolling
+const RecoveryCodeCount = 10
+
+// GenerateRecoveryCodes returns n random single use codes formatted as xxxxx-xxxxx
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	for i := range codes {
+		key := make([]byte, 5)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+
+		code := hex.EncodeToString(key)
+		codes[i] = code[:5] + "-" + code[5:]
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash a recovery code is stored and looked up by.
+// Case and dashes are ignored, since users type the codes in by hand.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,85 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 key of the RFC 6238 test vectors
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeRFC6238(t *testing.T) {
+	// the RFC lists 8 digit codes, these are their last 6 digits
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, want := range vectors {
+		code, err := Code(rfcSecret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		require.Equal(t, want, code, "time %d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := Code(secret, now)
+	require.NoError(t, err)
+
+	step, ok := Validate(secret, code, now)
+	require.True(t, ok)
+	require.Equal(t, Step(now), step)
+
+	// one step of drift either way is tolerated, more is not
+	_, ok = Validate(secret, code, now.Add(Period))
+	require.True(t, ok)
+	_, ok = Validate(secret, code, now.Add(-Period))
+	require.True(t, ok)
+	_, ok = Validate(secret, code, now.Add(3*Period))
+	require.False(t, ok)
+
+	_, ok = Validate(secret, "12345", now)
+	require.False(t, ok)
+	_, ok = Validate("not base32!", code, now)
+	require.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(ProvisioningURI("JBSWY3DPEHPK3PXP", "Simple Bank", "alice"))
+	require.NoError(t, err)
+
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/Simple Bank:alice", uri.Path)
+	require.Equal(t, "JBSWY3DPEHPK3PXP", uri.Query().Get("secret"))
+	require.Equal(t, "Simple Bank", uri.Query().Get("issuer"))
+	require.Equal(t, "6", uri.Query().Get("digits"))
+	require.Equal(t, "30", uri.Query().Get("period"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(RecoveryCodeCount)
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		require.Regexp(t, `^[0-9a-f]{5}-[0-9a-f]{5}$`, code)
+		require.False(t, seen[code])
+		seen[code] = true
+	}
+
+	require.Equal(t, HashRecoveryCode("abcde-12345"), HashRecoveryCode(" ABCDE12345 "))
+	require.NotEqual(t, HashRecoveryCode("abcde-12345"), HashRecoveryCode("abcde-12346"))
+}
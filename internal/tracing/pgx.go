@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer is a pgx.QueryTracer that records a span per query, named after its sqlc query,
+// e.g. GetAccount. Set it as the Tracer of the pool's ConnConfig.
+// The arguments of a query are never recorded, as they can hold password hashes and tokens.
+type QueryTracer struct{}
+
+var _ pgx.QueryTracer = QueryTracer{}
+
+// TraceQueryStart starts the span of a query
+func (QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	name := queryName(data.SQL)
+	ctx, _ = Tracer().Start(ctx, "db."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(name),
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+	return ctx
+}
+
+// TraceQueryEnd ends the span of a query, recording its error
+func (QueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
+
+// queryName is the name sqlc gives a query in the comment its SQL starts with, e.g.
+// "-- name: GetAccount :one", or the SQL command, e.g. BEGIN, of any other statement
+func queryName(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if rest, ok := strings.CutPrefix(sql, "-- name: "); ok {
+		if fields := strings.Fields(rest); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "query"
+}
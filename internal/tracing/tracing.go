@@ -0,0 +1,101 @@
+// Package tracing sets up OpenTelemetry tracing: the exporter spans are sent to, the W3C trace context
+// propagated through incoming headers, and the spans of HTTP requests, store transactions and queries.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName names the service in every exported span
+const ServiceName = "simple_bank"
+
+// Exporter kinds accepted by Setup
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+// Tracer is what the bank starts its own spans with. Until Setup runs it is a no-op.
+func Tracer() trace.Tracer {
+	return otel.Tracer(ServiceName)
+}
+
+// Setup installs the global tracer provider and W3C trace context propagator, exporting spans to kind:
+// none or "" to not record spans, stdout or file to write them as JSON for local use, or otlp.
+// target is the file path for file exporters and the collector URL, e.g. http://localhost:4317, for otlp,
+// where the standard OTEL_EXPORTER_OTLP_* variables apply when it is empty.
+// The returned shutdown flushes the spans still buffered.
+func Setup(ctx context.Context, kind, target string) (shutdown func(context.Context) error, err error) {
+	// trace context propagates even when this service records nothing
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, err := newExporter(ctx, kind, target)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, kind, target string) (sdktrace.SpanExporter, error) {
+	switch kind {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		return newWriterExporter(os.Stdout)
+	case ExporterFile:
+		if target == "" {
+			return nil, fmt.Errorf("file trace exporter needs a target path")
+		}
+
+		file, err := os.OpenFile(target, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("cannot open trace file: %w", err)
+		}
+		return newWriterExporter(file)
+	case ExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if target != "" {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(target))
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", kind)
+	}
+}
+
+func newWriterExporter(w io.Writer) (sdktrace.SpanExporter, error) {
+	return stdouttrace.New(stdouttrace.WithWriter(w))
+}
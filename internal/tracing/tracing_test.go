@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans makes the global tracer provider record spans in memory for the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func TestQueryName(t *testing.T) {
+	require.Equal(t, "GetAccount", queryName("-- name: GetAccount :one\nSELECT id FROM accounts WHERE id = $1"))
+	require.Equal(t, "BEGIN", queryName("begin"))
+	require.Equal(t, "query", queryName("  "))
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := recordSpans(t)
+	tracer := QueryTracer{}
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL:  "-- name: UpdateUserPassword :one\nUPDATE users SET hashed_password = $1",
+		Args: []any{"$2a$10$hash"},
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("UPDATE 1")})
+
+	ctx = tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "-- name: GetAccount :one\nSELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("no rows in result set")})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "db.UpdateUserPassword", spans[0].Name())
+	for _, attr := range spans[0].Attributes() {
+		require.NotContains(t, attr.Value.Emit(), "$2a$10$hash")
+	}
+
+	require.Equal(t, "db.GetAccount", spans[1].Name())
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestSetup(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	_, err := Setup(context.Background(), "zipkin", "")
+	require.Error(t, err)
+
+	_, err = Setup(context.Background(), ExporterFile, "")
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "spans.json")
+	shutdown, err := Setup(context.Background(), ExporterFile, path)
+	require.NoError(t, err)
+
+	_, span := Tracer().Start(context.Background(), "db.TransferTx")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"Name":"db.TransferTx"`)
+	require.Contains(t, string(data), ServiceName)
+}
@@ -0,0 +1,191 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"simple_bank/internal/db"
+	"simple_bank/internal/health"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Delivery statuses. A dead delivery has used up its attempts and waits for a manual retry.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryDead      = "dead"
+)
+
+// Retry policy used by NewDeliverer
+const (
+	DefaultMaxAttempts  = 8
+	DefaultBaseBackoff  = 30 * time.Second
+	DefaultMaxBackoff   = time.Hour
+	DefaultPollInterval = time.Second
+	deliveryBatchSize   = 50
+	// deliveryLease keeps claimed deliveries from other deliverers for longer than a batch of slow endpoints takes
+	deliveryLease = 15 * time.Minute
+)
+
+// DelivererStore is the part of db.Store the deliverer needs to claim and record deliveries
+type DelivererStore interface {
+	ClaimDueWebhookDeliveries(ctx context.Context, arg db.ClaimDueWebhookDeliveriesParams) ([]db.ClaimDueWebhookDeliveriesRow, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) error
+}
+
+// Deliverer sends pending webhook deliveries, retrying failures with exponential backoff.
+// Deliveries are claimed before they are sent, so several deliverers can run side by side.
+type Deliverer struct {
+	store       DelivererStore
+	client      *http.Client
+	MaxAttempts int32
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Heartbeat, when set, is beaten after every batch so readiness checks can tell the deliverer is alive
+	Heartbeat *health.Heartbeat
+	now       func() time.Time
+}
+
+// NewDeliverer creates a deliverer with the default retry policy.
+// client should come from NewHTTPClient, which keeps deliveries out of private networks.
+func NewDeliverer(store DelivererStore, client *http.Client) *Deliverer {
+	return &Deliverer{
+		store:       store,
+		client:      client,
+		MaxAttempts: DefaultMaxAttempts,
+		BaseBackoff: DefaultBaseBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+		now:         time.Now,
+	}
+}
+
+// Run sends due deliveries every interval until ctx is cancelled
+func (deliverer *Deliverer) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := deliverer.DeliverOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "webhook: cannot deliver", "error", err)
+		}
+		deliverer.Heartbeat.Beat(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverOnce claims and sends one batch of due deliveries and returns how many succeeded.
+// A delivery claimed by a deliverer that dies before recording the attempt is sent again once its lease runs out.
+func (deliverer *Deliverer) DeliverOnce(ctx context.Context) (int, error) {
+	rows, err := deliverer.store.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: pgtype.Timestamptz{Time: deliverer.now().Add(deliveryLease), Valid: true},
+		Limit:      deliveryBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	succeeded := 0
+	for _, row := range rows {
+		statusCode, sendErr := deliverer.send(ctx, row)
+		if sendErr == nil {
+			succeeded++
+		}
+
+		err := deliverer.store.RecordWebhookDeliveryAttempt(ctx, deliverer.attempt(row.WebhookDelivery, statusCode, sendErr))
+		if err != nil {
+			return succeeded, err
+		}
+	}
+
+	return succeeded, nil
+}
+
+// send posts the signed payload and returns the response status code, if any.
+// Plain http endpoints, registered before URLs were validated, fail here rather than being sent to;
+// the client refuses private addresses.
+func (deliverer *Deliverer) send(ctx context.Context, row db.ClaimDueWebhookDeliveriesRow) (int, error) {
+	delivery := row.WebhookDelivery
+
+	if target, err := url.Parse(row.Url); err != nil || target.Scheme != "https" {
+		return 0, ErrForbiddenTarget
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, row.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, delivery.EventType)
+	request.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	request.Header.Set(SignatureHeader, Sign(row.Secret, deliverer.now(), delivery.Payload))
+
+	response, err := deliverer.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("unexpected status %s", response.Status)
+	}
+
+	return response.StatusCode, nil
+}
+
+// attempt describes the outcome of one delivery attempt.
+// Failures are retried after BaseBackoff doubled per earlier attempt, up to MaxBackoff,
+// and the delivery is dead lettered once MaxAttempts is reached.
+func (deliverer *Deliverer) attempt(delivery db.WebhookDelivery, statusCode int, sendErr error) db.RecordWebhookDeliveryAttemptParams {
+	now := deliverer.now()
+	arg := db.RecordWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         DeliverySucceeded,
+		NextAttemptAt:  pgtype.Timestamptz{Time: now, Valid: true},
+		ResponseStatus: pgtype.Int4{Int32: int32(statusCode), Valid: statusCode != 0},
+	}
+	if sendErr == nil {
+		return arg
+	}
+
+	attempts := delivery.Attempts + 1
+	arg.LastError = pgtype.Text{String: sendErr.Error(), Valid: true}
+	if attempts >= deliverer.MaxAttempts {
+		arg.Status = DeliveryDead
+		return arg
+	}
+
+	arg.Status = DeliveryPending
+	arg.NextAttemptAt.Time = now.Add(deliverer.Backoff(attempts))
+	return arg
+}
+
+// Backoff returns how long to wait before retrying after the given number of failed attempts
+func (deliverer *Deliverer) Backoff(attempts int32) time.Duration {
+	backoff := deliverer.BaseBackoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= deliverer.MaxBackoff {
+			return deliverer.MaxBackoff
+		}
+	}
+
+	return backoff
+}
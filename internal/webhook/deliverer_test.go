@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"simple_bank/internal/db"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDelivererStore hands out the same due deliveries and records every claim and attempt
+type fakeDelivererStore struct {
+	rows     []db.ClaimDueWebhookDeliveriesRow
+	claims   []db.ClaimDueWebhookDeliveriesParams
+	attempts []db.RecordWebhookDeliveryAttemptParams
+}
+
+func (store *fakeDelivererStore) ClaimDueWebhookDeliveries(ctx context.Context, arg db.ClaimDueWebhookDeliveriesParams) ([]db.ClaimDueWebhookDeliveriesRow, error) {
+	store.claims = append(store.claims, arg)
+	return store.rows, nil
+}
+
+func (store *fakeDelivererStore) RecordWebhookDeliveryAttempt(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) error {
+	store.attempts = append(store.attempts, arg)
+	return nil
+}
+
+func dueDelivery(url string, attempts int32) db.ClaimDueWebhookDeliveriesRow {
+	return db.ClaimDueWebhookDeliveriesRow{
+		WebhookDelivery: db.WebhookDelivery{
+			ID:        9,
+			EventType: db.EventTransferCreated,
+			Payload:   []byte(`{"id":1}`),
+			Attempts:  attempts,
+		},
+		Url:    url,
+		Secret: "whsec_test",
+	}
+}
+
+func TestDeliverSigned(t *testing.T) {
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, Verify("whsec_test", r.Header.Get(SignatureHeader), body, time.Minute))
+		require.Equal(t, db.EventTransferCreated, r.Header.Get(EventHeader))
+		require.Equal(t, "9", r.Header.Get(DeliveryHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	store := &fakeDelivererStore{rows: []db.ClaimDueWebhookDeliveriesRow{dueDelivery(receiver.URL, 0)}}
+	deliverer := NewDeliverer(store, receiver.Client())
+
+	n, err := deliverer.DeliverOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Len(t, store.attempts, 1)
+	require.Equal(t, DeliverySucceeded, store.attempts[0].Status)
+	require.Equal(t, int32(http.StatusOK), store.attempts[0].ResponseStatus.Int32)
+
+	// the delivery was leased while it was sent
+	require.Len(t, store.claims, 1)
+	require.WithinDuration(t, time.Now().Add(deliveryLease), store.claims[0].LeaseUntil.Time, time.Minute)
+}
+
+func TestDeliverRefusesPlainHTTP(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("a plain http endpoint was sent to")
+	}))
+	defer receiver.Close()
+
+	store := &fakeDelivererStore{rows: []db.ClaimDueWebhookDeliveriesRow{dueDelivery(receiver.URL, 0)}}
+	deliverer := NewDeliverer(store, receiver.Client())
+
+	n, err := deliverer.DeliverOnce(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Equal(t, DeliveryPending, store.attempts[0].Status)
+	require.Contains(t, store.attempts[0].LastError.String, ErrForbiddenTarget.Error())
+}
+
+func TestDeliverRetriesWithBackoff(t *testing.T) {
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	now := time.Now()
+	store := &fakeDelivererStore{rows: []db.ClaimDueWebhookDeliveriesRow{dueDelivery(receiver.URL, 2)}}
+	deliverer := NewDeliverer(store, receiver.Client())
+	deliverer.now = func() time.Time { return now }
+
+	n, err := deliverer.DeliverOnce(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	attempt := store.attempts[0]
+	require.Equal(t, DeliveryPending, attempt.Status)
+	require.True(t, attempt.LastError.Valid)
+	require.Equal(t, now.Add(4*DefaultBaseBackoff), attempt.NextAttemptAt.Time)
+}
+
+func TestDeliverDeadLetters(t *testing.T) {
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer receiver.Close()
+
+	store := &fakeDelivererStore{rows: []db.ClaimDueWebhookDeliveriesRow{dueDelivery(receiver.URL, DefaultMaxAttempts-1)}}
+	deliverer := NewDeliverer(store, receiver.Client())
+
+	_, err := deliverer.DeliverOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, DeliveryDead, store.attempts[0].Status)
+}
+
+func TestBackoff(t *testing.T) {
+	deliverer := NewDeliverer(nil, nil)
+
+	require.Equal(t, DefaultBaseBackoff, deliverer.Backoff(1))
+	require.Equal(t, 2*DefaultBaseBackoff, deliverer.Backoff(2))
+	require.Equal(t, DefaultMaxBackoff, deliverer.Backoff(20))
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"simple_bank/internal/db"
+	"simple_bank/internal/outbox"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// FanoutStore is the part of db.Store the fanout needs to find interested endpoints
+type FanoutStore interface {
+	ListMerchantsByAdminAccount(ctx context.Context, adminID int32) ([]db.Merchant, error)
+	ListMerchantIDsByOrder(ctx context.Context, orderID pgtype.Int4) ([]int32, error)
+	ListWebhookEndpointsForEvent(ctx context.Context, arg db.ListWebhookEndpointsForEventParams) ([]db.WebhookEndpoint, error)
+	CreateWebhookDelivery(ctx context.Context, arg db.CreateWebhookDeliveryParams) error
+}
+
+// Fanout is an outbox publisher that turns domain events into pending webhook deliveries
+// for every endpoint of the merchants the event concerns. Deliveries are deduplicated on
+// the outbox event ID, so an event the outbox publishes twice is still delivered once.
+type Fanout struct {
+	store FanoutStore
+}
+
+// NewFanout creates a fanout publisher
+func NewFanout(store FanoutStore) *Fanout {
+	return &Fanout{store: store}
+}
+
+// Payload is the body of every delivery. Data describes the event with only the fields merchants need,
+// never the owners, balances or ledger entries of the accounts involved.
+type Payload struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// TransferData is the data of a transfer.created delivery
+type TransferData struct {
+	ID            int64     `json:"id"`
+	FromAccountID int64     `json:"from_account_id"`
+	ToAccountID   int64     `json:"to_account_id"`
+	Amount        int64     `json:"amount"`
+	Currency      string    `json:"currency"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// OrderData is the data of an order.created or order.status_changed delivery
+type OrderData struct {
+	ID        int32     `json:"id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Publish queues a delivery of event to each endpoint subscribed to it
+func (fanout *Fanout) Publish(ctx context.Context, event outbox.Event) error {
+	merchantIDs, data, err := fanout.describe(ctx, event)
+	if err != nil || len(merchantIDs) == 0 {
+		return err
+	}
+
+	endpoints, err := fanout.store.ListWebhookEndpointsForEvent(ctx, db.ListWebhookEndpointsForEventParams{
+		MerchantIds: merchantIDs,
+		EventType:   event.Type,
+	})
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(Payload{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, endpoint := range endpoints {
+		err = fanout.store.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+			EndpointID: endpoint.ID,
+			EventID:    event.ID,
+			EventType:  event.Type,
+			Payload:    payload,
+		})
+		if err != nil {
+			return fmt.Errorf("queue delivery to endpoint %d: %w", endpoint.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// describe returns the merchants an event concerns, those whose account sent or received
+// a transfer and those selling products in an order, along with the data delivered to them
+func (fanout *Fanout) describe(ctx context.Context, event outbox.Event) ([]int64, any, error) {
+	switch event.Type {
+	case db.EventTransferCreated:
+		var result db.TransferTxResult
+		if err := json.Unmarshal(event.Payload, &result); err != nil {
+			return nil, nil, err
+		}
+
+		var ids []int64
+		for _, accountID := range []int64{result.Transfer.FromAccountID, result.Transfer.ToAccountID} {
+			merchants, err := fanout.store.ListMerchantsByAdminAccount(ctx, int32(accountID))
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, merchant := range merchants {
+				ids = append(ids, merchant.ID)
+			}
+		}
+
+		return ids, TransferData{
+			ID:            result.Transfer.ID,
+			FromAccountID: result.Transfer.FromAccountID,
+			ToAccountID:   result.Transfer.ToAccountID,
+			Amount:        result.Transfer.Amount,
+			Currency:      result.FromAccount.Currency,
+			CreatedAt:     result.Transfer.CreatedAt.Time,
+		}, nil
+
+	case db.EventOrderCreated, db.EventOrderStatusChanged:
+		var order db.Order
+		if err := json.Unmarshal(event.Payload, &order); err != nil {
+			return nil, nil, err
+		}
+
+		merchantIDs, err := fanout.store.ListMerchantIDsByOrder(ctx, pgtype.Int4{Int32: order.ID, Valid: true})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ids := make([]int64, len(merchantIDs))
+		for i, id := range merchantIDs {
+			ids[i] = int64(id)
+		}
+
+		return ids, OrderData{
+			ID:        order.ID,
+			Status:    order.Status.String,
+			CreatedAt: order.CreatedAt.Time,
+		}, nil
+	}
+
+	return nil, nil, nil
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"simple_bank/internal/db"
+	"simple_bank/internal/outbox"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFanoutStore knows one merchant behind account 10 and order 5, with one endpoint
+type fakeFanoutStore struct {
+	deliveries []db.CreateWebhookDeliveryParams
+}
+
+func (store *fakeFanoutStore) ListMerchantsByAdminAccount(ctx context.Context, adminID int32) ([]db.Merchant, error) {
+	if adminID == 10 {
+		return []db.Merchant{{ID: 3}}, nil
+	}
+	return nil, nil
+}
+
+func (store *fakeFanoutStore) ListMerchantIDsByOrder(ctx context.Context, orderID pgtype.Int4) ([]int32, error) {
+	if orderID.Int32 == 5 {
+		return []int32{3}, nil
+	}
+	return nil, nil
+}
+
+func (store *fakeFanoutStore) ListWebhookEndpointsForEvent(ctx context.Context, arg db.ListWebhookEndpointsForEventParams) ([]db.WebhookEndpoint, error) {
+	var endpoints []db.WebhookEndpoint
+	for _, id := range arg.MerchantIds {
+		if id == 3 {
+			endpoints = append(endpoints, db.WebhookEndpoint{ID: 7, MerchantID: 3})
+		}
+	}
+	return endpoints, nil
+}
+
+func (store *fakeFanoutStore) CreateWebhookDelivery(ctx context.Context, arg db.CreateWebhookDeliveryParams) error {
+	store.deliveries = append(store.deliveries, arg)
+	return nil
+}
+
+func TestFanoutTransferToMerchant(t *testing.T) {
+	payload, err := json.Marshal(db.TransferTxResult{
+		Transfer:    db.Transfer{ID: 1, FromAccountID: 2, ToAccountID: 10, Amount: 50},
+		FromAccount: db.Account{ID: 2, Owner: "alice", Balance: 950, Currency: "USD"},
+		ToAccount:   db.Account{ID: 10, Owner: "shop", Balance: 10050, Currency: "USD"},
+		FromEntry:   db.Entry{ID: 3, AccountID: 2, Amount: -50},
+		ToEntry:     db.Entry{ID: 4, AccountID: 10, Amount: 50},
+	})
+	require.NoError(t, err)
+
+	store := &fakeFanoutStore{}
+	err = NewFanout(store).Publish(context.Background(), outbox.Event{ID: 42, Type: db.EventTransferCreated, Payload: payload})
+	require.NoError(t, err)
+
+	require.Len(t, store.deliveries, 1)
+	require.Equal(t, int64(7), store.deliveries[0].EndpointID)
+	require.Equal(t, int64(42), store.deliveries[0].EventID)
+
+	// merchants learn about the transfer, not the accounts behind it
+	var delivered map[string]any
+	require.NoError(t, json.Unmarshal(store.deliveries[0].Payload, &delivered))
+	require.Equal(t, float64(42), delivered["id"])
+	require.Equal(t, db.EventTransferCreated, delivered["type"])
+	require.Equal(t, map[string]any{
+		"id":              float64(1),
+		"from_account_id": float64(2),
+		"to_account_id":   float64(10),
+		"amount":          float64(50),
+		"currency":        "USD",
+		"created_at":      "0001-01-01T00:00:00Z",
+	}, delivered["data"])
+	require.NotContains(t, string(store.deliveries[0].Payload), "alice")
+	require.NotContains(t, string(store.deliveries[0].Payload), "balance")
+}
+
+func TestFanoutOrderStatus(t *testing.T) {
+	payload, err := json.Marshal(db.Order{ID: 5})
+	require.NoError(t, err)
+
+	store := &fakeFanoutStore{}
+	err = NewFanout(store).Publish(context.Background(), outbox.Event{ID: 43, Type: db.EventOrderStatusChanged, Payload: payload})
+	require.NoError(t, err)
+	require.Len(t, store.deliveries, 1)
+}
+
+func TestFanoutIgnoresUnrelatedEvents(t *testing.T) {
+	payload, err := json.Marshal(db.TransferTxResult{Transfer: db.Transfer{ID: 1, FromAccountID: 2, ToAccountID: 4}})
+	require.NoError(t, err)
+
+	store := &fakeFanoutStore{}
+	err = NewFanout(store).Publish(context.Background(), outbox.Event{ID: 44, Type: db.EventTransferCreated, Payload: payload})
+	require.NoError(t, err)
+
+	err = NewFanout(store).Publish(context.Background(), outbox.Event{ID: 45, Type: db.EventAccountCreated, Payload: []byte(`{}`)})
+	require.NoError(t, err)
+	require.Empty(t, store.deliveries)
+}
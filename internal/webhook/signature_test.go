@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+
+	body := []byte(`{"id":1}`)
+	header := Sign(secret, time.Now(), body)
+
+	require.NoError(t, Verify(secret, header, body, time.Minute))
+	require.ErrorIs(t, Verify(secret, header, []byte(`{"id":2}`), time.Minute), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("whsec_other", header, body, time.Minute), ErrInvalidSignature)
+	require.ErrorIs(t, Verify(secret, "garbage", body, time.Minute), ErrInvalidSignature)
+}
+
+func TestVerifyStaleSignature(t *testing.T) {
+	body := []byte(`{}`)
+	header := Sign("secret", time.Now().Add(-time.Hour), body)
+
+	require.ErrorIs(t, Verify("secret", header, body, 5*time.Minute), ErrStaleSignature)
+	require.NoError(t, Verify("secret", header, body, 0))
+}
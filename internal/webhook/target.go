@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenTarget is returned for endpoint URLs that aren't https or point into a private network.
+// Merchants choose the URLs, so without this check the deliverer could be made to post to
+// the bank's own services, or to cloud metadata endpoints.
+var ErrForbiddenTarget = errors.New("webhook endpoint must be a public https URL")
+
+// carrierNAT is the shared address space of carrier-grade NAT, which netip doesn't count as private
+var carrierNAT = netip.MustParsePrefix("100.64.0.0/10")
+
+// ValidateURL checks rawURL can be registered as an endpoint: https, and not a loopback,
+// private or link-local address. Host names are checked again once resolved, at delivery time.
+func ValidateURL(rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil || target.Scheme != "https" || target.Hostname() == "" {
+		return ErrForbiddenTarget
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(target.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrForbiddenTarget, host)
+	}
+
+	if ip, err := netip.ParseAddr(host); err == nil && !isPublic(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenTarget, ip)
+	}
+
+	return nil
+}
+
+// NewHTTPClient creates the client deliveries are sent with. It only connects to public addresses,
+// whatever a host name resolves to at the time, and doesn't follow redirects or use a proxy.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: dialPublicOnly,
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// dialPublicOnly refuses connections to non-public addresses, after name resolution
+func dialPublicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	if !isPublic(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenTarget, ip)
+	}
+	return nil
+}
+
+// isPublic reports whether ip is routable on the internet as far as the deliverer cares
+func isPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!carrierNAT.Contains(ip)
+}
@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateURL(t *testing.T) {
+	for _, rawURL := range []string{
+		"https://merchant.example.com/hooks",
+		"https://203.0.113.7:8443/hooks",
+	} {
+		require.NoError(t, ValidateURL(rawURL), rawURL)
+	}
+
+	for _, rawURL := range []string{
+		"http://merchant.example.com/hooks",
+		"ftp://merchant.example.com/hooks",
+		"https:///hooks",
+		"https://localhost/hooks",
+		"https://api.localhost./hooks",
+		"https://127.0.0.1/hooks",
+		"https://10.1.2.3/hooks",
+		"https://192.168.0.10/hooks",
+		"https://169.254.169.254/latest/meta-data",
+		"https://100.64.0.1/hooks",
+		"https://0.0.0.0/hooks",
+		"https://[::1]/hooks",
+		"https://[fd00::1]/hooks",
+		"https://[fe80::1]/hooks",
+		"https://[::ffff:127.0.0.1]/hooks",
+	} {
+		require.ErrorIs(t, ValidateURL(rawURL), ErrForbiddenTarget, rawURL)
+	}
+}
+
+func TestHTTPClientRefusesPrivateAddresses(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	// the receiver listens on loopback, as a host name resolving into the bank's network would
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, receiver.URL, nil)
+	require.NoError(t, err)
+
+	_, err = NewHTTPClient(time.Second).Do(request)
+	require.ErrorIs(t, err, ErrForbiddenTarget)
+}
@@ -1,37 +1,172 @@
+// Command simple_bank runs the bank. Every subcommand reads the same configuration,
+// from app.env when there is one and from environment variables:
+//
+//	simple_bank [-config DIR] serve [-api http|grpc|both] [-workers]
+//	simple_bank [-config DIR] worker
+//	simple_bank [-config DIR] migrate up [N] | down [N|all] | status | force VERSION
+//	simple_bank [-config DIR] seed [-password PASSWORD]
+//	simple_bank [-config DIR] reconcile
 package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
-	"log"
-	"simple_bank/api"
+	"log/slog"
+	"os"
+	"os/signal"
 	"simple_bank/internal/db"
+	"simple_bank/internal/logging"
+	"simple_bank/internal/metrics"
+	"simple_bank/internal/shutdown"
+	"simple_bank/internal/tracing"
 	"simple_bank/util"
+	"syscall"
 
+	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+// command is a subcommand of the binary. run parses args, the arguments after the command name.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, configPath string, args []string) error
+}
+
+var commands = []command{
+	{"serve", "serve the HTTP and gRPC APIs, and run the background workers", runServe},
+	{"worker", "run the outbox dispatcher, webhook deliverer and job worker without the APIs", runWorker},
+	{"migrate", "apply or revert the database migrations shipped with the binary", runMigrate},
+	{"seed", "create demo users, accounts and balances for local development", runSeed},
+	{"reconcile", "check every balance against its entries, exiting 1 when the ledger doesn't reconcile", runReconcile},
+}
 
 func main() {
-	config, err := util.LoadConfig(".")
+	configPath := flag.String("config", ".", "directory of the app.env file; environment variables override it")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := flag.Arg(0)
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		// SIGTERM from the orchestrator or ^C stops the command, serve draining first.
+		// The handler is reset once it fires, so a second signal kills the process.
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+		go func() {
+			<-ctx.Done()
+			stop()
+		}()
+		err := cmd.run(ctx, *configPath, flag.Args()[1:])
+		stop()
+		if err != nil {
+			slog.Error("Command failed", "command", name, "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(flag.CommandLine.Output(), "unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [-config DIR] <command> [flags]\n\nCommands:\n", os.Args[0])
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(out, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+// app is what every command shares: the configuration, the database and the hooks that stop them
+type app struct {
+	config util.Config
+	pool   *pgxpool.Pool
+	store  db.Store
+	hooks  shutdown.Hooks
+}
+
+// newApp loads the configuration from configPath, sets up logging and tracing and opens the pool.
+// Everything started with it, then and later, is stopped by close.
+func newApp(ctx context.Context, configPath string) (*app, error) {
+	config, err := util.LoadConfig(configPath)
 	if err != nil {
-		log.Fatal("Cannot load config:", err)
+		return nil, fmt.Errorf("cannot load config: %w", err)
 	}
 
-	// Add this log so you can see what's happening in the console
-	fmt.Printf("Testing connection to: %s\n", config.DBSource)
+	logger, err := logging.New(os.Stdout, config.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create logger: %w", err)
+	}
+	// the servers, background workers and commands all log through the default logger
+	slog.SetDefault(logger)
+	// gin's debug output is plain text, which would break up the JSON lines
+	gin.SetMode(gin.ReleaseMode)
 
-	conn, err := pgxpool.New(context.Background(), config.DBSource)
+	app := &app{config: config}
+
+	shutdownTracing, err := tracing.Setup(ctx, config.TraceExporter, config.TraceTarget)
 	if err != nil {
-		log.Fatal("cannot connect to db:", err)
+		return nil, fmt.Errorf("cannot set up tracing: %w", err)
 	}
+	app.hooks.Add("tracing", shutdownTracing)
+
+	slog.Info("Connecting to database", "database", logging.RedactURL(config.DBSource))
 
-	store := db.NewStore(conn)
-	server := api.NewServer(store)
+	poolConfig, err := pgxpool.ParseConfig(config.DBSource)
+	if err != nil {
+		app.close()
+		return nil, fmt.Errorf("cannot parse db source: %w", err)
+	}
+	// every query gets a span, under the request or transaction that ran it
+	poolConfig.ConnConfig.Tracer = tracing.QueryTracer{}
 
-	err = server.StartServer(config.ServerAddress)
+	app.pool, err = pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
-		log.Fatal("Cannot start server:", err)
+		app.close()
+		return nil, fmt.Errorf("cannot connect to db: %w", err)
 	}
+	app.hooks.Add("database pool", func(context.Context) error {
+		app.pool.Close()
+		return nil
+	})
+
+	app.store = db.NewStore(app.pool)
+	return app, nil
+}
 
+// registerPoolMetrics reports the pool on GET /metrics, alongside the requests it serves
+func (app *app) registerPoolMetrics() {
+	prometheus.MustRegister(metrics.NewPoolCollector(app.pool))
+}
+
+// close runs the shutdown hooks, latest first, within SHUTDOWN_TIMEOUT
+func (app *app) close() error {
+	timeout := app.config.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = shutdown.DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return app.hooks.Run(ctx)
+}
+
+// closeApp closes app once a command is done, reporting a failed shutdown unless the command failed first
+func closeApp(app *app, err *error) {
+	if closeErr := app.close(); closeErr != nil && *err == nil {
+		*err = fmt.Errorf("cannot shut down cleanly: %w", closeErr)
+	}
 }
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"simple_bank/internal/db"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+// runMigrate applies, reverts or reports the migrations embedded in the binary with golang-migrate:
+// up [N] applies N pending migrations or all of them, down [N|all] reverts the last N, 1 by default,
+// status reports the version and force VERSION clears a failed migration once the schema is fixed
+func runMigrate(ctx context.Context, configPath string, args []string) (err error) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: migrate up [N] | down [N|all] | status | force VERSION")
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 || flags.NArg() > 2 {
+		flags.Usage()
+		return fmt.Errorf("migrate needs a single subcommand and at most one argument")
+	}
+	action, arg := flags.Arg(0), flags.Arg(1)
+
+	// all arguments are checked before connecting
+	var n, version int
+	switch action {
+	case "up":
+		n, err = migrationCount(arg, 0)
+	case "down":
+		n, err = migrationCount(arg, 1)
+	case "status":
+		if arg != "" {
+			err = fmt.Errorf("status takes no argument")
+		}
+	case "force":
+		if arg == "" {
+			err = fmt.Errorf("force needs the version to record")
+		} else if version, err = strconv.Atoi(arg); err != nil {
+			err = fmt.Errorf("invalid version %q: %w", arg, err)
+		}
+	default:
+		err = fmt.Errorf("unknown migrate subcommand %q, want up, down, status or force", action)
+	}
+	if err != nil {
+		return err
+	}
+
+	app, err := newApp(ctx, configPath)
+	if err != nil {
+		return err
+	}
+	defer closeApp(app, &err)
+
+	migrator, err := db.NewMigrator(app.pool)
+	if err != nil {
+		return fmt.Errorf("cannot create migrator: %w", err)
+	}
+	defer migrator.Close()
+	migrator.Log = migrateLogger{}
+
+	// a signal stops after the migration being applied, never halfway through one
+	go func() {
+		<-ctx.Done()
+		migrator.GracefulStop <- true
+	}()
+
+	switch action {
+	case "up":
+		if n == 0 {
+			err = migrator.Up()
+		} else {
+			err = migrator.Steps(n)
+		}
+	case "down":
+		if n == 0 {
+			err = migrator.Down()
+		} else {
+			err = migrator.Steps(-n)
+		}
+	case "force":
+		err = migrator.Force(version)
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	latest, err := db.LatestMigration()
+	if err != nil {
+		return err
+	}
+
+	current, dirty, err := migrator.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		slog.Info("Migration status", "version", "none", "latest", latest)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info("Migration status", "version", current, "dirty", dirty, "latest", latest)
+	return nil
+}
+
+// migrateLogger writes the progress of golang-migrate to the default logger
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...any) {
+	slog.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (migrateLogger) Verbose() bool {
+	return true
+}
+
+// migrationCount parses the N of up and down, where all or an empty arg mean fallback
+// and 0 means every migration
+func migrationCount(arg string, fallback int) (int, error) {
+	switch arg {
+	case "":
+		return fallback, nil
+	case "all":
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(arg)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid migration count %q, want a positive number or all", arg)
+	}
+	return n, nil
+}
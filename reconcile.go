@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"simple_bank/internal/reconcile"
+)
+
+// errUnreconciled fails reconcile, so a scheduler alerts on its exit status
+var errUnreconciled = errors.New("ledger doesn't reconcile")
+
+// runReconcile checks every account and GL balance against its entries, logging each mismatch
+func runReconcile(ctx context.Context, configPath string, args []string) (err error) {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	flags.Parse(args)
+
+	app, err := newApp(ctx, configPath)
+	if err != nil {
+		return err
+	}
+	defer closeApp(app, &err)
+
+	report, err := reconcile.Run(ctx, app.store)
+	if err != nil {
+		return err
+	}
+
+	report.Log(ctx, slog.Default())
+	if !report.OK() {
+		return errUnreconciled
+	}
+	return nil
+}
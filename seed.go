@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"simple_bank/internal/db"
+	"simple_bank/util"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// seedCountry is the country of the seeded accounts
+var seedCountry = db.CreateCountryParams{
+	Code:          840,
+	Name:          pgtype.Text{String: "United States", Valid: true},
+	ContinentName: pgtype.Text{String: "North America", Valid: true},
+}
+
+// seedActor requests the opening balance adjustments, which the system approves
+const seedActor = "seed"
+
+// seedAccount is an account opened for a demo user, with its opening balance in minor units
+type seedAccount struct {
+	currency string
+	balance  int64
+}
+
+// seedUser is a demo user, with the roles granted on top of customer and the accounts opened for them
+type seedUser struct {
+	username string
+	fullName string
+	roles    []string
+	accounts []seedAccount
+}
+
+var seedUsers = []seedUser{
+	{username: "alice", fullName: "Alice Customer", accounts: []seedAccount{{util.USD, 100_000}, {util.EUR, 50_000}}},
+	{username: "bob", fullName: "Bob Customer", accounts: []seedAccount{{util.USD, 25_000}}},
+	{username: "staff", fullName: "Sam Staff", roles: []string{db.RoleBankStaff}},
+	{username: "admin", fullName: "Ada Admin", roles: []string{db.RoleBankAdmin}},
+}
+
+// runSeed creates the demo users and accounts of a local database. Users that exist already are left alone,
+// so seeding twice changes nothing. Opening balances are posted as approved goodwill adjustments
+// against the suspense GL account, so a seeded ledger reconciles.
+func runSeed(ctx context.Context, configPath string, args []string) (err error) {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	password := flags.String("password", "secret", "password of every seeded user")
+	flags.Parse(args)
+
+	hashedPassword, err := util.HashPassword(*password)
+	if err != nil {
+		return fmt.Errorf("cannot hash password: %w", err)
+	}
+
+	app, err := newApp(ctx, configPath)
+	if err != nil {
+		return err
+	}
+	defer closeApp(app, &err)
+
+	if _, err := app.store.GetCountry(ctx, seedCountry.Code); errors.Is(err, db.ErrRecordNotFound) {
+		_, err = app.store.CreateCountry(ctx, seedCountry)
+		if err != nil {
+			return fmt.Errorf("cannot create country: %w", err)
+		}
+	} else if err != nil {
+		return err
+	}
+
+	for _, seed := range seedUsers {
+		created, err := seedOne(ctx, app.store, seed, hashedPassword)
+		if err != nil {
+			return fmt.Errorf("cannot seed %s: %w", seed.username, err)
+		}
+		if created {
+			slog.Info("Seeded user", "username", seed.username, "roles", seed.roles, "accounts", len(seed.accounts))
+		} else {
+			slog.Info("User exists, skipped", "username", seed.username)
+		}
+	}
+	return nil
+}
+
+// seedOne creates seed with a verified email and its roles and funded accounts, unless the user exists
+func seedOne(ctx context.Context, store db.Store, seed seedUser, hashedPassword string) (bool, error) {
+	_, err := store.GetUser(ctx, seed.username)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, db.ErrRecordNotFound) {
+		return false, err
+	}
+
+	// the verification row is created with the user, then used straight away
+	var verifyEmail db.VerifyEmail
+	user, err := store.CreateUserTx(ctx, db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       seed.username,
+			HashedPassword: hashedPassword,
+			FullName:       seed.fullName,
+			Email:          seed.username + "@simplebank.local",
+		},
+		AfterCreate: func(q *db.Queries, user db.User) error {
+			var err error
+			verifyEmail, err = q.CreateVerifyEmail(ctx, db.CreateVerifyEmailParams{
+				Username:   user.Username,
+				Email:      user.Email,
+				SecretCode: util.RandomString(32),
+			})
+			return err
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	_, err = store.VerifyEmailTx(ctx, db.VerifyEmailTxParams{ID: verifyEmail.ID, SecretCode: verifyEmail.SecretCode})
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range seed.roles {
+		_, err := store.GrantRoleTx(ctx, db.GrantUserRoleParams{
+			Username:  user.Username,
+			Role:      role,
+			GrantedBy: db.GrantedBySystem,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for _, seedAccount := range seed.accounts {
+		account, err := store.CreateAccountTx(ctx, db.CreateAccountParams{
+			Owner:       user.Username,
+			Currency:    seedAccount.currency,
+			CountryCode: seedCountry.Code,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		adjustment, err := store.RequestAdjustmentTx(ctx, db.CreateLedgerAdjustmentParams{
+			AccountID:   account.ID,
+			GlCode:      db.GLSuspense,
+			Amount:      seedAccount.balance,
+			ReasonCode:  db.AdjustmentGoodwill,
+			Note:        "opening balance from seed",
+			RequestedBy: seedActor,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		_, err = store.DecideAdjustmentTx(ctx, db.DecideAdjustmentTxParams{
+			AdjustmentID: adjustment.ID,
+			Approve:      true,
+			DecidedBy:    db.GrantedBySystem,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
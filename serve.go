@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"simple_bank/api"
+	"simple_bank/gapi"
+	"simple_bank/internal/health"
+	"simple_bank/internal/jobs"
+	"simple_bank/internal/mail"
+	"simple_bank/internal/outbox"
+	"simple_bank/internal/shutdown"
+	"simple_bank/internal/tasks"
+	"simple_bank/internal/webhook"
+	"sync"
+	"time"
+)
+
+// workerStaleAfter is how long a background worker may go without finishing a batch before
+// GET /readyz reports it degraded. A batch of slow webhook endpoints can take minutes.
+const workerStaleAfter = 10 * time.Minute
+
+// runServe serves the APIs until a signal or a server failure, then drains them:
+// the hooks run in reverse, so the servers stop first, then the workers, the pool and tracing
+func runServe(ctx context.Context, configPath string, args []string) (err error) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	apis := flags.String("api", "http", "which APIs to serve: http, grpc or both")
+	withWorkers := flags.Bool("workers", false, "also run the background workers; leave off when a worker process runs them, and turn on in one instance only")
+	flags.Parse(args)
+
+	serveHTTP := *apis == "http" || *apis == "both"
+	serveGRPC := *apis == "grpc" || *apis == "both"
+	if !serveHTTP && !serveGRPC {
+		return fmt.Errorf("unknown -api %q, want http, grpc or both", *apis)
+	}
+
+	app, err := newApp(ctx, configPath)
+	if err != nil {
+		return err
+	}
+	defer closeApp(app, &err)
+	app.registerPoolMetrics()
+
+	var workers *workers
+	if *withWorkers {
+		workers, err = startWorkers(app)
+		if err != nil {
+			return err
+		}
+	}
+
+	// whichever server stops first takes the process down
+	serverErrs := make(chan error, 2)
+
+	if serveHTTP {
+		server, err := api.NewServer(app.config, app.store)
+		if err != nil {
+			return fmt.Errorf("cannot create server: %w", err)
+		}
+		if workers != nil {
+			workers.watch(server)
+		}
+		app.hooks.Add("HTTP server", server.Shutdown)
+		go func() {
+			serverErrs <- server.StartServer(app.config.ServerAddress)
+		}()
+	}
+
+	if serveGRPC {
+		grpcServer, err := gapi.NewServer(app.config, app.store)
+		if err != nil {
+			return fmt.Errorf("cannot create gRPC server: %w", err)
+		}
+		app.hooks.Add("gRPC server", grpcServer.Shutdown)
+		go func() {
+			serverErrs <- grpcServer.StartServer(app.config.GRPCServerAddress)
+		}()
+	}
+
+	select {
+	case err := <-serverErrs:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
+		slog.Info("Shutting down", "timeout", app.config.ShutdownTimeout)
+		return nil
+	}
+}
+
+// runWorker runs the background workers alone, for deployments that scale them apart from the APIs
+func runWorker(ctx context.Context, configPath string, args []string) (err error) {
+	flags := flag.NewFlagSet("worker", flag.ExitOnError)
+	flags.Parse(args)
+
+	app, err := newApp(ctx, configPath)
+	if err != nil {
+		return err
+	}
+	defer closeApp(app, &err)
+
+	if _, err := startWorkers(app); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	slog.Info("Shutting down", "timeout", app.config.ShutdownTimeout)
+	return nil
+}
+
+// workers are the background loops, sharing the pool of the app they run in
+type workers struct {
+	dispatcher *outbox.Dispatcher
+	deliverer  *webhook.Deliverer
+	jobs       *jobs.Worker
+}
+
+// startWorkers starts the outbox dispatcher, webhook deliverer and job worker.
+// They outlive the signal until their hook runs, so requests still draining can enqueue work.
+func startWorkers(app *app) (*workers, error) {
+	publisher, err := outbox.NewPublisher(app.config.OutboxPublisher, app.config.OutboxTarget)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create outbox publisher: %w", err)
+	}
+	// merchants hear about the same events through their webhooks
+	publisher = outbox.MultiPublisher{publisher, webhook.NewFanout(app.store)}
+
+	mailer, err := mail.NewMailer(app.config.Mailer, app.config.MailerTarget, app.config.MailFrom)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create mailer: %w", err)
+	}
+
+	w := &workers{
+		dispatcher: outbox.NewDispatcher(app.store, publisher, app.config.OutboxPollInterval, 0),
+		deliverer:  webhook.NewDeliverer(app.store, webhook.NewHTTPClient(10*time.Second)),
+		jobs:       jobs.NewWorker(app.store, jobs.DefaultConcurrency, jobs.DefaultPollInterval),
+	}
+	w.dispatcher.Heartbeat = health.NewHeartbeat(workerStaleAfter)
+	w.deliverer.Heartbeat = health.NewHeartbeat(workerStaleAfter)
+	w.jobs.Heartbeat = health.NewHeartbeat(workerStaleAfter)
+	tasks.NewEmailer(app.store, mailer, app.config.AppBaseURL).Register(w.jobs)
+
+	ctx, stop := context.WithCancel(context.Background())
+	var running sync.WaitGroup
+	running.Go(func() { w.dispatcher.Run(ctx) })
+	running.Go(func() { w.deliverer.Run(ctx, webhook.DefaultPollInterval) })
+	running.Go(func() { w.jobs.Run(ctx) })
+
+	app.hooks.Add("background workers", func(ctx context.Context) error {
+		stop()
+		return shutdown.Wait(ctx, &running)
+	})
+	return w, nil
+}
+
+// watch adds the heartbeats of the workers to the readiness checks of server
+func (w *workers) watch(server *api.Server) {
+	server.WatchWorker("outbox", w.dispatcher.Heartbeat)
+	server.WatchWorker("webhooks", w.deliverer.Heartbeat)
+	server.WatchWorker("jobs", w.jobs.Heartbeat)
+}
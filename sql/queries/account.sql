@@ -14,6 +14,14 @@ ORDER BY id
 LIMIT $1
 OFFSET $2;
 
+-- name: ListAccountsByHolder :many
+SELECT accounts.* FROM accounts
+JOIN account_holders ON account_holders.account_id = accounts.id
+WHERE account_holders.username = $1
+ORDER BY accounts.id
+LIMIT $2
+OFFSET $3;
+
 -- name: CreateAccount :one
 INSERT INTO accounts (
   owner, balance, currency, country_code
@@ -38,6 +46,20 @@ SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = sqlc.arg(status),
+    frozen_by = sqlc.narg(frozen_by),
+    closed_at = CASE WHEN sqlc.arg(status)::varchar = 'closed' THEN now() ELSE closed_at END,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
 -- name: DeleteAccount :exec
 DELETE FROM accounts 
 WHERE id = $1;
+
+
+-- name: CountAccountsByOwner :one
+SELECT count(*) FROM accounts
+WHERE owner = $1 AND status <> 'closed';
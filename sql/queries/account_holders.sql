@@ -0,0 +1,39 @@
+-- ACCOUNT HOLDERS
+-- name: CreateAccountHolder :one
+INSERT INTO account_holders (
+  account_id, username, role
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetAccountHolder :one
+SELECT * FROM account_holders
+WHERE account_id = $1 AND username = $2 LIMIT 1;
+
+-- name: ListAccountHolders :many
+SELECT * FROM account_holders
+WHERE account_id = $1
+ORDER BY created_at;
+
+-- name: CountAccountOwners :one
+SELECT count(*) FROM account_holders
+WHERE account_id = $1 AND role = 'owner';
+
+-- name: DeleteAccountHolder :one
+DELETE FROM account_holders
+WHERE account_id = $1 AND username = $2
+RETURNING *;
+
+-- name: CreateAccountHolderEvent :one
+INSERT INTO account_holder_events (
+  account_id, username, role, action, actor
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListAccountHolderEvents :many
+SELECT * FROM account_holder_events
+WHERE account_id = $1
+ORDER BY id;
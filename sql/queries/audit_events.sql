@@ -0,0 +1,17 @@
+-- AUDIT EVENTS
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+  actor, action, resource_type, resource_id, before, after, ip, request_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_events
+WHERE (sqlc.narg(actor)::varchar IS NULL OR actor = sqlc.narg(actor))
+  AND (sqlc.narg(resource_type)::varchar IS NULL OR resource_type = sqlc.narg(resource_type))
+  AND (sqlc.narg(resource_id)::varchar IS NULL OR resource_id = sqlc.narg(resource_id))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
@@ -0,0 +1,40 @@
+-- BENEFICIARIES
+-- name: CreateBeneficiary :one
+INSERT INTO beneficiaries (
+  username, nickname, account_id
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetBeneficiary :one
+SELECT * FROM beneficiaries
+WHERE id = $1 AND username = $2 LIMIT 1;
+
+-- name: GetBeneficiaryByAccount :one
+SELECT * FROM beneficiaries
+WHERE username = $1 AND account_id = $2 LIMIT 1;
+
+-- name: ListBeneficiaries :many
+SELECT * FROM beneficiaries
+WHERE username = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateBeneficiaryNickname :one
+UPDATE beneficiaries
+SET nickname = $3
+WHERE id = $1 AND username = $2
+RETURNING *;
+
+-- name: VerifyBeneficiary :one
+UPDATE beneficiaries
+SET verified = true
+WHERE id = $1 AND username = $2
+RETURNING *;
+
+-- name: DeleteBeneficiary :one
+DELETE FROM beneficiaries
+WHERE id = $1 AND username = $2
+RETURNING *;
@@ -18,4 +18,10 @@ RETURNING *;
 
 -- name: DeleteEntry :exec
 DELETE FROM entries
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+-- name: ListEntries :many
+SELECT * FROM entries
+WHERE (sqlc.narg(account_id)::bigint IS NULL OR account_id = sqlc.narg(account_id))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
@@ -0,0 +1,67 @@
+-- JOBS
+-- name: EnqueueJob :one
+INSERT INTO jobs (
+  kind, payload, unique_key, max_attempts, run_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (unique_key) WHERE status IN ('pending', 'running') DO NOTHING
+RETURNING *;
+
+-- name: GetJob :one
+SELECT * FROM jobs
+WHERE id = $1 LIMIT 1;
+
+-- name: ClaimJobs :many
+UPDATE jobs
+SET status = 'running',
+    attempts = attempts + 1,
+    locked_at = now()
+WHERE id IN (
+  SELECT id FROM jobs AS pending
+  WHERE pending.status = 'pending'
+    AND pending.run_at <= now()
+    AND pending.kind = ANY(sqlc.arg(kinds)::varchar[])
+  ORDER BY pending.run_at, pending.id
+  LIMIT sqlc.arg('limit')
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteJob :exec
+UPDATE jobs
+SET status = 'succeeded',
+    locked_at = NULL,
+    finished_at = now()
+WHERE id = $1;
+
+-- name: RetryJob :exec
+UPDATE jobs
+SET status = 'pending',
+    locked_at = NULL,
+    run_at = sqlc.arg(run_at),
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: FailJob :exec
+UPDATE jobs
+SET status = 'failed',
+    locked_at = NULL,
+    last_error = sqlc.arg(last_error),
+    finished_at = now()
+WHERE id = sqlc.arg(id);
+
+-- name: RequeueStaleJobs :execrows
+UPDATE jobs
+SET status = 'pending',
+    locked_at = NULL
+WHERE status = 'running'
+  AND locked_at < sqlc.arg(locked_before);
+
+-- name: ListJobs :many
+SELECT * FROM jobs
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(kind)::varchar IS NULL OR kind = sqlc.narg(kind))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
@@ -0,0 +1,58 @@
+-- LEDGER ADJUSTMENTS
+-- name: ListGLAccounts :many
+SELECT * FROM gl_accounts
+ORDER BY code, currency;
+
+-- name: GetGLAccountForUpdate :one
+SELECT * FROM gl_accounts
+WHERE code = $1 AND currency = $2 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: AddGLAccountBalance :one
+UPDATE gl_accounts
+SET balance = balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateGLEntry :one
+INSERT INTO gl_entries (
+  gl_account_id, amount
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: CreateLedgerAdjustment :one
+INSERT INTO ledger_adjustments (
+  account_id, gl_code, amount, reason_code, note, requested_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetLedgerAdjustment :one
+SELECT * FROM ledger_adjustments
+WHERE id = $1 LIMIT 1;
+
+-- name: GetLedgerAdjustmentForUpdate :one
+SELECT * FROM ledger_adjustments
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListLedgerAdjustments :many
+SELECT * FROM ledger_adjustments
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(account_id)::bigint IS NULL OR account_id = sqlc.narg(account_id))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: DecideLedgerAdjustment :one
+UPDATE ledger_adjustments
+SET status = $2,
+    decided_by = $3,
+    entry_id = $4,
+    gl_entry_id = $5,
+    decided_at = now()
+WHERE id = $1
+RETURNING *;
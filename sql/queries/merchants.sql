@@ -25,3 +25,15 @@ WHERE id = $1;
 -- name: DeleteMerchant :exec
 DELETE FROM merchants
 WHERE id = $1;
+
+
+-- name: ListMerchantsByAdminAccount :many
+SELECT * FROM merchants
+WHERE admin_id = $1
+ORDER BY id;
+
+-- name: ListMerchantIDsByOrder :many
+SELECT DISTINCT products.merchant_id FROM order_items
+JOIN products ON products.id = order_items.product_id
+WHERE order_items.order_id = $1
+ORDER BY products.merchant_id;
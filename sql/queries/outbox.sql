@@ -0,0 +1,32 @@
+-- OUTBOX
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox (
+  aggregate_type, aggregate_id, event_type, payload
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListPendingOutboxEvents :many
+SELECT * FROM outbox
+WHERE published_at IS NULL
+ORDER BY id
+LIMIT $1;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE outbox
+SET published_at = now()
+WHERE id = $1;
+
+-- name: RecordOutboxFailure :exec
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = $2
+WHERE id = $1;
+
+-- name: ListOutboxEvents :many
+SELECT * FROM outbox
+WHERE (sqlc.narg(published)::bool IS NULL OR (published_at IS NOT NULL) = sqlc.narg(published))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
@@ -0,0 +1,63 @@
+-- ROLES AND PERMISSIONS
+-- name: ListUserPermissions :many
+SELECT DISTINCT role_permissions.permission FROM user_roles
+JOIN role_permissions ON role_permissions.role = user_roles.role
+WHERE user_roles.username = $1
+ORDER BY role_permissions.permission;
+
+-- name: ListUserRoles :many
+SELECT * FROM user_roles
+WHERE username = $1
+ORDER BY role;
+
+-- name: GrantUserRole :one
+INSERT INTO user_roles (
+  username, role, granted_by
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (username, role) DO UPDATE
+SET granted_by = user_roles.granted_by
+RETURNING *;
+
+-- name: RevokeUserRole :one
+DELETE FROM user_roles
+WHERE username = $1 AND role = $2
+RETURNING *;
+
+-- name: ListRoles :many
+SELECT * FROM roles
+ORDER BY name;
+
+-- name: ListRolePermissions :many
+SELECT * FROM role_permissions
+ORDER BY role, permission;
+
+-- MERCHANT ADMINISTRATORS
+-- name: CreateMerchantAdmin :one
+INSERT INTO merchant_admins (
+  merchant_id, username
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: IsMerchantAdmin :one
+SELECT EXISTS (
+  SELECT 1 FROM merchant_admins
+  WHERE merchant_id = $1 AND username = $2
+);
+
+-- name: ListMerchantAdmins :many
+SELECT * FROM merchant_admins
+WHERE merchant_id = $1
+ORDER BY username;
+
+-- name: DeleteMerchantAdmin :one
+DELETE FROM merchant_admins
+WHERE merchant_id = $1 AND username = $2
+RETURNING *;
+
+-- name: CountMerchantAdminsByUser :one
+SELECT count(*) FROM merchant_admins
+WHERE username = $1;
@@ -0,0 +1,31 @@
+-- RECONCILIATION
+-- name: ListAccountBalanceMismatches :many
+SELECT a.id, a.owner, a.currency, a.balance, COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id;
+
+-- name: ListGLBalanceMismatches :many
+SELECT g.id, g.code, g.currency, g.balance, COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM gl_accounts g
+LEFT JOIN gl_entries e ON e.gl_account_id = g.id
+GROUP BY g.id
+HAVING g.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY g.id;
+
+-- name: ListCurrencyImbalances :many
+SELECT currency, SUM(amount)::bigint AS net
+FROM (
+  SELECT a.currency, e.amount
+  FROM entries e
+  JOIN accounts a ON a.id = e.account_id
+  UNION ALL
+  SELECT g.currency, e.amount
+  FROM gl_entries e
+  JOIN gl_accounts g ON g.id = e.gl_account_id
+) ledger
+GROUP BY currency
+HAVING SUM(amount) <> 0
+ORDER BY currency;
@@ -0,0 +1,67 @@
+-- TWO-FACTOR AUTHENTICATION
+-- name: UpsertUserTOTP :one
+INSERT INTO user_totps (
+  username, secret
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (username) DO UPDATE
+SET secret = EXCLUDED.secret,
+    last_used_step = 0,
+    created_at = now()
+WHERE user_totps.confirmed_at IS NULL
+RETURNING *;
+
+-- name: GetUserTOTP :one
+SELECT * FROM user_totps
+WHERE username = $1 LIMIT 1;
+
+-- name: ConfirmUserTOTP :one
+UPDATE user_totps
+SET confirmed_at = now(),
+    last_used_step = sqlc.arg(step)
+WHERE username = sqlc.arg(username) AND confirmed_at IS NULL
+RETURNING *;
+
+-- name: UseUserTOTPStep :one
+UPDATE user_totps
+SET last_used_step = sqlc.arg(step)
+WHERE username = sqlc.arg(username)
+  AND confirmed_at IS NOT NULL
+  AND last_used_step < sqlc.arg(step)
+RETURNING *;
+
+-- name: RecordFailedOTP :one
+-- the last allowed failure locks the user out and starts the count over
+UPDATE user_totps
+SET failed_attempts = CASE WHEN failed_attempts + 1 >= sqlc.arg(max_attempts)::int THEN 0 ELSE failed_attempts + 1 END,
+    locked_until = CASE WHEN failed_attempts + 1 >= sqlc.arg(max_attempts)::int THEN sqlc.arg(locked_until)::timestamptz ELSE locked_until END
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: ResetFailedOTPs :exec
+UPDATE user_totps
+SET failed_attempts = 0
+WHERE username = $1;
+
+-- name: DeleteUserTOTP :exec
+DELETE FROM user_totps
+WHERE username = $1;
+
+-- name: CreateRecoveryCode :one
+INSERT INTO recovery_codes (
+  username, code_hash
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: UseRecoveryCode :one
+UPDATE recovery_codes
+SET used_at = now()
+WHERE username = $1 AND code_hash = $2 AND used_at IS NULL
+RETURNING *;
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes
+WHERE username = $1;
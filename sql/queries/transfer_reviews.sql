@@ -0,0 +1,33 @@
+-- TRANSFER REVIEWS
+-- name: CreateTransferReview :one
+INSERT INTO transfer_reviews (
+  from_account_id, to_account_id, amount, reasons
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetTransferReview :one
+SELECT * FROM transfer_reviews
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferReviewForUpdate :one
+SELECT * FROM transfer_reviews
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListTransferReviews :many
+SELECT * FROM transfer_reviews
+WHERE status = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateTransferReview :one
+UPDATE transfer_reviews
+SET status = $2,
+    transfer_id = $3,
+    reviewed_by = $4,
+    reviewed_at = now()
+WHERE id = $1
+RETURNING *;
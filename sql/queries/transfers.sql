@@ -17,4 +17,18 @@ RETURNING *;
 
 -- name: DeleteTransfer :exec
 DELETE FROM transfers
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: CountTransfersBetweenAccounts :one
+SELECT count(*) FROM transfers
+WHERE from_account_id = $1 AND to_account_id = $2;
+
+-- name: CountTransfersFromAccountSince :one
+SELECT count(*) FROM transfers
+WHERE from_account_id = sqlc.arg(from_account_id)
+  AND created_at >= sqlc.arg(since);
+
+-- name: CountTransfersToCountry :one
+SELECT count(*) FROM transfers t
+JOIN accounts a ON a.id = t.to_account_id
+WHERE t.from_account_id = $1 AND a.country_code = $2;
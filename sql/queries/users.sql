@@ -13,4 +13,45 @@ RETURNING *;
 
 -- name: GetUser :one
 SELECT * FROM users
-WHERE username = $1 LIMIT 1;
\ No newline at end of file
+WHERE username = $1 LIMIT 1;
+
+-- name: UpdateUserKYC :one
+UPDATE users
+SET date_of_birth = sqlc.arg(date_of_birth),
+    address = sqlc.arg(address),
+    national_id = sqlc.arg(national_id),
+    kyc_status = sqlc.arg(kyc_status)::varchar,
+    kyc_tier = sqlc.arg(kyc_tier),
+    kyc_verified_at = CASE WHEN sqlc.arg(kyc_status)::varchar = 'verified' THEN now() ELSE NULL END,
+    updated_at = now()
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: MarkUserEmailVerified :one
+UPDATE users
+SET is_email_verified = true,
+    updated_at = now()
+WHERE username = sqlc.arg(username) AND email = sqlc.arg(email)
+RETURNING *;
+
+-- name: GetUserPasswordChangedAt :one
+SELECT password_changed_at FROM users
+WHERE username = $1 LIMIT 1;
+
+-- name: UpdateUserPassword :one
+UPDATE users
+SET hashed_password = sqlc.arg(hashed_password),
+    password_changed_at = sqlc.arg(password_changed_at),
+    updated_at = now()
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: SearchUsers :many
+SELECT * FROM users
+WHERE sqlc.narg(query)::varchar IS NULL
+   OR username ILIKE '%' || sqlc.narg(query) || '%'
+   OR email ILIKE '%' || sqlc.narg(query) || '%'
+   OR full_name ILIKE '%' || sqlc.narg(query) || '%'
+ORDER BY username
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
@@ -0,0 +1,17 @@
+-- VERIFY EMAILS
+-- name: CreateVerifyEmail :one
+INSERT INTO verify_emails (
+  username, email, secret_code
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: UseVerifyEmail :one
+UPDATE verify_emails
+SET is_used = true
+WHERE id = sqlc.arg(id)
+  AND secret_code = sqlc.arg(secret_code)
+  AND is_used = false
+  AND expired_at > now()
+RETURNING *;
@@ -0,0 +1,81 @@
+-- WEBHOOK ENDPOINTS
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (
+  merchant_id, url, secret, event_types
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListWebhookEndpoints :many
+SELECT * FROM webhook_endpoints
+WHERE merchant_id = $1
+ORDER BY id;
+
+-- name: DeleteWebhookEndpoint :one
+DELETE FROM webhook_endpoints
+WHERE id = $1 AND merchant_id = $2
+RETURNING *;
+
+-- name: ListWebhookEndpointsForEvent :many
+SELECT * FROM webhook_endpoints
+WHERE merchant_id = ANY(sqlc.arg(merchant_ids)::bigint[])
+  AND active
+  AND (cardinality(event_types) = 0 OR sqlc.arg(event_type)::text = ANY(event_types))
+ORDER BY id;
+
+-- WEBHOOK DELIVERIES
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+  endpoint_id, event_id, event_type, payload
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (endpoint_id, event_id) DO NOTHING;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- a claimed delivery isn't due again until lease_until, so concurrent deliverers never send it twice;
+-- recording the attempt sets its real next attempt
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg(lease_until)
+FROM webhook_endpoints
+WHERE webhook_endpoints.id = webhook_deliveries.endpoint_id
+  AND webhook_deliveries.id IN (
+    SELECT id FROM webhook_deliveries AS due
+    WHERE due.status = 'pending'
+      AND due.next_attempt_at <= now()
+    ORDER BY due.id
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING sqlc.embed(webhook_deliveries), webhook_endpoints.url, webhook_endpoints.secret;
+
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET status = sqlc.arg(status),
+    attempts = attempts + 1,
+    next_attempt_at = sqlc.arg(next_attempt_at),
+    last_error = sqlc.narg(last_error),
+    response_status = sqlc.narg(response_status),
+    delivered_at = CASE WHEN sqlc.arg(status)::varchar = 'succeeded' THEN now() ELSE NULL END
+WHERE id = sqlc.arg(id);
+
+-- name: ListWebhookDeliveries :many
+SELECT webhook_deliveries.* FROM webhook_deliveries
+JOIN webhook_endpoints ON webhook_endpoints.id = webhook_deliveries.endpoint_id
+WHERE webhook_endpoints.merchant_id = sqlc.arg(merchant_id)
+  AND (sqlc.narg(status)::varchar IS NULL OR webhook_deliveries.status = sqlc.narg(status))
+ORDER BY webhook_deliveries.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: RetryWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending',
+    next_attempt_at = now()
+FROM webhook_endpoints
+WHERE webhook_deliveries.id = sqlc.arg(id)
+  AND webhook_endpoints.id = webhook_deliveries.endpoint_id
+  AND webhook_endpoints.merchant_id = sqlc.arg(merchant_id)
+  AND webhook_deliveries.status = 'dead'
+RETURNING webhook_deliveries.*;
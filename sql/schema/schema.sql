@@ -6,6 +6,13 @@ CREATE TABLE "users"(
 	"hashed_password" varchar NOT NULL,
 	"full_name" varchar NOT NULL,
 	"email" varchar NOT NULL,
+	"date_of_birth" date,
+	"address" varchar,
+	"national_id" varchar,
+	"kyc_status" varchar NOT NULL DEFAULT 'unverified' CHECK (kyc_status IN ('unverified', 'verified', 'rejected')),
+	"kyc_tier" int NOT NULL DEFAULT 0,
+	"kyc_verified_at" timestamptz,
+	"is_email_verified" bool NOT NULL DEFAULT false,
 	"password_changed_at" timestamptz DEFAULT (now()),
 	"created_at" timestamptz NOT NULL DEFAULT (now()),
 	"updated_at" timestamptz DEFAULT (now())
@@ -16,6 +23,9 @@ CREATE TABLE "accounts" (
 	"balance" bigint NOT NULL,
 	"currency" varchar NOT NULL,
 	"country_code" int NOT NULL,
+	"status" varchar NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'frozen', 'debit_blocked', 'closed')),
+	"frozen_by" varchar CHECK (frozen_by IN ('customer', 'staff')),
+	"closed_at" timestamptz,
 	"created_at" timestamptz NOT NULL DEFAULT (now()),
 	"updated_at" timestamptz DEFAULT (now())
 );
@@ -33,8 +43,13 @@ CREATE TABLE "order_items" ("order_id" int, "product_id" int NOT NULL, "quantity
 
 CREATE TABLE "orders" ("id" int PRIMARY KEY NOT NULL, "user_id" int, "status" varchar, "created_at" timestamptz NOT NULL DEFAULT (now()));
 
+CREATE UNIQUE INDEX ON "users" ("username");
+
 CREATE INDEX ON "accounts" ("owner");
 
+-- one open account per owner and currency; closed ones are kept for their history
+CREATE UNIQUE INDEX "owner_currency_key" ON "accounts" ("owner", "currency") WHERE "status" <> 'closed';
+
 CREATE INDEX ON "entries" ("account_id");
 
 CREATE INDEX ON "transfers" ("from_account_id");
@@ -47,14 +62,20 @@ COMMENT ON COLUMN "entries"."amount" IS 'can be negative';
 
 COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
 
+COMMENT ON COLUMN "accounts"."status" IS 'active, frozen, debit_blocked or closed';
+
+COMMENT ON COLUMN "accounts"."frozen_by" IS 'customer or staff while the account is frozen; only staff can lift a staff freeze';
+
+COMMENT ON COLUMN "users"."kyc_tier" IS 'limits account count and transfer size, see internal/kyc';
+
 ALTER TABLE "entries"
-ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
 
 ALTER TABLE "transfers"
-ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
 
 ALTER TABLE "transfers"
-ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
 
 ALTER TABLE "merchants"
 ADD FOREIGN KEY ("country_code") REFERENCES "countries" ("code") ON DELETE CASCADE;
@@ -69,4 +90,444 @@ ALTER TABLE "order_items"
 ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
 
 ALTER TABLE "order_items"
-ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE;
\ No newline at end of file
+ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "transfer_reviews" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"from_account_id" bigint NOT NULL,
+	"to_account_id" bigint NOT NULL,
+	"amount" bigint NOT NULL CHECK (amount > 0),
+	"reasons" text[] NOT NULL DEFAULT '{}',
+	"status" varchar NOT NULL DEFAULT 'pending_review',
+	"transfer_id" bigint,
+	"reviewed_by" varchar,
+	"reviewed_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfer_reviews" ("status");
+
+COMMENT ON COLUMN "transfer_reviews"."status" IS 'pending_review, approved or rejected';
+
+ALTER TABLE "transfer_reviews"
+ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "transfer_reviews"
+ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "transfer_reviews"
+ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+
+CREATE TABLE "account_holders" (
+	"account_id" bigint NOT NULL,
+	"username" varchar NOT NULL,
+	"role" varchar NOT NULL CHECK (role IN ('owner', 'signatory', 'viewer')),
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	PRIMARY KEY ("account_id", "username")
+);
+
+CREATE TABLE "account_holder_events" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"account_id" bigint NOT NULL,
+	"username" varchar NOT NULL,
+	"role" varchar NOT NULL,
+	"action" varchar NOT NULL CHECK (action IN ('added', 'removed')),
+	"actor" varchar NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "account_holders" ("username");
+
+CREATE INDEX ON "account_holder_events" ("account_id");
+
+COMMENT ON COLUMN "account_holders"."role" IS 'owner, signatory or viewer';
+
+COMMENT ON COLUMN "account_holder_events"."actor" IS 'username of the holder who made the change';
+
+ALTER TABLE "account_holders"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "account_holders"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_holder_events"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+
+CREATE TABLE "beneficiaries" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"username" varchar NOT NULL,
+	"nickname" varchar NOT NULL,
+	"account_id" bigint NOT NULL,
+	"verified" boolean NOT NULL DEFAULT false,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "beneficiaries" ("username", "account_id");
+
+CREATE UNIQUE INDEX ON "beneficiaries" ("username", "nickname");
+
+COMMENT ON COLUMN "beneficiaries"."username" IS 'user who saved the payee';
+
+COMMENT ON COLUMN "beneficiaries"."verified" IS 'the user confirmed the payee, transfers to it skip the new payee confirmation';
+
+ALTER TABLE "beneficiaries"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "beneficiaries"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+
+CREATE TABLE "audit_events" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"actor" varchar NOT NULL,
+	"action" varchar NOT NULL,
+	"resource_type" varchar NOT NULL,
+	"resource_id" varchar NOT NULL,
+	"before" jsonb,
+	"after" jsonb,
+	"ip" varchar NOT NULL DEFAULT '',
+	"request_id" varchar NOT NULL DEFAULT '',
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_events" ("actor");
+
+CREATE INDEX ON "audit_events" ("resource_type", "resource_id");
+
+COMMENT ON TABLE "audit_events" IS 'append-only, updates, deletes and truncates are rejected by audit_events_immutable';
+
+COMMENT ON COLUMN "audit_events"."actor" IS 'username that made the change, or anonymous';
+
+CREATE FUNCTION audit_events_immutable() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_no_update
+BEFORE UPDATE OR DELETE ON "audit_events"
+FOR EACH ROW EXECUTE FUNCTION audit_events_immutable();
+
+CREATE TRIGGER audit_events_no_truncate
+BEFORE TRUNCATE ON "audit_events"
+FOR EACH STATEMENT EXECUTE FUNCTION audit_events_immutable();
+
+
+CREATE TABLE "outbox" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"aggregate_type" varchar NOT NULL,
+	"aggregate_id" varchar NOT NULL,
+	"event_type" varchar NOT NULL,
+	"payload" jsonb NOT NULL,
+	"attempts" int NOT NULL DEFAULT 0,
+	"last_error" varchar,
+	"published_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "outbox" ("id") WHERE "published_at" IS NULL;
+
+COMMENT ON TABLE "outbox" IS 'domain events written in the same transaction as the change, published in id order by the dispatcher';
+
+
+CREATE TABLE "webhook_endpoints" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"merchant_id" bigint NOT NULL,
+	"url" varchar NOT NULL,
+	"secret" varchar NOT NULL,
+	"event_types" text[] NOT NULL DEFAULT '{}',
+	"active" boolean NOT NULL DEFAULT true,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"endpoint_id" bigint NOT NULL,
+	"event_id" bigint NOT NULL,
+	"event_type" varchar NOT NULL,
+	"payload" jsonb NOT NULL,
+	"status" varchar NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'dead')),
+	"attempts" int NOT NULL DEFAULT 0,
+	"next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+	"last_error" varchar,
+	"response_status" int,
+	"delivered_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "webhook_endpoints" ("merchant_id");
+
+CREATE UNIQUE INDEX ON "webhook_deliveries" ("endpoint_id", "event_id");
+
+CREATE INDEX ON "webhook_deliveries" ("next_attempt_at") WHERE "status" = 'pending';
+
+COMMENT ON COLUMN "webhook_endpoints"."secret" IS 'HMAC-SHA256 key used to sign deliveries';
+
+COMMENT ON COLUMN "webhook_endpoints"."event_types" IS 'event types to deliver, empty for all';
+
+COMMENT ON COLUMN "webhook_deliveries"."event_id" IS 'outbox event that triggered the delivery';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or dead once retries are exhausted';
+
+ALTER TABLE "webhook_endpoints"
+ADD FOREIGN KEY ("merchant_id") REFERENCES "merchants" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_deliveries"
+ADD FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id") ON DELETE CASCADE;
+
+
+CREATE TABLE "jobs" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"kind" varchar NOT NULL,
+	"payload" jsonb NOT NULL DEFAULT '{}',
+	"status" varchar NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+	"unique_key" varchar,
+	"attempts" int NOT NULL DEFAULT 0,
+	"max_attempts" int NOT NULL DEFAULT 5,
+	"run_at" timestamptz NOT NULL DEFAULT (now()),
+	"locked_at" timestamptz,
+	"last_error" varchar,
+	"finished_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "jobs" ("run_at") WHERE "status" = 'pending';
+
+CREATE UNIQUE INDEX "jobs_unique_key_idx" ON "jobs" ("unique_key") WHERE "status" IN ('pending', 'running');
+
+CREATE INDEX "jobs_status_kind_idx" ON "jobs" ("status", "kind");
+
+COMMENT ON COLUMN "jobs"."unique_key" IS 'at most one pending or running job per key';
+
+COMMENT ON COLUMN "jobs"."status" IS 'pending, running, succeeded or failed once max_attempts is used up';
+
+CREATE TABLE "verify_emails" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"username" varchar NOT NULL,
+	"email" varchar NOT NULL,
+	"secret_code" varchar NOT NULL,
+	"is_used" bool NOT NULL DEFAULT false,
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	"expired_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
+);
+
+CREATE INDEX ON "verify_emails" ("username");
+
+COMMENT ON COLUMN "verify_emails"."email" IS 'address the code was sent to, the user may change theirs later';
+
+ALTER TABLE "verify_emails"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+CREATE TABLE "password_resets" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"username" varchar NOT NULL,
+	"token_hash" varchar NOT NULL,
+	"used_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	"expired_at" timestamptz NOT NULL DEFAULT (now() + interval '30 minutes')
+);
+
+CREATE UNIQUE INDEX ON "password_resets" ("token_hash");
+
+CREATE INDEX ON "password_resets" ("username");
+
+COMMENT ON COLUMN "password_resets"."token_hash" IS 'SHA-256 of the emailed token, the token itself is never stored';
+
+ALTER TABLE "password_resets"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+CREATE TABLE "user_totps" (
+	"username" varchar PRIMARY KEY NOT NULL,
+	"secret" varchar NOT NULL,
+	"confirmed_at" timestamptz,
+	"last_used_step" bigint NOT NULL DEFAULT 0,
+	"failed_attempts" int NOT NULL DEFAULT 0,
+	"locked_until" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "recovery_codes" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"username" varchar NOT NULL,
+	"code_hash" varchar NOT NULL,
+	"used_at" timestamptz,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "recovery_codes" ("username", "code_hash");
+
+COMMENT ON COLUMN "user_totps"."secret" IS 'base32 TOTP key, sealed with AES-GCM under TOTP_ENCRYPTION_KEY as codes need it in the clear';
+
+COMMENT ON COLUMN "user_totps"."confirmed_at" IS 'set once the user proved their app has the secret, a second factor is only required after that';
+
+COMMENT ON COLUMN "user_totps"."last_used_step" IS 'time step of the last accepted code, a code is never accepted twice';
+
+COMMENT ON COLUMN "user_totps"."failed_attempts" IS 'invalid codes since the last valid one or lockout';
+
+COMMENT ON COLUMN "user_totps"."locked_until" IS 'no code is accepted before then, after too many invalid ones';
+
+COMMENT ON COLUMN "recovery_codes"."code_hash" IS 'SHA-256 of the normalized code';
+
+ALTER TABLE "user_totps"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "recovery_codes"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+CREATE TABLE "roles" (
+	"name" varchar PRIMARY KEY NOT NULL,
+	"description" varchar NOT NULL DEFAULT ''
+);
+
+CREATE TABLE "permissions" (
+	"name" varchar PRIMARY KEY NOT NULL,
+	"description" varchar NOT NULL DEFAULT ''
+);
+
+CREATE TABLE "role_permissions" (
+	"role" varchar NOT NULL,
+	"permission" varchar NOT NULL,
+	PRIMARY KEY ("role", "permission")
+);
+
+CREATE TABLE "user_roles" (
+	"username" varchar NOT NULL,
+	"role" varchar NOT NULL,
+	"granted_by" varchar NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	PRIMARY KEY ("username", "role")
+);
+
+CREATE TABLE "merchant_admins" (
+	"merchant_id" bigint NOT NULL,
+	"username" varchar NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	PRIMARY KEY ("merchant_id", "username")
+);
+
+CREATE INDEX ON "merchant_admins" ("username");
+
+COMMENT ON COLUMN "merchants"."admin_id" IS 'account that receives the merchant''s payments, the users administering the merchant are in merchant_admins';
+
+COMMENT ON COLUMN "user_roles"."granted_by" IS 'username of the admin who granted the role, or system';
+
+ALTER TABLE "role_permissions"
+ADD FOREIGN KEY ("role") REFERENCES "roles" ("name") ON DELETE CASCADE;
+
+ALTER TABLE "role_permissions"
+ADD FOREIGN KEY ("permission") REFERENCES "permissions" ("name") ON DELETE CASCADE;
+
+ALTER TABLE "user_roles"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "user_roles"
+ADD FOREIGN KEY ("role") REFERENCES "roles" ("name") ON DELETE CASCADE;
+
+ALTER TABLE "merchant_admins"
+ADD FOREIGN KEY ("merchant_id") REFERENCES "merchants" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "merchant_admins"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+-- the roles and permissions the code checks for, which user creation and role grants refer to
+INSERT INTO "roles" ("name", "description") VALUES
+	('customer', 'holds accounts, saves payees and sends transfers'),
+	('merchant_admin', 'manages the merchants listed for them in merchant_admins'),
+	('bank_staff', 'reviews held transfers and reads the audit log'),
+	('bank_admin', 'manages users and their roles');
+
+INSERT INTO "permissions" ("name", "description") VALUES
+	('banking.use', 'open accounts, manage payees and send transfers'),
+	('merchants.manage', 'manage webhooks of administered merchants'),
+	('transfer_reviews.decide', 'list, approve and reject held transfers'),
+	('audit_events.read', 'search the audit log'),
+	('users.manage', 'manage users'),
+	('roles.manage', 'grant and revoke roles'),
+	('back_office.access', 'look up users, accounts, the ledger, outbox events and jobs under /admin'),
+	('accounts.freeze', 'freeze and unfreeze any account'),
+	('ledger.adjust', 'request manual adjustments'),
+	('ledger.approve', 'approve or reject manual adjustments requested by someone else');
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+	('customer', 'banking.use'),
+	('merchant_admin', 'merchants.manage'),
+	('bank_staff', 'transfer_reviews.decide'),
+	('bank_staff', 'audit_events.read'),
+	('bank_staff', 'back_office.access'),
+	('bank_staff', 'accounts.freeze'),
+	('bank_staff', 'ledger.adjust'),
+	('bank_admin', 'transfer_reviews.decide'),
+	('bank_admin', 'audit_events.read'),
+	('bank_admin', 'users.manage'),
+	('bank_admin', 'roles.manage'),
+	('bank_admin', 'back_office.access'),
+	('bank_admin', 'accounts.freeze'),
+	('bank_admin', 'ledger.adjust'),
+	('bank_admin', 'ledger.approve');
+
+CREATE TABLE "gl_accounts" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"code" varchar NOT NULL CHECK (code IN ('suspense', 'adjustments')),
+	"currency" varchar NOT NULL,
+	"balance" bigint NOT NULL DEFAULT 0,
+	"created_at" timestamptz NOT NULL DEFAULT (now()),
+	UNIQUE ("code", "currency")
+);
+
+CREATE TABLE "gl_entries" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"gl_account_id" bigint NOT NULL,
+	"amount" bigint NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "ledger_adjustments" (
+	"id" bigserial PRIMARY KEY NOT NULL,
+	"account_id" bigint NOT NULL,
+	"gl_code" varchar NOT NULL CHECK (gl_code IN ('suspense', 'adjustments')),
+	"amount" bigint NOT NULL CHECK (amount <> 0),
+	"reason_code" varchar NOT NULL CHECK (reason_code IN ('goodwill', 'fee_refund', 'error_correction', 'chargeback', 'write_off')),
+	"note" varchar NOT NULL DEFAULT '',
+	"status" varchar NOT NULL DEFAULT 'pending_approval' CHECK (status IN ('pending_approval', 'approved', 'rejected')),
+	"requested_by" varchar NOT NULL,
+	"decided_by" varchar,
+	"decided_at" timestamptz,
+	"entry_id" bigint,
+	"gl_entry_id" bigint,
+	"created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- manual adjustments look their GL account up by code and currency
+INSERT INTO "gl_accounts" ("code", "currency") VALUES
+	('suspense', 'USD'),
+	('suspense', 'EUR'),
+	('suspense', 'CAD'),
+	('adjustments', 'USD'),
+	('adjustments', 'EUR'),
+	('adjustments', 'CAD');
+
+CREATE INDEX ON "gl_entries" ("gl_account_id");
+
+CREATE INDEX ON "ledger_adjustments" ("status");
+
+CREATE INDEX ON "ledger_adjustments" ("account_id");
+
+COMMENT ON TABLE "gl_accounts" IS 'the bank''s own ledger accounts, one per code and currency, on the other side of manual adjustments';
+
+COMMENT ON COLUMN "ledger_adjustments"."amount" IS 'credited to the customer account when positive, debited when negative; the GL account gets the opposite';
+
+COMMENT ON COLUMN "ledger_adjustments"."status" IS 'pending_approval, approved or rejected; decided_by must differ from requested_by';
+
+ALTER TABLE "gl_entries"
+ADD FOREIGN KEY ("gl_account_id") REFERENCES "gl_accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "ledger_adjustments"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "ledger_adjustments"
+ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "ledger_adjustments"
+ADD FOREIGN KEY ("gl_entry_id") REFERENCES "gl_entries" ("id");
@@ -1,23 +1,66 @@
 package util
 
-import "github.com/spf13/viper"
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 /**
  * Config - stores all configuration of the application.
  * @DBSource: The connection string for the database.
  * @ServerAddress: The host and port the server will run on.
+ * @TrustedProxies: Comma separated IPs or CIDRs of the proxies whose X-Forwarded-For is believed; none by default.
+ * @GRPCServerAddress: The host and port the gRPC server will run on.
+ * @TokenSymmetricKey: The secret used to sign access tokens (at least 32 characters).
+ * @TOTPEncryptionKey: The secret TOTP secrets are encrypted with at rest (at least 32 characters).
+ * @AccessTokenDuration: How long an access token stays valid.
+ * @OutboxPublisher: Where outbox events are published: log, file or http.
+ * @OutboxTarget: The file path or URL used by the file and http publishers.
+ * @OutboxPollInterval: How often the outbox dispatcher looks for pending events.
+ * @Mailer: How emails are sent: log, file or smtp.
+ * @MailerTarget: The directory used by the file mailer or the smtp:// URL of the SMTP server.
+ * @MailFrom: The sender address of outgoing emails.
+ * @AppBaseURL: The public URL of the API, used for links in emails.
+ * @KYCProvider: Which KYC vendor verifies identities: stub, the only one so far, verifies locally.
+ * @StepUpTransferThreshold: Transfers of this amount or more need a second factor, 0 turns step-up off.
+ * @LogLevel: The lowest level written to the JSON logs: debug, info, warn or error.
+ * @TraceExporter: Where trace spans are exported: none, stdout, file or otlp.
+ * @TraceTarget: The file path used by the file exporter or the collector URL used by the otlp exporter.
+ * @ShutdownTimeout: How long in-flight requests and background jobs get to finish on SIGTERM or SIGINT.
  *
  * Description: Values are read by viper from a config file
- * or environment variables.
+ * or environment variables. The file is optional, so containers
+ * can be configured from the environment alone.
  */
 type Config struct {
-	DBSource      string `mapstructure:"DB_SOURCE"`
-	ServerAddress string `mapstructure:"SERVER_ADDRESS"`
+	DBSource                string        `mapstructure:"DB_SOURCE"`
+	ServerAddress           string        `mapstructure:"SERVER_ADDRESS"`
+	TrustedProxies          []string      `mapstructure:"TRUSTED_PROXIES"`
+	GRPCServerAddress       string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	TokenSymmetricKey       string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	TOTPEncryptionKey       string        `mapstructure:"TOTP_ENCRYPTION_KEY"`
+	AccessTokenDuration     time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	OutboxPublisher         string        `mapstructure:"OUTBOX_PUBLISHER"`
+	OutboxTarget            string        `mapstructure:"OUTBOX_TARGET"`
+	OutboxPollInterval      time.Duration `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	Mailer                  string        `mapstructure:"MAILER"`
+	MailerTarget            string        `mapstructure:"MAILER_TARGET"`
+	MailFrom                string        `mapstructure:"MAIL_FROM"`
+	AppBaseURL              string        `mapstructure:"APP_BASE_URL"`
+	KYCProvider             string        `mapstructure:"KYC_PROVIDER"`
+	StepUpTransferThreshold int64         `mapstructure:"STEP_UP_TRANSFER_THRESHOLD"`
+	LogLevel                string        `mapstructure:"LOG_LEVEL"`
+	TraceExporter           string        `mapstructure:"TRACE_EXPORTER"`
+	TraceTarget             string        `mapstructure:"TRACE_TARGET"`
+	ShutdownTimeout         time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
 }
 
 /**
  * LoadConfig - reads configuration from file or environment variables.
- * @path: The directory path containing the config file.
+ * @path: The directory path containing the config file, which may be missing.
  *
  * Return: (config, err) The populated config struct and any error encountered.
  */
@@ -32,13 +75,22 @@ func LoadConfig(path string) (config Config, err error) {
 	/* Allow environment variables to override file values */
 	viper.AutomaticEnv()
 
-	/* Start reading the config file */
+	/* Bind every key, as AutomaticEnv only covers keys viper already knows from the file */
+	fields := reflect.TypeOf(config)
+	for i := 0; i < fields.NumField(); i++ {
+		if err = viper.BindEnv(fields.Field(i).Tag.Get("mapstructure")); err != nil {
+			return
+		}
+	}
+
+	/* Start reading the config file, going on with the environment alone when there is none */
 	err = viper.ReadInConfig()
-	if err != nil {
+	var notFound viper.ConfigFileNotFoundError
+	if err != nil && !errors.As(err, &notFound) {
 		return
 	}
 
 	/* Unmarshal the read values into the config struct */
 	err = viper.Unmarshal(&config)
 	return
-}
\ No newline at end of file
+}
@@ -14,14 +14,14 @@ func init() {
 }
 
 func RandomInt(min, max int64) int64 {
-	return min+rand.Int63n(max - min + 1)
+	return min + rand.Int63n(max-min+1)
 }
 
 func RandomString(n int) string {
 	var sb strings.Builder
 	k := len(alphabet)
 
-	for i :=0; i < n; i ++ {
+	for i := 0; i < n; i++ {
 		c := alphabet[rand.Intn(k)]
 		sb.WriteByte(c)
 	}
@@ -45,7 +45,7 @@ func RandomCurrency() string {
 }
 
 func RandomContinent() string {
-	continents := []string{"Africa","Antarctica","Asia","Australia","Europe","North America","South America" }
+	continents := []string{"Africa", "Antarctica", "Asia", "Australia", "Europe", "North America", "South America"}
 	n := len(continents)
 
 	return continents[rand.Intn(n)]
@@ -61,10 +61,15 @@ func RandomOrderStatus() string {
 func RandomProductName() string {
 	adjectives := []string{"Premium", "Deluxe", "Basic", "Advanced", "Eco-Friendly", "Smart", "Wireless", "Portable"}
 	nouns := []string{"Widget", "Gadget", "Device", "Tool", "Accessory", "System", "Kit", "Bundle"}
-	
+
 	adjective := adjectives[RandomInt(0, int64(len(adjectives)-1))]
 	noun := nouns[RandomInt(0, int64(len(nouns)-1))]
 	number := RandomInt(100, 999)
-	
+
 	return fmt.Sprintf("%s %s %d", adjective, noun, number)
-}
\ No newline at end of file
+}
+
+// RandomEmail generates a random email
+func RandomEmail() string {
+	return fmt.Sprintf("%s@email.com", RandomString(6))
+}